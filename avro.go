@@ -0,0 +1,52 @@
+package utc
+
+import (
+	"time"
+)
+
+// Avro schemas of the timestamp logical types. Use them as type of a record field, or in a union with "null" for
+// optional timestamps:
+//
+//	{"name": "created", "type": ["null", {"type": "long", "logicalType": "timestamp-millis"}], "default": null}
+const (
+	AvroTimestampMillisSchema = `{"type":"long","logicalType":"timestamp-millis"}`
+	AvroTimestampMicrosSchema = `{"type":"long","logicalType":"timestamp-micros"}`
+)
+
+// AvroMillis is the encoding of the Avro timestamp-millis logical type: the number of milliseconds since the unix
+// epoch. It is a distinct type from AvroMicros in order to prevent mixing up the two precisions.
+type AvroMillis int64
+
+// AvroMicros is the encoding of the Avro timestamp-micros logical type: the number of microseconds since the unix
+// epoch.
+type AvroMicros int64
+
+// AvroMillis returns u encoded as Avro timestamp-millis. Sub-millisecond precision is truncated.
+func (u UTC) AvroMillis() AvroMillis {
+	return AvroMillis(u.UnixMilli())
+}
+
+// AvroMicros returns u encoded as Avro timestamp-micros. Sub-microsecond precision is truncated.
+func (u UTC) AvroMicros() AvroMicros {
+	return AvroMicros(u.Unix()*1e6 + int64(u.Nanosecond())/1e3)
+}
+
+// UTC returns the decoded timestamp-millis value.
+func (m AvroMillis) UTC() UTC {
+	return UnixMilli(int64(m))
+}
+
+// UTC returns the decoded timestamp-micros value.
+func (m AvroMicros) UTC() UTC {
+	return New(time.Unix(int64(m)/1e6, int64(m)%1e6*1e3))
+}
+
+// FromAvroMillis decodes the given Avro timestamp-millis value.
+func FromAvroMillis(millis int64) UTC {
+	return AvroMillis(millis).UTC()
+}
+
+// FromAvroMicros decodes the given Avro timestamp-micros value.
+func FromAvroMicros(micros int64) UTC {
+	return AvroMicros(micros).UTC()
+}
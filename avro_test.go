@@ -0,0 +1,44 @@
+package utc_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+func TestAvro(t *testing.T) {
+	tests := []struct {
+		ts     string
+		millis int64
+		micros int64
+	}{
+		{"1970-01-01T00:00:00Z", 0, 0},
+		{"2022-03-04T05:06:07.123456789Z", 1646370367123, 1646370367123456},
+		{"1969-12-31T23:59:59.999999Z", -1, -1},
+		{"1969-12-31T23:59:59.9985Z", -2, -1500},
+		{"0000-01-01T00:00:00Z", -62167219200000, -62167219200000000},
+		{"9999-12-31T23:59:59.999999999Z", 253402300799999, 253402300799999999},
+	}
+	for _, test := range tests {
+		t.Run(test.ts, func(t *testing.T) {
+			u := utc.MustParse(test.ts)
+			require.Equal(t, utc.AvroMillis(test.millis), u.AvroMillis())
+			require.Equal(t, utc.AvroMicros(test.micros), u.AvroMicros())
+			require.Equal(t, u.Truncate(time.Millisecond).String(), utc.FromAvroMillis(test.millis).String())
+			require.True(t, u.Truncate(time.Microsecond).Equal(utc.FromAvroMicros(test.micros)))
+			require.True(t, u.AvroMicros().UTC().Equal(utc.FromAvroMicros(test.micros)))
+		})
+	}
+}
+
+func TestAvroSchemas(t *testing.T) {
+	for _, schema := range []string{utc.AvroTimestampMillisSchema, utc.AvroTimestampMicrosSchema} {
+		var m map[string]string
+		require.NoError(t, json.Unmarshal([]byte(schema), &m))
+		require.Equal(t, "long", m["type"])
+	}
+}
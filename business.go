@@ -0,0 +1,187 @@
+package utc
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/eluv-io/errors-go"
+)
+
+const oneDay = 24 * time.Hour
+
+// HolidayCalendar defines the holidays to skip in business day computations in addition to weekends (Saturday and
+// Sunday).
+type HolidayCalendar interface {
+	// IsHoliday returns true if the date of the given UTC instant is a holiday.
+	IsHoliday(u UTC) bool
+}
+
+// HolidayCalendarFn is a function implementing HolidayCalendar
+type HolidayCalendarFn func(u UTC) bool
+
+func (fn HolidayCalendarFn) IsHoliday(u UTC) bool {
+	return fn(u)
+}
+
+// NoHolidays is a HolidayCalendar without any holidays.
+var NoHolidays HolidayCalendar = HolidayCalendarFn(func(UTC) bool { return false })
+
+// MonthDay is a day of the year identified by month and day of month.
+type MonthDay struct {
+	Month time.Month
+	Day   int
+}
+
+// HolidayDates returns a HolidayCalendar with the dates of the given UTC instants as holidays. The time of day of the
+// given instants is ignored.
+func HolidayDates(dates ...UTC) HolidayCalendar {
+	set := make(map[int64]struct{}, len(dates))
+	for _, d := range dates {
+		set[d.StartOfDay().Unix()] = struct{}{}
+	}
+	return HolidayCalendarFn(func(u UTC) bool {
+		_, ok := set[u.StartOfDay().Unix()]
+		return ok
+	})
+}
+
+// AnnualHolidays returns a HolidayCalendar with holidays that fall on the same date every year, e.g. January 1st or
+// December 25th.
+func AnnualHolidays(days ...MonthDay) HolidayCalendar {
+	set := make(map[MonthDay]struct{}, len(days))
+	for _, d := range days {
+		set[d] = struct{}{}
+	}
+	return HolidayCalendarFn(func(u UTC) bool {
+		_, ok := set[MonthDay{Month: u.Month(), Day: u.Day()}]
+		return ok
+	})
+}
+
+// ParseHolidays parses a holiday calendar from the given reader, e.g. a file maintained alongside the configuration of
+// an application. Each line contains a date followed by an optional name. Dates are either specific dates in the
+// format "2006-01-02" or annual dates in the ISO 8601 format "--01-02" that recur every year. Empty lines and comments
+// starting with '#' are ignored:
+//
+//	# US federal holidays
+//	--01-01     New Year's Day
+//	2024-01-15  Birthday of Martin Luther King, Jr.
+//	2024-11-28  Thanksgiving Day
+//	--12-25     Christmas Day
+func ParseHolidays(r io.Reader) (HolidayCalendar, error) {
+	e := errors.Template("ParseHolidays", errors.K.Invalid, ErrParse)
+
+	var dates []UTC
+	var days []MonthDay
+	scanner := bufio.NewScanner(r)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := scanner.Text()
+		if idx := strings.IndexByte(line, '#'); idx >= 0 {
+			line = line[:idx]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if strings.HasPrefix(fields[0], "--") {
+			t, err := time.Parse("--01-02", fields[0])
+			if err != nil {
+				return nil, e(WrapSentinel(ErrParse, err), "reason", "invalid annual date", "line", lineNo)
+			}
+			days = append(days, MonthDay{Month: t.Month(), Day: t.Day()})
+			continue
+		}
+		t, err := time.Parse("2006-01-02", fields[0])
+		if err != nil {
+			return nil, e(WrapSentinel(ErrParse, err), "reason", "invalid date", "line", lineNo)
+		}
+		dates = append(dates, New(t))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, e(err)
+	}
+	return CombinedHolidays(HolidayDates(dates...), AnnualHolidays(days...)), nil
+}
+
+// CombinedHolidays returns a HolidayCalendar that considers a date a holiday if any of the given calendars does.
+func CombinedHolidays(calendars ...HolidayCalendar) HolidayCalendar {
+	return HolidayCalendarFn(func(u UTC) bool {
+		for _, cal := range calendars {
+			if cal.IsHoliday(u) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// StartOfDay returns the start of the day of this instant: 00:00:00.000 on the same date. The result has no monotonic
+// clock reading.
+func (u UTC) StartOfDay() UTC {
+	year, month, dd := u.Date()
+	return New(time.Date(year, month, dd, 0, 0, 0, 0, time.UTC))
+}
+
+// IsWeekend returns true if this instant falls on a Saturday or Sunday.
+func (u UTC) IsWeekend() bool {
+	wd := u.Weekday()
+	return wd == time.Saturday || wd == time.Sunday
+}
+
+// IsBusinessDay returns true if this instant falls on a weekday (Monday through Friday) that is not a holiday according
+// to the given calendar. A nil calendar is equivalent to NoHolidays.
+func (u UTC) IsBusinessDay(cal HolidayCalendar) bool {
+	if cal == nil {
+		cal = NoHolidays
+	}
+	return !u.IsWeekend() && !cal.IsHoliday(u)
+}
+
+// NextBusinessDay returns the same time of day on the first business day after the date of this instant.
+func (u UTC) NextBusinessDay(cal HolidayCalendar) UTC {
+	return u.AddBusinessDays(1, cal)
+}
+
+// PrevBusinessDay returns the same time of day on the last business day before the date of this instant.
+func (u UTC) PrevBusinessDay(cal HolidayCalendar) UTC {
+	return u.AddBusinessDays(-1, cal)
+}
+
+// AddBusinessDays returns the same time of day n business days after (or before if n is negative) the date of this
+// instant. Weekends and holidays of the given calendar are skipped. If n is 0, the instant is returned unchanged, even
+// if it does not fall on a business day.
+//
+// The calendar must not declare all days as holidays, otherwise the function never returns.
+func (u UTC) AddBusinessDays(n int, cal HolidayCalendar) UTC {
+	step := oneDay
+	if n < 0 {
+		n = -n
+		step = -oneDay
+	}
+	for n > 0 {
+		u = u.Add(step)
+		if u.IsBusinessDay(cal) {
+			n--
+		}
+	}
+	return u
+}
+
+// BusinessDaysBetween returns the number of business days in the half-open interval [start, end) of dates. The result
+// is negative if end is before start.
+func BusinessDaysBetween(start, end UTC, cal HolidayCalendar) int {
+	sign := 1
+	if end.Before(start) {
+		start, end = end, start
+		sign = -1
+	}
+	count := 0
+	for d, e := start.StartOfDay(), end.StartOfDay(); d.Before(e); d = d.Add(oneDay) {
+		if d.IsBusinessDay(cal) {
+			count++
+		}
+	}
+	return sign * count
+}
@@ -0,0 +1,120 @@
+package utc_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+func TestIsBusinessDay(t *testing.T) {
+	cal := utc.CombinedHolidays(
+		utc.AnnualHolidays(utc.MonthDay{Month: time.December, Day: 25}),
+		utc.HolidayDates(utc.MustParse("2021-12-27T15:00:00Z")),
+	)
+	tests := []struct {
+		date string
+		want bool
+	}{
+		{"2021-12-20T10:00:00Z", true},  // Monday
+		{"2021-12-24T23:59:59Z", true},  // Friday
+		{"2021-12-25T00:00:00Z", false}, // Saturday
+		{"2021-12-26T00:00:00Z", false}, // Sunday
+		{"2021-12-27T00:00:00Z", false}, // Monday - holiday
+		{"2021-12-28T00:00:00Z", true},  // Tuesday
+		{"2024-12-25T00:00:00Z", false}, // Wednesday - annual holiday
+	}
+	for _, test := range tests {
+		t.Run(test.date, func(t *testing.T) {
+			assert.Equal(t, test.want, utc.MustParse(test.date).IsBusinessDay(cal))
+		})
+	}
+	assert.True(t, utc.MustParse("2024-12-25").IsBusinessDay(nil))
+	assert.True(t, utc.MustParse("2024-12-25").IsBusinessDay(utc.NoHolidays))
+}
+
+func TestParseHolidays(t *testing.T) {
+	cal, err := utc.ParseHolidays(strings.NewReader(`
+# US federal holidays
+--01-01     New Year's Day
+2024-01-15  Birthday of Martin Luther King, Jr.
+	2024-11-28	Thanksgiving Day # tab separated
+--02-29
+--12-25     Christmas Day
+`))
+	require.NoError(t, err)
+
+	tests := []struct {
+		date string
+		want bool
+	}{
+		{"2024-01-01T10:00:00Z", true},
+		{"2030-01-01T00:00:00Z", true},
+		{"2024-01-15T23:59:59Z", true},
+		{"2025-01-15T00:00:00Z", false},
+		{"2024-11-28T00:00:00Z", true},
+		{"2024-11-29T00:00:00Z", false},
+		{"2024-02-29T00:00:00Z", true},
+		{"2023-12-25T00:00:00Z", true},
+	}
+	for _, test := range tests {
+		t.Run(test.date, func(t *testing.T) {
+			assert.Equal(t, test.want, cal.IsHoliday(utc.MustParse(test.date)))
+		})
+	}
+	// Friday after Thanksgiving
+	require.Equal(t, "2024-11-29", utc.MustParse("2024-11-27").NextBusinessDay(cal).Format(time.DateOnly))
+
+	cal, err = utc.ParseHolidays(strings.NewReader(""))
+	require.NoError(t, err)
+	require.False(t, cal.IsHoliday(utc.MustParse("2024-12-25")))
+
+	for _, s := range []string{
+		"2024-13-01",
+		"2024-02-30 invalid day",
+		"--13-01",
+		"--02-30",
+		"24-12-25",
+		"Christmas --12-25",
+	} {
+		_, err = utc.ParseHolidays(strings.NewReader("--01-01\n" + s))
+		require.Error(t, err, s)
+		require.ErrorIs(t, err, utc.ErrParse, s)
+		require.Contains(t, err.Error(), "line", s)
+	}
+}
+
+func TestAddBusinessDays(t *testing.T) {
+	cal := utc.HolidayDates(utc.MustParse("2021-12-27"))
+	fri := utc.MustParse("2021-12-24T10:30:00Z")
+
+	tests := []struct {
+		n    int
+		cal  utc.HolidayCalendar
+		want string
+	}{
+		{0, nil, "2021-12-24T10:30:00Z"},
+		{1, nil, "2021-12-27T10:30:00Z"},
+		{1, cal, "2021-12-28T10:30:00Z"},
+		{5, nil, "2021-12-31T10:30:00Z"},
+		{5, cal, "2022-01-03T10:30:00Z"},
+		{-1, nil, "2021-12-23T10:30:00Z"},
+		{-5, nil, "2021-12-17T10:30:00Z"},
+	}
+	for _, test := range tests {
+		t.Run(test.want, func(t *testing.T) {
+			res := fri.AddBusinessDays(test.n, test.cal)
+			require.Equal(t, test.want, res.Format(time.RFC3339))
+			require.Equal(t, test.n, utc.BusinessDaysBetween(fri, res, test.cal))
+		})
+	}
+
+	sat := utc.MustParse("2021-12-25T08:00:00Z")
+	require.Equal(t, "2021-12-27T08:00:00Z", sat.NextBusinessDay(nil).Format(time.RFC3339))
+	require.Equal(t, "2021-12-28T08:00:00Z", sat.NextBusinessDay(cal).Format(time.RFC3339))
+	require.Equal(t, "2021-12-24T08:00:00Z", sat.PrevBusinessDay(cal).Format(time.RFC3339))
+}
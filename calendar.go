@@ -0,0 +1,303 @@
+package utc
+
+import (
+	"fmt"
+	"time"
+)
+
+// NextWeekday returns the same time of day on the next given weekday strictly after the date of this instant. If this
+// instant falls on the given weekday, the result is one week later.
+func (u UTC) NextWeekday(wd time.Weekday) UTC {
+	days := (int(wd) - int(u.Weekday()) + 7) % 7
+	if days == 0 {
+		days = 7
+	}
+	return u.Add(time.Duration(days) * oneDay)
+}
+
+// PrevWeekday returns the same time of day on the previous given weekday strictly before the date of this instant. If
+// this instant falls on the given weekday, the result is one week earlier.
+func (u UTC) PrevWeekday(wd time.Weekday) UTC {
+	days := (int(u.Weekday()) - int(wd) + 7) % 7
+	if days == 0 {
+		days = 7
+	}
+	return u.Add(-time.Duration(days) * oneDay)
+}
+
+// NthWeekdayOfMonth returns the start of the day of the n-th given weekday in the given month, e.g. the first Monday
+// for n=1 or the last Friday for n=-1. Returns false if the month has no such day, e.g. for the fifth Monday of a month
+// with only four Mondays, or if n is 0.
+func NthWeekdayOfMonth(year int, month time.Month, wd time.Weekday, n int) (UTC, bool) {
+	var res UTC
+	switch {
+	case n > 0:
+		first := New(time.Date(year, month, 1, 0, 0, 0, 0, time.UTC))
+		offset := (int(wd) - int(first.Weekday()) + 7) % 7
+		res = first.Add(time.Duration(offset+(n-1)*7) * oneDay)
+	case n < 0:
+		last := New(time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC))
+		offset := (int(last.Weekday()) - int(wd) + 7) % 7
+		res = last.Add(-time.Duration(offset+(-n-1)*7) * oneDay)
+	default:
+		return Zero, false
+	}
+	if res.Month() != month {
+		return Zero, false
+	}
+	return res, true
+}
+
+// IsLeapYear returns true if the given year is a leap year in the proleptic Gregorian calendar.
+func IsLeapYear(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
+
+// DaysInYear returns the number of days of the given year: 365 or 366 for leap years.
+func DaysInYear(year int) int {
+	if IsLeapYear(year) {
+		return 366
+	}
+	return 365
+}
+
+// DaysInMonth returns the number of days of the given month in the given year.
+func DaysInMonth(year int, month time.Month) int {
+	switch month {
+	case time.February:
+		if IsLeapYear(year) {
+			return 29
+		}
+		return 28
+	case time.April, time.June, time.September, time.November:
+		return 30
+	default:
+		return 31
+	}
+}
+
+// FirstOfMonth returns the start of the first day of the month of this instant.
+func (u UTC) FirstOfMonth() UTC {
+	year, month, _ := u.Date()
+	return New(time.Date(year, month, 1, 0, 0, 0, 0, time.UTC))
+}
+
+// LastOfMonth returns the start of the last day of the month of this instant.
+func (u UTC) LastOfMonth() UTC {
+	year, month, _ := u.Date()
+	return New(time.Date(year, month, DaysInMonth(year, month), 0, 0, 0, 0, time.UTC))
+}
+
+// FirstOfYear returns the start of the first day of the year of this instant.
+func (u UTC) FirstOfYear() UTC {
+	return New(time.Date(u.Year(), time.January, 1, 0, 0, 0, 0, time.UTC))
+}
+
+// LastOfYear returns the start of the last day of the year of this instant.
+func (u UTC) LastOfYear() UTC {
+	return New(time.Date(u.Year(), time.December, 31, 0, 0, 0, 0, time.UTC))
+}
+
+// Quarter returns the quarter of the year of this instant, 1 through 4.
+func (u UTC) Quarter() int {
+	return (int(u.Month())-1)/3 + 1
+}
+
+// StartOfISOWeek returns the start of the Monday of the ISO 8601 week of this instant.
+func (u UTC) StartOfISOWeek() UTC {
+	days := (int(u.Weekday()) + 6) % 7 // days since Monday
+	return u.StartOfDay().Add(-time.Duration(days) * oneDay)
+}
+
+// ISOWeekRange returns the range of the ISO 8601 week of this instant: [Monday 00:00, next Monday 00:00).
+func (u UTC) ISOWeekRange() Range {
+	start := u.StartOfISOWeek()
+	return Range{Start: start, End: start.Add(7 * oneDay)}
+}
+
+// ISOWeekStart returns the start of the Monday of the given ISO 8601 week. The year is the ISO week-numbering year as
+// returned by ISOWeek(), which differs from the calendar year for some days around January 1st.
+func ISOWeekStart(year, week int) UTC {
+	// January 4th is always in week 1
+	jan4 := New(time.Date(year, time.January, 4, 0, 0, 0, 0, time.UTC))
+	return jan4.StartOfISOWeek().Add(time.Duration(week-1) * 7 * oneDay)
+}
+
+// Quarter is a quarter of a calendar year.
+type Quarter struct {
+	Year int
+	Q    int // the quarter: 1 through 4
+}
+
+// QuarterOf returns the quarter of the given instant.
+func QuarterOf(u UTC) Quarter {
+	return Quarter{Year: u.Year(), Q: u.Quarter()}
+}
+
+// String returns the quarter formatted as 2006-Q1
+func (q Quarter) String() string {
+	return fmt.Sprintf("%04d-Q%d", q.Year, q.Q)
+}
+
+// Next returns the following quarter.
+func (q Quarter) Next() Quarter {
+	return q.add(1)
+}
+
+// Prev returns the preceding quarter.
+func (q Quarter) Prev() Quarter {
+	return q.add(-1)
+}
+
+func (q Quarter) add(n int) Quarter {
+	idx := q.Year*4 + q.Q - 1 + n
+	year := idx / 4
+	if idx < 0 && idx%4 != 0 {
+		year--
+	}
+	return Quarter{Year: year, Q: idx - year*4 + 1}
+}
+
+// Start returns the start of the first day of the quarter.
+func (q Quarter) Start() UTC {
+	return New(time.Date(q.Year, time.Month((q.Q-1)*3+1), 1, 0, 0, 0, 0, time.UTC))
+}
+
+// Range returns the range of the quarter: [first day 00:00, first day of next quarter 00:00).
+func (q Quarter) Range() Range {
+	return Range{Start: q.Start(), End: q.Next().Start()}
+}
+
+// Contains returns true if the given instant falls into the quarter.
+func (q Quarter) Contains(u UTC) bool {
+	return QuarterOf(u) == q
+}
+
+// YearMonth is a month of a calendar year.
+type YearMonth struct {
+	Year  int
+	Month time.Month
+}
+
+// YearMonthOf returns the month of the given instant.
+func YearMonthOf(u UTC) YearMonth {
+	year, month, _ := u.Date()
+	return YearMonth{Year: year, Month: month}
+}
+
+// String returns the month formatted as 2006-01
+func (m YearMonth) String() string {
+	return fmt.Sprintf("%04d-%02d", m.Year, int(m.Month))
+}
+
+// Next returns the following month.
+func (m YearMonth) Next() YearMonth {
+	return m.AddMonths(1)
+}
+
+// Prev returns the preceding month.
+func (m YearMonth) Prev() YearMonth {
+	return m.AddMonths(-1)
+}
+
+// AddMonths returns the month n months after this month, or before for negative n.
+func (m YearMonth) AddMonths(n int) YearMonth {
+	idx := m.Year*12 + int(m.Month) - 1 + n
+	year := idx / 12
+	if idx < 0 && idx%12 != 0 {
+		year--
+	}
+	return YearMonth{Year: year, Month: time.Month(idx - year*12 + 1)}
+}
+
+// Start returns the start of the first day of the month.
+func (m YearMonth) Start() UTC {
+	return New(time.Date(m.Year, m.Month, 1, 0, 0, 0, 0, time.UTC))
+}
+
+// Range returns the range of the month: [first day 00:00, first day of next month 00:00).
+func (m YearMonth) Range() Range {
+	return Range{Start: m.Start(), End: m.Next().Start()}
+}
+
+// Contains returns true if the given instant falls into the month.
+func (m YearMonth) Contains(u UTC) bool {
+	return YearMonthOf(u) == m
+}
+
+// YearRange returns the range of the given calendar year: [January 1st 00:00, January 1st of next year 00:00).
+func YearRange(year int) Range {
+	return Range{
+		Start: New(time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)),
+		End:   New(time.Date(year+1, time.January, 1, 0, 0, 0, 0, time.UTC)),
+	}
+}
+
+// WeekScheme is a convention for the numbering of the weeks of a year - see WeekNumber.
+type WeekScheme int
+
+const (
+	// WeekISO is the ISO 8601 week numbering: weeks start on Monday and week 1 is the week with the year's first
+	// Thursday. The first days of January may belong to the last week of the previous year and the last days of
+	// December to week 1 of the next year - see UTC.ISOWeek.
+	WeekISO WeekScheme = iota
+
+	// WeekUS is the week numbering common in the United States: weeks start on Sunday and week 1 is the week of
+	// January 1st. The week that contains January 1st is week 1 of the new year, but its days in December are in the
+	// last week (53 or 54) of the previous year.
+	WeekUS
+
+	// WeekMiddleEast is the week numbering common in the Middle East: weeks start on Saturday and week 1 is the week of
+	// January 1st. Like with WeekUS, a week spanning two years is numbered in both.
+	WeekMiddleEast
+)
+
+// String returns the name of the scheme.
+func (s WeekScheme) String() string {
+	switch s {
+	case WeekISO:
+		return "iso"
+	case WeekUS:
+		return "us"
+	case WeekMiddleEast:
+		return "middle-east"
+	}
+	return fmt.Sprintf("WeekScheme(%d)", int(s))
+}
+
+// FirstDay returns the first day of the week in the scheme.
+func (s WeekScheme) FirstDay() time.Weekday {
+	switch s {
+	case WeekUS:
+		return time.Sunday
+	case WeekMiddleEast:
+		return time.Saturday
+	}
+	return time.Monday
+}
+
+// WeekNumber returns the week-numbering year and the week of the given instant in the given scheme. For WeekUS and
+// WeekMiddleEast, the year is always the calendar year of the instant. For WeekISO, it is the ISO week-numbering year,
+// which differs from the calendar year for some days around January 1st - see UTC.ISOWeek.
+func WeekNumber(u UTC, scheme WeekScheme) (year, week int) {
+	if scheme == WeekISO {
+		return u.ISOWeek()
+	}
+	year = u.Year()
+	jan1 := New(time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC))
+	offset := (int(jan1.Weekday()) - int(scheme.FirstDay()) + 7) % 7 // days of week 1 before January 1st
+	return year, (u.YearDay()-1+offset)/7 + 1
+}
+
+// StartOfWeek returns the start of the first day of the week of the given instant in the given scheme.
+func StartOfWeek(u UTC, scheme WeekScheme) UTC {
+	days := (int(u.Weekday()) - int(scheme.FirstDay()) + 7) % 7 // days since the first day of the week
+	return u.StartOfDay().Add(-time.Duration(days) * oneDay)
+}
+
+// WeekRange returns the range of the week of the given instant in the given scheme: [first day 00:00, first day of next
+// week 00:00). The range always spans seven days, also for weeks that WeekUS and WeekMiddleEast number in two years.
+func WeekRange(u UTC, scheme WeekScheme) Range {
+	start := StartOfWeek(u, scheme)
+	return Range{Start: start, End: start.Add(7 * oneDay)}
+}
@@ -0,0 +1,128 @@
+package utc
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CalendarDiff is the difference between two instants broken down into calendar units. All components have the same
+// sign: they are all positive or zero if the second instant is after the first, and negative or zero otherwise.
+type CalendarDiff struct {
+	Years       int
+	Months      int
+	Days        int
+	Hours       int
+	Minutes     int
+	Seconds     int
+	Nanoseconds int
+}
+
+// DiffCalendar returns the calendar difference b - a: the number of whole years and months that can be added to a
+// without passing b, followed by the remaining days, hours, minutes, seconds and nanoseconds.
+//
+// Adding months to a date at the end of the month is clamped to the last day of the target month, e.g. the difference
+// between Jan 31st and Feb 28th is one month (in a non-leap year) and the difference between Jan 31st and Mar 1st is
+// one month and one day.
+func DiffCalendar(a, b UTC) CalendarDiff {
+	if b.Before(a) {
+		return DiffCalendar(b, a).negate()
+	}
+
+	months := (b.Year()-a.Year())*12 + int(b.Month()) - int(a.Month())
+	base := addMonthsClamped(a, months)
+	if base.After(b) {
+		months--
+		base = addMonthsClamped(a, months)
+	}
+
+	rem := b.Time.Sub(base.Time)
+	res := CalendarDiff{
+		Years:  months / 12,
+		Months: months % 12,
+		Days:   int(rem / oneDay),
+	}
+	rem %= oneDay
+	res.Hours = int(rem / time.Hour)
+	rem %= time.Hour
+	res.Minutes = int(rem / time.Minute)
+	rem %= time.Minute
+	res.Seconds = int(rem / time.Second)
+	res.Nanoseconds = int(rem % time.Second)
+	return res
+}
+
+// AgeInYears returns the number of whole years between birth and at, e.g. the age of a person or content. People
+// born on February 29th complete their year on February 28th in non-leap years.
+func AgeInYears(birth, at UTC) int {
+	return DiffCalendar(birth, at).Years
+}
+
+// addMonthsClamped adds the given number of months to u, clamping the day to the last day of the target month.
+func addMonthsClamped(u UTC, months int) UTC {
+	year, month, dd := u.Date()
+	idx := year*12 + int(month) - 1 + months
+	year = idx / 12
+	if idx < 0 && idx%12 != 0 {
+		year--
+	}
+	month = time.Month(idx - year*12 + 1)
+	if maxDay := DaysInMonth(year, month); dd > maxDay {
+		dd = maxDay
+	}
+	hour, min, sec := u.Clock()
+	return New(time.Date(year, month, dd, hour, min, sec, u.Nanosecond(), time.UTC))
+}
+
+func (d CalendarDiff) negate() CalendarDiff {
+	return CalendarDiff{
+		Years:       -d.Years,
+		Months:      -d.Months,
+		Days:        -d.Days,
+		Hours:       -d.Hours,
+		Minutes:     -d.Minutes,
+		Seconds:     -d.Seconds,
+		Nanoseconds: -d.Nanoseconds,
+	}
+}
+
+// IsZero returns true if all components are zero.
+func (d CalendarDiff) IsZero() bool {
+	return d == CalendarDiff{}
+}
+
+// String returns the difference formatted as ISO 8601 duration, e.g. P1Y2M3DT4H5M6.5S. Negative differences are
+// prefixed with a minus sign: -P1M. The zero difference is formatted as PT0S.
+func (d CalendarDiff) String() string {
+	if d.IsZero() {
+		return "PT0S"
+	}
+	sb := strings.Builder{}
+	if d.Years < 0 || d.Months < 0 || d.Days < 0 || d.Hours < 0 || d.Minutes < 0 || d.Seconds < 0 || d.Nanoseconds < 0 {
+		sb.WriteByte('-')
+		d = d.negate()
+	}
+	sb.WriteByte('P')
+	writeComponent := func(val int, unit byte) {
+		if val != 0 {
+			sb.WriteString(strconv.Itoa(val))
+			sb.WriteByte(unit)
+		}
+	}
+	writeComponent(d.Years, 'Y')
+	writeComponent(d.Months, 'M')
+	writeComponent(d.Days, 'D')
+	if d.Hours == 0 && d.Minutes == 0 && d.Seconds == 0 && d.Nanoseconds == 0 {
+		return sb.String()
+	}
+	sb.WriteByte('T')
+	writeComponent(d.Hours, 'H')
+	writeComponent(d.Minutes, 'M')
+	if d.Nanoseconds != 0 {
+		frac := strings.TrimRight(strconv.Itoa(1_000_000_000 + d.Nanoseconds)[1:], "0")
+		sb.WriteString(strconv.Itoa(d.Seconds) + "." + frac + "S")
+	} else {
+		writeComponent(d.Seconds, 'S')
+	}
+	return sb.String()
+}
@@ -0,0 +1,45 @@
+package utc_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+func TestDiffCalendar(t *testing.T) {
+	tests := []struct {
+		a    string
+		b    string
+		want utc.CalendarDiff
+		str  string
+	}{
+		{"2021-01-01", "2021-01-01", utc.CalendarDiff{}, "PT0S"},
+		{"2021-01-31", "2021-02-28", utc.CalendarDiff{Months: 1}, "P1M"},
+		{"2021-01-31", "2021-03-01", utc.CalendarDiff{Months: 1, Days: 1}, "P1M1D"},
+		{"2020-01-31", "2020-03-01", utc.CalendarDiff{Months: 1, Days: 1}, "P1M1D"},
+		{"2020-01-30", "2020-03-01", utc.CalendarDiff{Months: 1, Days: 1}, "P1M1D"},
+		{"2021-01-15T12:00:00Z", "2021-02-15T11:59:59.5Z", utc.CalendarDiff{Days: 30, Hours: 23, Minutes: 59, Seconds: 59, Nanoseconds: 500_000_000}, "P30DT23H59M59.5S"},
+		{"2000-02-29", "2021-02-28", utc.CalendarDiff{Years: 21}, "P21Y"},
+		{"2000-06-15T10:00:00Z", "2021-03-10T12:30:00Z", utc.CalendarDiff{Years: 20, Months: 8, Days: 23, Hours: 2, Minutes: 30}, "P20Y8M23DT2H30M"},
+		{"2021-03-10T12:30:00Z", "2000-06-15T10:00:00Z", utc.CalendarDiff{Years: -20, Months: -8, Days: -23, Hours: -2, Minutes: -30}, "-P20Y8M23DT2H30M"},
+		{"0000-01-01", "9999-12-31T23:59:59.999999999Z", utc.CalendarDiff{Years: 9999, Months: 11, Days: 30, Hours: 23, Minutes: 59, Seconds: 59, Nanoseconds: 999_999_999}, "P9999Y11M30DT23H59M59.999999999S"},
+	}
+	for _, test := range tests {
+		t.Run(test.a+"_"+test.b, func(t *testing.T) {
+			diff := utc.DiffCalendar(utc.MustParse(test.a), utc.MustParse(test.b))
+			require.Equal(t, test.want, diff)
+			require.Equal(t, test.str, diff.String())
+		})
+	}
+}
+
+func TestAgeInYears(t *testing.T) {
+	birth := utc.MustParse("2000-02-29")
+	require.Equal(t, 0, utc.AgeInYears(birth, utc.MustParse("2001-02-27")))
+	require.Equal(t, 1, utc.AgeInYears(birth, utc.MustParse("2001-02-28")))
+	require.Equal(t, 3, utc.AgeInYears(birth, utc.MustParse("2004-02-28T23:59:59Z")))
+	require.Equal(t, 4, utc.AgeInYears(birth, utc.MustParse("2004-02-29")))
+	require.Equal(t, -1, utc.AgeInYears(birth, utc.MustParse("1999-02-28")))
+}
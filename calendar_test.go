@@ -0,0 +1,222 @@
+package utc_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+func TestNextPrevWeekday(t *testing.T) {
+	wed := utc.MustParse("2021-12-22T10:30:00.000Z")
+
+	tests := []struct {
+		wd   time.Weekday
+		next string
+		prev string
+	}{
+		{time.Monday, "2021-12-27T10:30:00.000Z", "2021-12-20T10:30:00.000Z"},
+		{time.Tuesday, "2021-12-28T10:30:00.000Z", "2021-12-21T10:30:00.000Z"},
+		{time.Wednesday, "2021-12-29T10:30:00.000Z", "2021-12-15T10:30:00.000Z"},
+		{time.Thursday, "2021-12-23T10:30:00.000Z", "2021-12-16T10:30:00.000Z"},
+		{time.Sunday, "2021-12-26T10:30:00.000Z", "2021-12-19T10:30:00.000Z"},
+	}
+	for _, test := range tests {
+		t.Run(test.wd.String(), func(t *testing.T) {
+			assert.Equal(t, test.next, wed.NextWeekday(test.wd).String())
+			assert.Equal(t, test.prev, wed.PrevWeekday(test.wd).String())
+		})
+	}
+}
+
+func TestNthWeekdayOfMonth(t *testing.T) {
+	tests := []struct {
+		year  int
+		month time.Month
+		wd    time.Weekday
+		n     int
+		want  string
+	}{
+		{2021, time.December, time.Monday, 1, "2021-12-06T00:00:00.000Z"},
+		{2021, time.December, time.Wednesday, 1, "2021-12-01T00:00:00.000Z"},
+		{2021, time.December, time.Friday, 5, "2021-12-31T00:00:00.000Z"},
+		{2021, time.December, time.Monday, 5, ""},
+		{2021, time.December, time.Friday, -1, "2021-12-31T00:00:00.000Z"},
+		{2021, time.December, time.Monday, -1, "2021-12-27T00:00:00.000Z"},
+		{2021, time.December, time.Wednesday, -5, "2021-12-01T00:00:00.000Z"},
+		{2021, time.December, time.Tuesday, -5, ""},
+		{2020, time.February, time.Saturday, 5, "2020-02-29T00:00:00.000Z"},
+		{2021, time.February, time.Saturday, 0, ""},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprint(test.year, test.month, test.wd, test.n), func(t *testing.T) {
+			res, ok := utc.NthWeekdayOfMonth(test.year, test.month, test.wd, test.n)
+			if test.want == "" {
+				require.False(t, ok)
+				return
+			}
+			require.True(t, ok)
+			require.Equal(t, test.want, res.String())
+		})
+	}
+}
+
+func TestDaysInMonth(t *testing.T) {
+	assert.True(t, utc.IsLeapYear(2000))
+	assert.True(t, utc.IsLeapYear(2024))
+	assert.True(t, utc.IsLeapYear(0))
+	assert.False(t, utc.IsLeapYear(1900))
+	assert.False(t, utc.IsLeapYear(2021))
+	assert.Equal(t, 366, utc.DaysInYear(2024))
+	assert.Equal(t, 365, utc.DaysInYear(2100))
+
+	for _, year := range []int{0, 1900, 2000, 2021, 2024, 9999} {
+		for month := time.January; month <= time.December; month++ {
+			want := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+			require.Equal(t, want, utc.DaysInMonth(year, month), "%d-%d", year, month)
+		}
+	}
+}
+
+func TestFirstLastOfMonth(t *testing.T) {
+	tests := []struct {
+		u           string
+		firstOfMon  string
+		lastOfMon   string
+		firstOfYear string
+		lastOfYear  string
+	}{
+		{"2024-02-14T10:30:00.000Z", "2024-02-01T00:00:00.000Z", "2024-02-29T00:00:00.000Z", "2024-01-01T00:00:00.000Z", "2024-12-31T00:00:00.000Z"},
+		{"2021-02-01T00:00:00.000Z", "2021-02-01T00:00:00.000Z", "2021-02-28T00:00:00.000Z", "2021-01-01T00:00:00.000Z", "2021-12-31T00:00:00.000Z"},
+		{"2021-12-31T23:59:59.999Z", "2021-12-01T00:00:00.000Z", "2021-12-31T00:00:00.000Z", "2021-01-01T00:00:00.000Z", "2021-12-31T00:00:00.000Z"},
+	}
+	for _, test := range tests {
+		t.Run(test.u, func(t *testing.T) {
+			u := utc.MustParse(test.u)
+			assert.Equal(t, test.firstOfMon, u.FirstOfMonth().String())
+			assert.Equal(t, test.lastOfMon, u.LastOfMonth().String())
+			assert.Equal(t, test.firstOfYear, u.FirstOfYear().String())
+			assert.Equal(t, test.lastOfYear, u.LastOfYear().String())
+		})
+	}
+}
+
+func TestISOWeek(t *testing.T) {
+	tests := []struct {
+		u     string
+		start string
+		year  int
+		week  int
+	}{
+		{"2021-12-22T10:30:00.000Z", "2021-12-20T00:00:00.000Z", 2021, 51},
+		{"2021-12-20T00:00:00.000Z", "2021-12-20T00:00:00.000Z", 2021, 51},
+		{"2021-12-26T23:59:59.999Z", "2021-12-20T00:00:00.000Z", 2021, 51},
+		{"2021-01-01T12:00:00.000Z", "2020-12-28T00:00:00.000Z", 2020, 53},
+		{"2019-12-31T12:00:00.000Z", "2019-12-30T00:00:00.000Z", 2020, 1},
+	}
+	for _, test := range tests {
+		t.Run(test.u, func(t *testing.T) {
+			u := utc.MustParse(test.u)
+			require.Equal(t, test.start, u.StartOfISOWeek().String())
+
+			r := u.ISOWeekRange()
+			require.Equal(t, test.start, r.Start.String())
+			require.Equal(t, 7*24*time.Hour, r.Duration())
+			require.True(t, r.Contains(u))
+
+			year, week := u.ISOWeek()
+			require.Equal(t, test.year, year)
+			require.Equal(t, test.week, week)
+			require.Equal(t, test.start, utc.ISOWeekStart(year, week).String())
+		})
+	}
+}
+
+func TestQuarter(t *testing.T) {
+	u := utc.MustParse("2021-11-22T10:30:00.000Z")
+	require.Equal(t, 4, u.Quarter())
+	require.Equal(t, 1, utc.MustParse("2021-03-31T23:59:59.999Z").Quarter())
+	require.Equal(t, 2, utc.MustParse("2021-04-01").Quarter())
+
+	q := utc.QuarterOf(u)
+	require.Equal(t, utc.Quarter{Year: 2021, Q: 4}, q)
+	require.Equal(t, "2021-Q4", q.String())
+	require.Equal(t, utc.Quarter{Year: 2022, Q: 1}, q.Next())
+	require.Equal(t, utc.Quarter{Year: 2021, Q: 3}, q.Prev())
+	require.Equal(t, utc.Quarter{Year: 2020, Q: 4}, q.Next().Prev().Prev().Prev().Prev().Prev())
+	require.Equal(t, utc.Quarter{Year: -1, Q: 4}, utc.Quarter{Year: 0, Q: 1}.Prev())
+
+	require.Equal(t, "2021-10-01T00:00:00.000Z", q.Start().String())
+	require.Equal(t, "2021-10-01T00:00:00.000Z/2022-01-01T00:00:00.000Z", q.Range().String())
+	require.True(t, q.Contains(u))
+	require.True(t, q.Contains(utc.MustParse("2021-10-01")))
+	require.False(t, q.Contains(utc.MustParse("2022-01-01")))
+	require.False(t, q.Contains(utc.MustParse("2020-11-22")))
+}
+
+func TestYearMonth(t *testing.T) {
+	u := utc.MustParse("2021-12-31T23:59:59.999Z")
+	m := utc.YearMonthOf(u)
+	require.Equal(t, utc.YearMonth{Year: 2021, Month: time.December}, m)
+	require.Equal(t, "2021-12", m.String())
+	require.Equal(t, utc.YearMonth{Year: 2022, Month: time.January}, m.Next())
+	require.Equal(t, utc.YearMonth{Year: 2021, Month: time.November}, m.Prev())
+	require.Equal(t, utc.YearMonth{Year: 2020, Month: time.December}, m.AddMonths(-12))
+	require.Equal(t, utc.YearMonth{Year: 2023, Month: time.March}, m.AddMonths(15))
+	require.Equal(t, utc.YearMonth{Year: -1, Month: time.December}, utc.YearMonth{Year: 0, Month: time.January}.Prev())
+
+	require.Equal(t, "2021-12-01T00:00:00.000Z", m.Start().String())
+	require.Equal(t, "2021-12-01T00:00:00.000Z/2022-01-01T00:00:00.000Z", m.Range().String())
+	require.Equal(t, "2020-02-01T00:00:00.000Z/2020-03-01T00:00:00.000Z", utc.YearMonth{Year: 2020, Month: 2}.Range().String())
+	require.True(t, m.Contains(u))
+	require.False(t, m.Contains(u.Add(time.Millisecond)))
+
+	require.Equal(t, "2020-01-01T00:00:00.000Z/2021-01-01T00:00:00.000Z", utc.YearRange(2020).String())
+}
+
+func TestWeekNumber(t *testing.T) {
+	for _, test := range []struct {
+		date   string
+		scheme utc.WeekScheme
+		year   int
+		week   int
+		start  string
+	}{
+		// 2022-01-01 is a Saturday
+		{"2022-01-01", utc.WeekISO, 2021, 52, "2021-12-27"},
+		{"2022-01-03", utc.WeekISO, 2022, 1, "2022-01-03"},
+		{"2022-01-01", utc.WeekUS, 2022, 1, "2021-12-26"},
+		{"2022-01-02", utc.WeekUS, 2022, 2, "2022-01-02"},
+		{"2021-12-31", utc.WeekUS, 2021, 53, "2021-12-26"},
+		{"2022-12-31", utc.WeekUS, 2022, 53, "2022-12-25"},
+		{"2022-01-01", utc.WeekMiddleEast, 2022, 1, "2022-01-01"},
+		{"2022-01-07", utc.WeekMiddleEast, 2022, 1, "2022-01-01"},
+		{"2022-01-08", utc.WeekMiddleEast, 2022, 2, "2022-01-08"},
+		// 2000 is a leap year starting on Saturday: December 31st is in week 54 in the US
+		{"2000-12-31", utc.WeekUS, 2000, 54, "2000-12-31"},
+		{"2000-12-31", utc.WeekISO, 2000, 52, "2000-12-25"},
+		// 2023-01-01 is a Sunday
+		{"2023-01-01", utc.WeekUS, 2023, 1, "2023-01-01"},
+		{"2023-01-01", utc.WeekMiddleEast, 2023, 1, "2022-12-31"},
+		{"2023-01-01", utc.WeekISO, 2022, 52, "2022-12-26"},
+	} {
+		u := utc.MustParse(test.date).Add(13 * time.Hour)
+		name := test.date + " " + test.scheme.String()
+		year, week := utc.WeekNumber(u, test.scheme)
+		require.Equal(t, test.year, year, name)
+		require.Equal(t, test.week, week, name)
+
+		start := utc.MustParse(test.start)
+		require.Equal(t, start, utc.StartOfWeek(u, test.scheme), name)
+		r := utc.WeekRange(u, test.scheme)
+		require.Equal(t, start, r.Start, name)
+		require.Equal(t, 7*24*time.Hour, r.Duration(), name)
+		require.Equal(t, test.scheme.FirstDay(), r.Start.Weekday(), name)
+	}
+
+	require.Equal(t, "WeekScheme(5)", utc.WeekScheme(5).String())
+}
@@ -13,9 +13,19 @@ func (fn ClockFn) Now() UTC {
 	return fn()
 }
 
+// clockNow returns the current time of the given clock, or utc.Now() if the clock is nil.
+func clockNow(clock Clock) UTC {
+	if clock == nil {
+		return Now()
+	}
+	return clock.Now()
+}
+
 var (
 	wall      = wallClock{}
-	wallMs    = wallClock{ms: true}
+	wallMs    = wallClock{precision: time.Millisecond}
+	wallUs    = wallClock{precision: time.Microsecond}
+	wallSec   = wallClock{precision: time.Second}
 	monotonic = mono{}
 )
 
@@ -29,20 +39,30 @@ func WallClockMs() UTC {
 	return wallMs.Now()
 }
 
+// WallClockUs is like WallClock rounded to the microsecond.
+func WallClockUs() UTC {
+	return wallUs.Now()
+}
+
+// WallClockSec is like WallClock rounded to the second.
+func WallClockSec() UTC {
+	return wallSec.Now()
+}
+
 // Mono returns the current time with the monotonic clock.
 func Mono() UTC {
 	return monotonic.Now()
 }
 
 type wallClock struct {
-	ms bool
+	precision time.Duration // rounding precision, no rounding if 0
 }
 
 func (w wallClock) Now() UTC {
 	// strip mono
 	ret := New(now().Time.Truncate(0))
-	if w.ms {
-		ret = ret.Round(time.Millisecond)
+	if w.precision > 0 {
+		ret = ret.Round(w.precision)
 	}
 	return ret
 }
@@ -52,3 +72,44 @@ type mono struct{}
 func (mono) Now() UTC {
 	return now()
 }
+
+// QuantizedClock is a Clock that truncates (or rounds) the times returned by another Clock to a fixed precision, e.g.
+// to the second for coarse timestamps. The returned times have no monotonic clock reading.
+type QuantizedClock struct {
+	Clock     Clock         // the underlying clock - utc.Now() if nil
+	Precision time.Duration // the precision to truncate or round to - no quantization if <= 0
+	Round     bool          // round to the nearest multiple of Precision instead of truncating
+}
+
+// NewQuantizedClock returns a QuantizedClock that truncates the times of the given clock to the given precision. Pass
+// a nil clock to quantize utc.Now(), including its mocks.
+func NewQuantizedClock(c Clock, precision time.Duration) QuantizedClock {
+	return QuantizedClock{Clock: c, Precision: precision}
+}
+
+// Now returns the quantized time of the underlying clock.
+func (q QuantizedClock) Now() UTC {
+	u := clockNow(q.Clock)
+	if q.Round {
+		return u.Round(q.Precision)
+	}
+	return u.Truncate(q.Precision)
+}
+
+// OffsetClock is a Clock that shifts the times of another Clock by a fixed offset, e.g. to follow the clock of a remote
+// peer whose offset from the local clock is known.
+type OffsetClock struct {
+	Clock  Clock         // the underlying clock - utc.Now() if nil
+	Offset time.Duration // the offset added to the times of the underlying clock
+}
+
+// NewOffsetClock returns an OffsetClock that adds the given offset to the times of the given clock. Pass a nil clock
+// to shift utc.Now(), including its mocks.
+func NewOffsetClock(c Clock, offset time.Duration) OffsetClock {
+	return OffsetClock{Clock: c, Offset: offset}
+}
+
+// Now returns the time of the underlying clock plus the offset.
+func (o OffsetClock) Now() UTC {
+	return clockNow(o.Clock).Add(o.Offset)
+}
@@ -12,6 +12,12 @@ func TestWallClock(t *testing.T) {
 	now := WallClock()
 	ms := WallClockMs()
 	require.True(t, ms.Sub(now) <= time.Millisecond)
+	us := WallClockUs()
+	require.True(t, us.Sub(now) <= time.Millisecond)
+	require.Zero(t, us.Nanosecond()%1000)
+	sec := WallClockSec()
+	require.True(t, sec.Sub(now) <= time.Second)
+	require.Zero(t, sec.Nanosecond())
 }
 
 func TestWallClockMs(t *testing.T) {
@@ -35,3 +41,81 @@ func TestMono(t *testing.T) {
 	require.NoError(t, err)
 	require.NotEqual(t, now, n)
 }
+
+func TestQuantizedClock(t *testing.T) {
+	u := MustParse("2020-01-01T10:00:00.789Z")
+	c := NewQuantizedClock(ClockFn(func() UTC { return u }), time.Second)
+	require.Equal(t, "2020-01-01T10:00:00.000Z", c.Now().String())
+
+	c.Round = true
+	require.Equal(t, "2020-01-01T10:00:01.000Z", c.Now().String())
+
+	c.Precision = 10 * time.Millisecond
+	require.Equal(t, "2020-01-01T10:00:00.790Z", c.Now().String())
+
+	c.Precision = 0
+	require.Equal(t, u.StripMono(), c.Now())
+
+	// nil clock uses utc.Now, including mocks
+	tc := NewWallClock(u).MockNow()
+	defer tc.UnmockNow()
+	require.Equal(t, "2020-01-01T10:00:00.000Z", NewQuantizedClock(nil, time.Minute).Now().String())
+}
+
+func TestOffsetClock(t *testing.T) {
+	u := MustParse("2020-01-01T10:00:00Z")
+	c := NewOffsetClock(ClockFn(func() UTC { return u }), time.Hour)
+	require.Equal(t, "2020-01-01T11:00:00.000Z", c.Now().String())
+
+	c.Offset = -time.Minute
+	require.Equal(t, "2020-01-01T09:59:00.000Z", c.Now().String())
+
+	// nil clock uses utc.Now, including mocks
+	tc := NewWallClock(u).MockNow()
+	defer tc.UnmockNow()
+	require.Equal(t, "2020-01-01T10:00:01.000Z", NewOffsetClock(nil, time.Second).Now().String())
+}
+
+func TestStartTimeAndUptime(t *testing.T) {
+	require.True(t, StartTime().Before(Now()))
+	require.Equal(t, startTime, StartTime())
+	up := Uptime()
+	require.True(t, up > 0)
+	require.True(t, Uptime() >= up)
+
+	u := MustParse("2020-01-01T10:00:00Z")
+	tc := NewWallClock(u).MockNow()
+	require.Equal(t, u, StartTime())
+	require.Equal(t, time.Duration(0), Uptime())
+	tc.Add(time.Hour)
+	require.Equal(t, time.Hour, Uptime())
+
+	tc.UnmockNow()
+	require.Equal(t, startTime, StartTime())
+	require.True(t, Uptime() >= up)
+}
+
+func TestMockDoesNotConsumeNow(t *testing.T) {
+	base := MustParse("2020-01-01T10:00:00Z")
+	calls := 0
+	next := func() UTC {
+		calls++
+		return base.Add(time.Duration(calls) * time.Second)
+	}
+
+	restore := MockNowFn(next)
+	defer restore()
+	require.Equal(t, 0, calls)
+	require.Equal(t, base.Add(time.Second), Now())
+
+	// the start time is recorded on first use
+	require.Equal(t, base.Add(2*time.Second), StartTime())
+	require.Equal(t, base.Add(2*time.Second), StartTime())
+	require.Equal(t, time.Second, Uptime())
+
+	d := NewDomain("test")
+	calls = 0
+	defer d.Mock(ClockFn(next))()
+	require.Equal(t, 0, calls)
+	require.Equal(t, base.Add(time.Second), d.Now())
+}
@@ -0,0 +1,203 @@
+package utc
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// AfterFunc waits until the current clock reaches the given instant and then calls fn. It returns a function that
+// stops the timer: stop returns true if the call prevented fn from being called, false if fn was already called or
+// the timer was already stopped.
+//
+// If a TestClock is the global clock, the timer is a timer of the TestClock - see TestClock.AfterFunc. Otherwise, the
+// timer is a standard time.AfterFunc timer expiring after Until(u), and fn is called in its own goroutine.
+func AfterFunc(u UTC, fn func()) (stop func() bool) {
+	if tc, ok := mockedTestClock(); ok {
+		return tc.AfterFunc(u, fn)
+	}
+	metrics.timers.Add(1)
+	return time.AfterFunc(Until(u), fn).Stop
+}
+
+// clockAfterFunc waits until the given clock reaches the given instant and then calls fn. A nil clock denotes the
+// global clock - see AfterFunc. Timers of a TestClock or Domain are created through the respective AfterFunc method,
+// for all other clocks a standard time.AfterFunc timer is used.
+func clockAfterFunc(clock Clock, u UTC, fn func()) (stop func() bool) {
+	switch c := clock.(type) {
+	case nil:
+		return AfterFunc(u, fn)
+	case TestClock:
+		return c.AfterFunc(u, fn)
+	case *Domain:
+		return c.AfterFunc(u, fn)
+	}
+	metrics.timers.Add(1)
+	return time.AfterFunc(u.Sub(clock.Now()), fn).Stop
+}
+
+// mockedTestClock returns the global clock if it is a TestClock.
+func mockedTestClock() (TestClock, bool) {
+	if m := atomicClock.Load(); m != nil {
+		tc, ok := m.c.(TestClock)
+		return tc, ok
+	}
+	return TestClock{}, false
+}
+
+// AfterFunc registers fn to be called when the time of this TestClock reaches the given instant. Timers that are due
+// are fired synchronously in the goroutine that sets or advances the clock (Set, Add, SetNow...), in the order of
+// their instants, and after the clock has been updated. A timer for an instant that is not after the current time is
+// fired immediately. This makes code waiting for timers deterministic in tests.
+//
+// If the clock is not set to a specific time and hence follows the wall clock, timers are additionally fired when the
+// wall clock reaches their instant.
+//
+// The returned function stops the timer: it returns true if the call prevented fn from being called, false if fn was
+// already called or the timer was already stopped.
+func (c TestClock) AfterFunc(u UTC, fn func()) (stop func() bool) {
+	metrics.timers.Add(1)
+	t := &clockTimer{at: u, fn: fn}
+	if c.now.Load() == nil {
+		// follow the wall clock
+		t.stopReal = time.AfterFunc(u.Sub(c.Now()), func() {
+			if c.now.Load() == nil && c.timers.remove(t) {
+				t.fn()
+			}
+		}).Stop
+	}
+	c.timers.add(t)
+	c.timers.fireDue(c)
+	return func() bool {
+		return c.timers.remove(t)
+	}
+}
+
+// clockTimers are the pending timers of a TestClock.
+type clockTimers struct {
+	mu      sync.Mutex
+	pending []*clockTimer // sorted by instant, timers with the same instant in insertion order
+	changed chan struct{} // closed when the clock is set or advanced - see changes
+}
+
+type clockTimer struct {
+	at       UTC
+	fn       func()
+	stopReal func() bool // stops the real timer of a clock following the wall clock
+}
+
+func (ts *clockTimers) add(t *clockTimer) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	idx := sort.Search(len(ts.pending), func(i int) bool {
+		return ts.pending[i].at.After(t.at)
+	})
+	ts.pending = append(ts.pending, nil)
+	copy(ts.pending[idx+1:], ts.pending[idx:])
+	ts.pending[idx] = t
+}
+
+// next returns the instant of the earliest pending timer.
+func (ts *clockTimers) next() (UTC, bool) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if len(ts.pending) == 0 {
+		return Zero, false
+	}
+	return ts.pending[0].at, true
+}
+
+// remove removes the given timer and returns true if it was pending.
+func (ts *clockTimers) remove(t *clockTimer) bool {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	for i, p := range ts.pending {
+		if p == t {
+			ts.pending = append(ts.pending[:i], ts.pending[i+1:]...)
+			if t.stopReal != nil {
+				t.stopReal()
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// changes returns a channel that is closed the next time the clock is set or advanced.
+func (ts *clockTimers) changes() <-chan struct{} {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if ts.changed == nil {
+		ts.changed = make(chan struct{})
+	}
+	return ts.changed
+}
+
+// clockChanged notifies the waiters for changes of the clock and fires the timers that are due at the new time of the
+// given clock.
+func (ts *clockTimers) clockChanged(c TestClock) {
+	if ts == nil {
+		return
+	}
+	ts.mu.Lock()
+	if ts.changed != nil {
+		close(ts.changed)
+		ts.changed = nil
+	}
+	ts.mu.Unlock()
+	ts.fireDue(c)
+}
+
+// fireDue removes the timers that are due at the current time of the given clock and calls their functions. The
+// functions are called without holding the lock, so they may register new timers or advance the clock.
+func (ts *clockTimers) fireDue(c TestClock) {
+	if ts == nil {
+		return
+	}
+	ts.mu.Lock()
+	now := c.Now()
+	n := 0
+	for n < len(ts.pending) && !ts.pending[n].at.After(now) {
+		n++
+	}
+	due := make([]*clockTimer, n)
+	copy(due, ts.pending[:n])
+	ts.pending = append(ts.pending[:0], ts.pending[n:]...)
+	ts.mu.Unlock()
+
+	for _, t := range due {
+		if t.stopReal != nil {
+			t.stopReal()
+		}
+		t.fn()
+	}
+}
+
+// WaitUntil blocks until the current clock reaches the given instant or the context is done. It returns nil if the
+// instant was reached, and the context's error otherwise. If a TestClock is the global clock, WaitUntil returns as
+// soon as the TestClock is set or advanced to the instant - see AfterFunc.
+func WaitUntil(ctx context.Context, u UTC) error {
+	return waitUntil(ctx, u, AfterFunc)
+}
+
+// waitUntil implements WaitUntil with the given timer function.
+func waitUntil(ctx context.Context, u UTC, afterFunc func(UTC, func()) func() bool) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	reached := make(chan struct{})
+	stop := afterFunc(u, func() { close(reached) })
+	defer stop()
+
+	select {
+	case <-reached:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,135 @@
+package utc
+
+import (
+	"math"
+	"time"
+
+	"github.com/eluv-io/errors-go"
+)
+
+// UTC64 is a compact representation of a UTC instant as the number of nanoseconds since the unix epoch. At 8 bytes, it
+// is a sixth of the size of UTC (two time.Time values of 24 bytes each), holds no pointers and is safely comparable with
+// == and ordered with < and > like UTC. Use it for large in-memory collections of timestamps, e.g. indexes and map
+// keys, and convert to UTC with the UTC method for everything else.
+//
+// UTC64 has no monotonic clock reading, hence durations between UTC64 values are wall clock durations - see
+// UTC.SubWall. Its range is limited to the range of int64 nanoseconds: 1677-09-21T00:12:43.145224193Z to
+// 2262-04-11T23:47:16.854775807Z.
+//
+// Zero is represented by UTC64Zero, which sorts before all other values like Zero sorts before all instants of the
+// range of UTC64. Note that the zero value of UTC64 is therefore the unix epoch 1970-01-01T00:00:00.000000000Z, not
+// Zero. UTC64 marshals to text and JSON like UTC.
+type UTC64 int64
+
+// UTC64Zero is the UTC64 representing Zero. It is the smallest UTC64 value.
+const UTC64Zero UTC64 = math.MinInt64
+
+// ToUTC64 converts the given UTC to UTC64. It returns an error if u is outside of the range of UTC64.
+func ToUTC64(u UTC) (UTC64, error) {
+	if u.IsZero() {
+		return UTC64Zero, nil
+	}
+	sec, nsec := u.Unix(), int64(u.Nanosecond())
+	if sec < math.MinInt64/int64(time.Second)-1 || sec > math.MaxInt64/int64(time.Second) {
+		return 0, errors.E("ToUTC64", errors.K.Invalid, ErrOutOfRange, "reason", "out of range", "utc", u)
+	}
+	// sec*1e9 may overflow for the first and last second of the range, while the sum does not: compute in unsigned
+	// arithmetic, where the overflow of the product is compensated by the addition
+	n := int64(uint64(sec)*uint64(time.Second) + uint64(nsec))
+	if sec < 0 && n > 0 || sec >= 0 && n < 0 {
+		return 0, errors.E("ToUTC64", errors.K.Invalid, ErrOutOfRange, "reason", "out of range", "utc", u)
+	}
+	if UTC64(n) == UTC64Zero {
+		return 0, errors.E("ToUTC64", errors.K.Invalid, ErrOutOfRange, "reason", "reserved for Zero", "utc", u)
+	}
+	return UTC64(n), nil
+}
+
+// MustUTC64 converts the given UTC to UTC64 like ToUTC64, but panics in case of errors.
+func MustUTC64(u UTC) UTC64 {
+	c, err := ToUTC64(u)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// UTC converts this UTC64 to UTC. The result has no monotonic clock reading.
+func (c UTC64) UTC() UTC {
+	if c == UTC64Zero {
+		return Zero
+	}
+	return NewWall(time.Unix(0, int64(c)))
+}
+
+// IsZero returns true if c represents Zero.
+func (c UTC64) IsZero() bool {
+	return c == UTC64Zero
+}
+
+// UnixNano returns the number of nanoseconds since the unix epoch.
+func (c UTC64) UnixNano() int64 {
+	return int64(c)
+}
+
+// Sub returns the duration c-other, saturated at the minimum and maximum time.Duration.
+func (c UTC64) Sub(other UTC64) time.Duration {
+	d := int64(c) - int64(other)
+	switch {
+	case c > other && d < 0:
+		return math.MaxInt64
+	case c < other && d > 0:
+		return math.MinInt64
+	}
+	return time.Duration(d)
+}
+
+// String returns the time formatted in ISO 8601 format - see UTC.String.
+func (c UTC64) String() string {
+	return c.UTC().String()
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (c UTC64) MarshalJSON() ([]byte, error) {
+	return c.UTC().MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (c *UTC64) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var u UTC
+	if err := u.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	return c.set("UTC64.UnmarshalJSON", u)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (c UTC64) MarshalText() ([]byte, error) {
+	return c.UTC().MarshalText()
+}
+
+// AppendText implements the encoding.TextAppender interface.
+func (c UTC64) AppendText(b []byte) ([]byte, error) {
+	return c.UTC().AppendText(b)
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (c *UTC64) UnmarshalText(data []byte) error {
+	var u UTC
+	if err := u.UnmarshalText(data); err != nil {
+		return err
+	}
+	return c.set("UTC64.UnmarshalText", u)
+}
+
+func (c *UTC64) set(op string, u UTC) error {
+	res, err := ToUTC64(u)
+	if err != nil {
+		return errors.E(op, errors.K.Invalid, err)
+	}
+	*c = res
+	return nil
+}
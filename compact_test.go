@@ -0,0 +1,125 @@
+package utc_test
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+	"testing"
+	"time"
+	"unsafe"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+func TestUTC64(t *testing.T) {
+	require.Equal(t, uintptr(8), unsafe.Sizeof(utc.UTC64(0)))
+
+	u := utc.MustParse("2022-03-04T05:06:07.123456789Z")
+	c, err := utc.ToUTC64(u)
+	require.NoError(t, err)
+	require.Equal(t, u.UnixNano(), c.UnixNano())
+	require.True(t, u.Identical(c.UTC()))
+	require.Equal(t, "2022-03-04T05:06:07.123Z", c.String())
+
+	// comparable with == regardless of the monotonic clock
+	now := utc.Now()
+	require.Equal(t, utc.MustUTC64(now), utc.MustUTC64(now.StripMono()))
+	require.Equal(t, utc.MustUTC64(now), utc.MustUTC64(utc.New(now.In(time.FixedZone("", 3600)))))
+
+	before := utc.MustParse("1960-01-01T00:00:00.5Z")
+	require.True(t, utc.MustUTC64(before) < c)
+	require.True(t, before.Equal(utc.MustUTC64(before).UTC()))
+
+	require.Equal(t, u.Sub(before), c.Sub(utc.MustUTC64(before)))
+	require.Equal(t, before.Sub(u), utc.MustUTC64(before).Sub(c))
+}
+
+func TestUTC64_zero(t *testing.T) {
+	c := utc.UTC64Zero
+	require.True(t, c.IsZero())
+	require.True(t, c.UTC().IsZero())
+	require.Equal(t, utc.Zero, c.UTC())
+
+	c, err := utc.ToUTC64(utc.Zero)
+	require.NoError(t, err)
+	require.Equal(t, utc.UTC64Zero, c)
+
+	// the zero value is the unix epoch
+	var epoch utc.UTC64
+	require.False(t, epoch.IsZero())
+	require.True(t, utc.Unix(0, 0).Equal(epoch.UTC()))
+	require.Equal(t, epoch, utc.MustUTC64(utc.Unix(0, 0)))
+	require.Equal(t, utc.UTC64(1), utc.MustUTC64(utc.Unix(0, 1)))
+	require.Equal(t, utc.UTC64(-1), utc.MustUTC64(utc.Unix(0, -1)))
+
+	// ordered like UTC: Zero before all other instants
+	first := utc.MustUTC64(utc.MustParse("1677-09-21T00:12:43.145224193Z"))
+	require.True(t, utc.UTC64Zero < first)
+	require.True(t, utc.UTC64Zero < utc.MustUTC64(utc.MustParse("1960-01-01")))
+	require.True(t, utc.Zero.Before(first.UTC()))
+}
+
+func TestUTC64_range(t *testing.T) {
+	first := utc.MustParse("1677-09-21T00:12:43.145224193Z")
+	last := utc.MustParse("2262-04-11T23:47:16.854775807Z")
+
+	require.Equal(t, utc.UTC64(math.MinInt64+1), utc.MustUTC64(first))
+	require.Equal(t, utc.UTC64(math.MaxInt64), utc.MustUTC64(last))
+	require.True(t, first.Equal(utc.UTC64(math.MinInt64+1).UTC()))
+	require.True(t, last.Equal(utc.UTC64(math.MaxInt64).UTC()))
+
+	// first.Add(-1) is reserved for Zero
+	for _, u := range []utc.UTC{first.Add(-1), first.Add(-2), last.Add(1), utc.Min, utc.Max} {
+		_, err := utc.ToUTC64(u)
+		require.Error(t, err, u)
+		require.Panics(t, func() { utc.MustUTC64(u) })
+	}
+
+	require.Equal(t, time.Duration(math.MaxInt64), utc.UTC64(math.MaxInt64).Sub(utc.UTC64(math.MinInt64+1)))
+	require.Equal(t, time.Duration(math.MinInt64), utc.UTC64(math.MinInt64+1).Sub(utc.UTC64(math.MaxInt64)))
+}
+
+func TestUTC64_marshal(t *testing.T) {
+	type doc struct {
+		At   utc.UTC64 `json:"at"`
+		Zero utc.UTC64 `json:"zero"`
+	}
+	d := doc{At: utc.MustUTC64(utc.MustParse("2022-03-04T05:06:07.123Z")), Zero: utc.UTC64Zero}
+	b, err := json.Marshal(d)
+	require.NoError(t, err)
+	require.Equal(t, `{"at":"2022-03-04T05:06:07.123Z","zero":""}`, string(b))
+
+	var res doc
+	require.NoError(t, json.Unmarshal(b, &res))
+	require.Equal(t, d, res)
+
+	require.NoError(t, json.Unmarshal([]byte(`{"at":null}`), &res))
+	require.Equal(t, d.At, res.At)
+
+	txt, err := d.At.MarshalText()
+	require.NoError(t, err)
+	var c utc.UTC64
+	require.NoError(t, c.UnmarshalText(txt))
+	require.Equal(t, d.At, c)
+
+	buf, err := d.At.AppendText([]byte("at="))
+	require.NoError(t, err)
+	require.Equal(t, "at=2022-03-04T05:06:07.123Z", string(buf))
+
+	require.Error(t, c.UnmarshalText([]byte("3000-01-01T00:00:00Z")))
+	require.Error(t, json.Unmarshal([]byte(`{"at":"1000-01-01T00:00:00Z"}`), &res))
+	require.Error(t, json.Unmarshal([]byte(`{"at":"invalid"}`), &res))
+}
+
+func TestUTC64_sort(t *testing.T) {
+	us := []utc.UTC64{
+		utc.MustUTC64(utc.MustParse("2022-01-03")),
+		utc.MustUTC64(utc.MustParse("1960-01-01")),
+		utc.MustUTC64(utc.MustParse("2022-01-01")),
+	}
+	sort.Slice(us, func(i, j int) bool { return us[i] < us[j] })
+	require.Equal(t, "1960-01-01T00:00:00.000Z", us[0].String())
+	require.Equal(t, "2022-01-03T00:00:00.000Z", us[2].String())
+}
@@ -0,0 +1,142 @@
+package utc
+
+import (
+	"math"
+	"time"
+
+	"github.com/eluv-io/errors-go"
+)
+
+// Alphabets of the compact encodings. The characters of each alphabet are in ascending ASCII order, so that encoded
+// values of the same length sort lexicographically in numerical order.
+const (
+	base32Alphabet = "0123456789abcdefghijklmnopqrstuv"                               // RFC 4648 "extended hex", lower case
+	base62Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz" // digits, upper and lower case
+)
+
+// CompactEncoding is an encoding of UTC instants as short, fixed-length strings of the epoch milliseconds or
+// nanoseconds in base62 or base32 - see EncodeCompact. Encoded values sort lexicographically in chronological order.
+// The zero value is not a valid encoding, use one of the predefined encodings.
+type CompactEncoding struct {
+	alphabet string
+	digits   [256]int8 // the values of the characters of the alphabet, -1 for other characters
+	unit     time.Duration
+	max      uint64 // the maximum encoded value
+	width    int
+}
+
+var (
+	// Base62Millis encodes the milliseconds since the unix epoch in 9 characters of 0-9, A-Z, a-z. It covers the range
+	// [1970-01-01, 9999-12-31].
+	Base62Millis = newCompactEncoding(base62Alphabet, time.Millisecond, false)
+
+	// Base62Nanos encodes the nanoseconds since the unix epoch in 11 characters of 0-9, A-Z, a-z. It covers the range
+	// [1970-01-01, 2262-04-11].
+	Base62Nanos = newCompactEncoding(base62Alphabet, time.Nanosecond, false)
+
+	// Base32Millis encodes the milliseconds since the unix epoch in 10 characters of 0-9, a-v, the "extended hex"
+	// alphabet of RFC 4648. It covers the range [1970-01-01, 9999-12-31] and decodes case-insensitively.
+	Base32Millis = newCompactEncoding(base32Alphabet, time.Millisecond, true)
+
+	// Base32Nanos encodes the nanoseconds since the unix epoch in 13 characters of 0-9, a-v, the "extended hex"
+	// alphabet of RFC 4648. It covers the range [1970-01-01, 2262-04-11] and decodes case-insensitively.
+	Base32Nanos = newCompactEncoding(base32Alphabet, time.Nanosecond, true)
+)
+
+func newCompactEncoding(alphabet string, unit time.Duration, caseInsensitive bool) *CompactEncoding {
+	e := &CompactEncoding{alphabet: alphabet, unit: unit}
+	for i := range e.digits {
+		e.digits[i] = -1
+	}
+	for i := 0; i < len(alphabet); i++ {
+		e.digits[alphabet[i]] = int8(i)
+		if caseInsensitive && alphabet[i] >= 'a' && alphabet[i] <= 'z' {
+			e.digits[alphabet[i]-'a'+'A'] = int8(i)
+		}
+	}
+	if unit == time.Millisecond {
+		e.max = uint64(Max.UnixMilli())
+	} else {
+		e.max = math.MaxInt64
+	}
+	for n := e.max; n > 0; n /= uint64(len(alphabet)) {
+		e.width++
+	}
+	return e
+}
+
+// Len returns the length of the strings produced by this encoding.
+func (e *CompactEncoding) Len() int {
+	return e.width
+}
+
+// EncodeCompact encodes the time with the given compact encoding, e.g. for embedding a timestamp in short IDs and URLs.
+// The time is truncated to the unit of the encoding, and clamped to the range of the encoding: times before the unix
+// epoch are encoded as the epoch, times after the end of the range as the end of the range.
+func (u UTC) EncodeCompact(enc *CompactEncoding) string {
+	var n uint64
+	switch {
+	case u.Unix() < 0:
+		n = 0
+	case enc.unit == time.Millisecond:
+		n = uint64(u.UnixMilli())
+	case u.After(maxUnixNano):
+		n = math.MaxInt64
+	default:
+		n = uint64(u.UnixNano())
+	}
+	if n > enc.max {
+		n = enc.max
+	}
+	return string(appendDigits(make([]byte, 0, enc.width), n, enc.alphabet, enc.width))
+}
+
+// DecodeCompact decodes a string produced by EncodeCompact with the given compact encoding.
+func DecodeCompact(s string, enc *CompactEncoding) (UTC, error) {
+	if len(s) != enc.width {
+		return Zero, errors.E("DecodeCompact", errors.K.Invalid, ErrInvalidLength,
+			"reason", "invalid length",
+			"value", s,
+			"expected_length", enc.width)
+	}
+	n, ok := parseDigits(s, &enc.digits, uint64(len(enc.alphabet)))
+	if !ok || n > enc.max {
+		return Zero, errors.E("DecodeCompact", errors.K.Invalid, ErrParse, "reason", "invalid value", "value", s)
+	}
+	if enc.unit == time.Millisecond {
+		return NewWall(time.UnixMilli(int64(n))), nil
+	}
+	return NewWall(time.Unix(0, int64(n))), nil
+}
+
+// maxUnixNano is the largest instant whose unix time in nanoseconds fits in an int64.
+var maxUnixNano = New(time.Unix(0, math.MaxInt64))
+
+// appendDigits appends the given number in the base and digits of the given alphabet to b, left-padded with the zero
+// digit to the given width.
+func appendDigits(b []byte, n uint64, alphabet string, width int) []byte {
+	base := uint64(len(alphabet))
+	start := len(b)
+	for i := 0; i < width; i++ {
+		b = append(b, alphabet[0])
+	}
+	for i := len(b) - 1; i >= start && n > 0; i-- {
+		b[i] = alphabet[n%base]
+		n /= base
+	}
+	return b
+}
+
+// parseDigits parses the given string of digits with the given values in the given base. It returns false if the
+// string contains invalid digits or if the value overflows an uint64.
+func parseDigits(s string, digits *[256]int8, base uint64) (uint64, bool) {
+	var n uint64
+	for i := 0; i < len(s); i++ {
+		d := digits[s[i]]
+		if d < 0 || n > (math.MaxUint64-uint64(d))/base {
+			return 0, false
+		}
+		n = n*base + uint64(d)
+	}
+	return n, true
+}
@@ -0,0 +1,100 @@
+package utc_test
+
+import (
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+var compactEncodings = map[string]*utc.CompactEncoding{
+	"Base62Millis": utc.Base62Millis,
+	"Base62Nanos":  utc.Base62Nanos,
+	"Base32Millis": utc.Base32Millis,
+	"Base32Nanos":  utc.Base32Nanos,
+}
+
+func TestEncodeCompact(t *testing.T) {
+	require.Equal(t, 9, utc.Base62Millis.Len())
+	require.Equal(t, 11, utc.Base62Nanos.Len())
+	require.Equal(t, 10, utc.Base32Millis.Len())
+	require.Equal(t, 13, utc.Base32Nanos.Len())
+
+	u := utc.MustParse("2021-09-09T01:46:40.123456789Z")
+	require.Equal(t, "00SiTTYpn", u.EncodeCompact(utc.Base62Millis))
+	require.Equal(t, "01ff43oj3r", u.EncodeCompact(utc.Base32Millis))
+
+	for name, enc := range compactEncodings {
+		s := u.EncodeCompact(enc)
+		require.Len(t, s, enc.Len(), name)
+		decoded, err := utc.DecodeCompact(s, enc)
+		require.NoError(t, err, name)
+		precision := time.Millisecond
+		if strings.HasSuffix(name, "Nanos") {
+			precision = time.Nanosecond
+		}
+		require.Equal(t, u.Truncate(precision), decoded, name)
+	}
+}
+
+func TestEncodeCompact_range(t *testing.T) {
+	epoch := utc.Unix(0, 0)
+	maxNanos := utc.Unix(0, 1<<63-1)
+	for name, enc := range compactEncodings {
+		end := utc.Max.Truncate(time.Millisecond)
+		if strings.HasSuffix(name, "Nanos") {
+			end = maxNanos
+		}
+		for _, test := range []struct {
+			u    utc.UTC
+			want utc.UTC
+		}{
+			{utc.Zero, epoch},
+			{epoch.Add(-time.Nanosecond), epoch},
+			{epoch, epoch},
+			{end, end},
+			{utc.Max, end},
+		} {
+			decoded, err := utc.DecodeCompact(test.u.EncodeCompact(enc), enc)
+			require.NoError(t, err, name)
+			require.True(t, test.want.Equal(decoded), "%s %s: %s", name, test.u, decoded)
+		}
+	}
+}
+
+func TestEncodeCompact_sortable(t *testing.T) {
+	times := []utc.UTC{
+		utc.Unix(0, 0),
+		utc.UnixMilli(1),
+		utc.UnixMilli(61),
+		utc.UnixMilli(62),
+		utc.MustParse("2021-09-09T01:46:40.123Z"),
+		utc.MustParse("2021-09-09T01:46:40.124Z"),
+		utc.MustParse("2262-01-01T00:00:00Z"),
+	}
+	for name, enc := range compactEncodings {
+		encoded := make([]string, len(times))
+		for i, u := range times {
+			encoded[i] = u.EncodeCompact(enc)
+		}
+		require.True(t, sort.StringsAreSorted(encoded), "%s: %v", name, encoded)
+	}
+}
+
+func TestDecodeCompact_invalid(t *testing.T) {
+	for _, s := range []string{"", "00SiTTYp", "00SiTTYpn0", "00SiTTYp-", "zzzzzzzzz"} {
+		_, err := utc.DecodeCompact(s, utc.Base62Millis)
+		require.Error(t, err, s)
+	}
+
+	// base32 is case-insensitive, base62 is not
+	u, err := utc.DecodeCompact("01FF43OJ3R", utc.Base32Millis)
+	require.NoError(t, err)
+	require.Equal(t, "2021-09-09T01:46:40.123Z", u.String())
+	_, err = utc.DecodeCompact("01ff43oj3z", utc.Base32Millis)
+	require.Error(t, err)
+}
@@ -0,0 +1,98 @@
+package utc
+
+import (
+	"context"
+	"time"
+)
+
+// ContextWithDeadline is like context.WithDeadline, but honors mocked clocks: if a TestClock is the global clock, the
+// returned context expires when the TestClock reaches the deadline (see TestClock.AfterFunc) rather than in real
+// time. Otherwise, it is equivalent to context.WithDeadline(ctx, deadline.Time).
+//
+// As with context.WithDeadline, an expired context reports context.DeadlineExceeded from Err(), and the cancel
+// function should be called as soon as the operations running in the context complete.
+func ContextWithDeadline(ctx context.Context, deadline UTC) (context.Context, context.CancelFunc) {
+	tc, ok := mockedTestClock()
+	if !ok {
+		return context.WithDeadline(ctx, deadline.Time)
+	}
+	inner, cancel := context.WithCancelCause(ctx)
+	cancelFn := func() { cancel(context.Canceled) }
+
+	// only compare with deadlines of parents created by this function: the deadline of any other parent is in real
+	// time, while the deadline is in the time of the TestClock. Such parents still cancel the returned context when they
+	// expire.
+	if cur, ok := ctx.Value(clockDeadlineCtxKey{}).(*clockDeadlineCtx); ok && cur.deadline.Before(deadline) {
+		// the parent expires earlier
+		return &clockDeadlineCtx{Context: inner, deadline: cur.deadline}, cancelFn
+	}
+
+	res := &clockDeadlineCtx{Context: inner, deadline: deadline}
+	stop := tc.AfterFunc(deadline, func() {
+		cancel(context.DeadlineExceeded)
+	})
+	context.AfterFunc(inner, func() { stop() })
+	return res, cancelFn
+}
+
+// ContextWithTimeout returns ContextWithDeadline(ctx, Now().Add(timeout)).
+func ContextWithTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	return ContextWithDeadline(ctx, Now().Add(timeout))
+}
+
+// clockCtxKey is the context key of the clock installed with ContextWithClock.
+type clockCtxKey struct{}
+
+// ContextWithClock returns a copy of ctx that carries the given clock. Code that handles requests can retrieve it with
+// ContextClock or ContextNow instead of using the global clock, so that the time can be controlled per request, e.g. by
+// a server interceptor that follows the clock of the client in integration tests. A nil clock removes the clock of the
+// parent context.
+func ContextWithClock(ctx context.Context, c Clock) context.Context {
+	return context.WithValue(ctx, clockCtxKey{}, c)
+}
+
+// ContextClock returns the clock installed in the given context with ContextWithClock, or nil if there is none. Since
+// the functions of this package that accept a Clock treat nil as the global clock, the result can be passed on as is.
+func ContextClock(ctx context.Context) Clock {
+	c, _ := ctx.Value(clockCtxKey{}).(Clock)
+	return c
+}
+
+// ContextNow returns the current time of the clock installed in the given context, or Now() if there is none.
+func ContextNow(ctx context.Context) UTC {
+	return clockNow(ContextClock(ctx))
+}
+
+// clockDeadlineCtx is a context with a deadline driven by a TestClock.
+type clockDeadlineCtx struct {
+	context.Context // the cancelable inner context, canceled with the cause context.DeadlineExceeded on expiry
+	deadline        UTC
+}
+
+// clockDeadlineCtxKey is the context key under which a clockDeadlineCtx returns itself, so that ContextWithDeadline
+// finds the nearest parent with a deadline driven by a TestClock.
+type clockDeadlineCtxKey struct{}
+
+func (c *clockDeadlineCtx) Value(key any) any {
+	if key == (clockDeadlineCtxKey{}) {
+		return c
+	}
+	return c.Context.Value(key)
+}
+
+func (c *clockDeadlineCtx) Deadline() (time.Time, bool) {
+	return c.deadline.Time, true
+}
+
+func (c *clockDeadlineCtx) Err() error {
+	err := c.Context.Err()
+	if err != nil && context.Cause(c.Context) == context.DeadlineExceeded {
+		// expired, or a parent expired
+		return context.DeadlineExceeded
+	}
+	return err
+}
+
+func (c *clockDeadlineCtx) String() string {
+	return "utc.ContextWithDeadline(" + c.deadline.String() + ")"
+}
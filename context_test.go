@@ -0,0 +1,191 @@
+package utc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+func TestContextWithDeadline_testClock(t *testing.T) {
+	now := utc.MustParse("2020-01-01T10:00:00Z")
+	clock := utc.NewWallClock(now).MockNow()
+	defer clock.UnmockNow()
+
+	ctx, cancel := utc.ContextWithTimeout(context.Background(), time.Hour)
+	defer cancel()
+
+	deadline, ok := ctx.Deadline()
+	require.True(t, ok)
+	require.Equal(t, now.Add(time.Hour).Time, deadline)
+
+	clock.Add(59 * time.Minute)
+	require.NoError(t, ctx.Err())
+	select {
+	case <-ctx.Done():
+		require.Fail(t, "context expired early")
+	default:
+	}
+
+	clock.Add(time.Minute)
+	<-ctx.Done()
+	require.Equal(t, context.DeadlineExceeded, ctx.Err())
+	require.Equal(t, context.DeadlineExceeded, context.Cause(ctx))
+}
+
+func TestContextWithDeadline_cancel(t *testing.T) {
+	now := utc.MustParse("2020-01-01T10:00:00Z")
+	clock := utc.NewWallClock(now).MockNow()
+	defer clock.UnmockNow()
+
+	ctx, cancel := utc.ContextWithDeadline(context.Background(), now.Add(time.Hour))
+	cancel()
+	<-ctx.Done()
+	require.Equal(t, context.Canceled, ctx.Err())
+
+	// expiry after cancellation does not change the error
+	clock.Add(2 * time.Hour)
+	require.Equal(t, context.Canceled, ctx.Err())
+
+	// parent cancellation
+	parent, cancelParent := context.WithCancel(context.Background())
+	ctx, cancel = utc.ContextWithDeadline(parent, now.Add(3*time.Hour))
+	defer cancel()
+	cancelParent()
+	<-ctx.Done()
+	require.Equal(t, context.Canceled, ctx.Err())
+
+	// deadline in the past
+	ctx, cancel = utc.ContextWithDeadline(context.Background(), now)
+	defer cancel()
+	<-ctx.Done()
+	require.Equal(t, context.DeadlineExceeded, ctx.Err())
+}
+
+func TestContextWithDeadline_parents(t *testing.T) {
+	now := utc.MustParse("2030-01-01T10:00:00Z") // in the future of the real parent's deadline
+	clock := utc.NewWallClock(now).MockNow()
+	defer clock.UnmockNow()
+
+	// the real deadline of the parent is not compared with the mocked deadline
+	real, cancelReal := context.WithTimeout(context.Background(), time.Hour)
+	defer cancelReal()
+	ctx, cancel := utc.ContextWithTimeout(real, time.Minute)
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	require.True(t, ok)
+	require.Equal(t, now.Add(time.Minute).Time, deadline)
+	clock.Add(time.Minute)
+	<-ctx.Done()
+	require.Equal(t, context.DeadlineExceeded, ctx.Err())
+	require.NoError(t, real.Err())
+
+	// a mocked parent deadline that expires earlier takes precedence
+	parent, cancelParent := utc.ContextWithTimeout(context.Background(), time.Minute)
+	defer cancelParent()
+	ctx, cancel = utc.ContextWithTimeout(parent, time.Hour)
+	defer cancel()
+	deadline, ok = ctx.Deadline()
+	require.True(t, ok)
+	require.Equal(t, clock.Now().Add(time.Minute).Time, deadline)
+	clock.Add(time.Minute)
+	<-ctx.Done()
+	require.Equal(t, context.DeadlineExceeded, ctx.Err())
+
+	// a mocked parent deadline that expires later does not
+	parent, cancelParent = utc.ContextWithTimeout(context.Background(), time.Hour)
+	defer cancelParent()
+	ctx, cancel = utc.ContextWithTimeout(context.WithValue(parent, struct{}{}, 1), time.Minute)
+	defer cancel()
+	clock.Add(time.Minute)
+	<-ctx.Done()
+	require.Equal(t, context.DeadlineExceeded, ctx.Err())
+	require.NoError(t, parent.Err())
+}
+
+func TestContextWithDeadline_realClock(t *testing.T) {
+	ctx, cancel := utc.ContextWithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	<-ctx.Done()
+	require.Equal(t, context.DeadlineExceeded, ctx.Err())
+}
+
+func TestContextWithClock(t *testing.T) {
+	ctx := context.Background()
+	require.Nil(t, utc.ContextClock(ctx))
+	require.False(t, utc.ContextNow(ctx).IsZero())
+
+	u := utc.MustParse("2020-01-01T10:00:00Z")
+	clock := utc.NewWallClock(u)
+	ctx = utc.ContextWithClock(ctx, clock)
+	require.Equal(t, clock, utc.ContextClock(ctx))
+	require.Equal(t, u, utc.ContextNow(ctx))
+
+	// the clock is inherited by derived contexts
+	child, cancel := context.WithCancel(ctx)
+	defer cancel()
+	require.Equal(t, u, utc.ContextNow(child))
+
+	// a nil clock removes the clock of the parent
+	ctx = utc.ContextWithClock(ctx, nil)
+	require.Nil(t, utc.ContextClock(ctx))
+
+	// without a clock in the context, the global clock is used
+	tc := utc.NewWallClock(u.Add(time.Hour)).MockNow()
+	defer tc.UnmockNow()
+	require.Equal(t, u.Add(time.Hour), utc.ContextNow(ctx))
+}
+
+func TestWaitUntil(t *testing.T) {
+	start := utc.MustParse("2020-01-01T10:00:00Z")
+	clock := utc.NewWallClock(start).MockNow()
+	defer clock.UnmockNow()
+
+	res := make(chan error, 1)
+	go func() {
+		res <- utc.WaitUntil(context.Background(), start.Add(time.Hour))
+	}()
+
+	select {
+	case <-res:
+		require.Fail(t, "WaitUntil returned early")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	clock.Add(time.Hour)
+	select {
+	case err := <-res:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "WaitUntil did not return")
+	}
+
+	// instant already reached
+	require.NoError(t, utc.WaitUntil(context.Background(), start))
+
+	// context done
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		res <- utc.WaitUntil(ctx, start.Add(2*time.Hour))
+	}()
+	cancel()
+	require.Equal(t, context.Canceled, <-res)
+
+	// context with clock deadline
+	ctx, cancel = utc.ContextWithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	go func() {
+		res <- utc.WaitUntil(ctx, clock.Now().Add(time.Hour))
+	}()
+	clock.Add(time.Minute)
+	require.Equal(t, context.DeadlineExceeded, <-res)
+}
+
+func TestWaitUntil_realClock(t *testing.T) {
+	start := utc.Now()
+	require.NoError(t, utc.WaitUntil(context.Background(), start.Add(10*time.Millisecond)))
+	require.GreaterOrEqual(t, utc.Since(start), 10*time.Millisecond)
+}
@@ -0,0 +1,76 @@
+package utc
+
+import (
+	"math"
+	"math/bits"
+	"time"
+)
+
+// StepCounter returns the number of complete steps of the given duration between t0 and this instant, i.e.
+// floor((u - t0) / step). t0 defaults to the Unix epoch 1970-01-01T00:00:00Z. With a step of 30 seconds and the
+// default t0, the result is the time counter T of TOTP as defined in RFC 6238.
+//
+// The counter is computed on the wall clock values in UTC only, without any dependency on the local timezone or the
+// monotonic clock. Instants before t0 yield 0. StepCounter panics if step is not positive.
+func StepCounter(u UTC, step time.Duration, t0 ...UTC) uint64 {
+	if step <= 0 {
+		panic("utc.StepCounter: non-positive step")
+	}
+	start := stepOrigin(t0)
+	if !u.Time.After(start.Time) {
+		return 0
+	}
+	sec := uint64(u.Unix() - start.Unix())
+	nsec := int64(u.Nanosecond()) - int64(start.Nanosecond())
+	if nsec < 0 {
+		sec--
+		nsec += int64(time.Second)
+	}
+
+	// (sec * 1e9 + nsec) / step in 128 bit arithmetic
+	hi, lo := bits.Mul64(sec, uint64(time.Second))
+	lo, carry := bits.Add64(lo, uint64(nsec), 0)
+	hi += carry
+	if hi >= uint64(step) {
+		return math.MaxUint64
+	}
+	q, _ := bits.Div64(hi, lo, uint64(step))
+	return q
+}
+
+// StepStart is the inverse of StepCounter and returns the start of the step with the given counter:
+// t0 + counter * step. t0 defaults to the Unix epoch 1970-01-01T00:00:00Z. Results after Max are saturated at Max.
+// StepStart panics if step is not positive.
+func StepStart(counter uint64, step time.Duration, t0 ...UTC) UTC {
+	if step <= 0 {
+		panic("utc.StepStart: non-positive step")
+	}
+	start := stepOrigin(t0)
+
+	// counter * step in 128 bit arithmetic, split into seconds and nanoseconds
+	hi, lo := bits.Mul64(counter, uint64(step))
+	if hi >= uint64(time.Second) {
+		return Max
+	}
+	sec, nsec := bits.Div64(hi, lo, uint64(time.Second))
+	if remaining := Max.Unix() - start.Unix(); remaining < 0 || sec > uint64(remaining) {
+		return Max
+	}
+	res := New(time.Unix(start.Unix()+int64(sec), int64(start.Nanosecond())+int64(nsec)))
+	if res.After(Max) {
+		return Max
+	}
+	return res
+}
+
+// StepRange returns the range [StepStart(counter), StepStart(counter+1)) of the step with the given counter.
+func StepRange(counter uint64, step time.Duration, t0 ...UTC) Range {
+	return Range{Start: StepStart(counter, step, t0...), End: StepStart(counter+1, step, t0...)}
+}
+
+func stepOrigin(t0 []UTC) UTC {
+	if len(t0) > 0 {
+		return t0[0]
+	}
+	return Unix(0, 0)
+}
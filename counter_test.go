@@ -0,0 +1,84 @@
+package utc_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+func TestStepCounter_RFC6238(t *testing.T) {
+	// test vectors of RFC 6238, Appendix B
+	tests := []struct {
+		unix int64
+		want uint64
+	}{
+		{59, 0x1},
+		{1111111109, 0x23523EC},
+		{1111111111, 0x23523ED},
+		{1234567890, 0x273EF07},
+		{2000000000, 0x3F940AA},
+		{20000000000, 0x27BC86AA},
+	}
+	for _, tt := range tests {
+		u := utc.Unix(tt.unix, 0)
+		require.Equal(t, tt.want, utc.StepCounter(u, 30*time.Second), u)
+		start := utc.StepStart(tt.want, 30*time.Second)
+		require.False(t, start.After(u))
+		require.True(t, u.Before(start.Add(30*time.Second)))
+	}
+}
+
+func TestStepCounter(t *testing.T) {
+	t0 := utc.MustParse("2020-01-01T00:00:00.500Z")
+
+	require.Equal(t, uint64(0), utc.StepCounter(t0, time.Minute, t0))
+	require.Equal(t, uint64(0), utc.StepCounter(t0.Add(-time.Hour), time.Minute, t0))
+	require.Equal(t, uint64(0), utc.StepCounter(t0.Add(59999*time.Millisecond), time.Minute, t0))
+	require.Equal(t, uint64(1), utc.StepCounter(t0.Add(time.Minute), time.Minute, t0))
+	require.Equal(t, uint64(60), utc.StepCounter(t0.Add(time.Hour+time.Second), time.Minute, t0))
+	require.Equal(t, uint64(2), utc.StepCounter(t0.Add(time.Second), 400*time.Millisecond, t0))
+
+	// independent of the location and monotonic reading of the input
+	loc := time.FixedZone("", -7*3600)
+	u := utc.New(t0.Time.In(loc).Add(90 * time.Minute))
+	require.Equal(t, uint64(3), utc.StepCounter(u, 30*time.Minute, t0))
+
+	require.Panics(t, func() { utc.StepCounter(t0, 0) })
+	require.Panics(t, func() { utc.StepStart(1, -time.Second) })
+}
+
+func TestStepStart(t *testing.T) {
+	t0 := utc.MustParse("2020-01-01T00:00:00.500Z")
+	steps := []time.Duration{time.Nanosecond, 7 * time.Millisecond, 30 * time.Second, 1500 * time.Second, 24 * time.Hour}
+	for _, step := range steps {
+		for _, counter := range []uint64{0, 1, 2, 1000, 123456} {
+			start := utc.StepStart(counter, step, t0)
+			require.Equal(t, counter, utc.StepCounter(start, step, t0), "step %s counter %d", step, counter)
+			if counter > 0 {
+				require.Equal(t, counter-1, utc.StepCounter(start.Add(-1), step, t0), "step %s counter %d", step, counter)
+			}
+		}
+	}
+
+	require.True(t, t0.Add(time.Hour).Equal(utc.StepStart(60, time.Minute, t0)))
+	require.True(t, utc.MustParse("2020-01-01T00:00:30Z").Equal(utc.StepStart(52594561, 30*time.Second)))
+	require.Equal(t, utc.Max, utc.StepStart(1<<63, time.Hour))
+
+	// inverse at the upper boundary
+	max := utc.StepCounter(utc.Max, time.Second)
+	last := utc.StepStart(max, time.Second)
+	require.Equal(t, "9999-12-31T23:59:59.000Z", last.String())
+	require.Equal(t, max, utc.StepCounter(last, time.Second))
+	require.Equal(t, utc.Max, utc.StepStart(max+1, time.Second))
+	require.Equal(t, utc.Max, utc.StepStart(max+1e9, time.Nanosecond, utc.Max.Add(-time.Second)))
+	require.Equal(t, max*1e3+999, utc.StepCounter(utc.StepStart(max*1e3+999, time.Millisecond), time.Millisecond))
+	require.True(t, utc.StepStart(0, time.Second, utc.Max).IsMax())
+	require.True(t, utc.StepStart(1, time.Second, utc.Max).IsMax())
+
+	r := utc.StepRange(2, time.Minute, t0)
+	require.True(t, t0.Add(2*time.Minute).Equal(r.Start))
+	require.True(t, t0.Add(3*time.Minute).Equal(r.End))
+}
@@ -0,0 +1,97 @@
+// Package cqlutc maps utc.UTC values to Cassandra/CQL timestamp columns with gocql.
+//
+// CQL timestamps are milliseconds since the unix epoch. Timestamp wraps utc.UTC and implements gocql.Marshaler and
+// gocql.Unmarshaler, so that it can be used directly in queries and scans:
+//
+//	var created cqlutc.Timestamp
+//	err := session.Query(`SELECT created FROM items WHERE id = ?`, id).Scan(&created)
+//	...
+//	err = session.Query(`INSERT INTO items (id, created) VALUES (?, ?)`, id, cqlutc.New(utc.Now())).Exec()
+//
+// The values are mapped as follows:
+//
+//   - utc.Zero <-> NULL (and empty values)
+//   - other values are truncated to milliseconds - see WarnTruncation
+//
+// Text columns (ascii, text, varchar) are supported as well and hold the ISO 8601 representation of utc.UTC.
+package cqlutc
+
+import (
+	"time"
+
+	"github.com/eluv-io/errors-go"
+	"github.com/gocql/gocql"
+
+	"github.com/eluv-io/utc-go"
+)
+
+// WarnTruncation is called with the original value whenever a value with sub-millisecond precision is truncated when
+// marshaled to a CQL timestamp. It is nil by default. Set it during initialization, e.g. to log a warning, in order to
+// detect values that do not round-trip through the database.
+var WarnTruncation func(u utc.UTC)
+
+// Timestamp is a utc.UTC that marshals to and from CQL timestamp columns.
+type Timestamp struct {
+	utc.UTC
+}
+
+// New creates a Timestamp from the given UTC.
+func New(u utc.UTC) Timestamp {
+	return Timestamp{UTC: u}
+}
+
+// ToCQL converts the given UTC to a CQL timestamp: milliseconds since the unix epoch. Sub-millisecond precision is
+// truncated.
+func ToCQL(u utc.UTC) int64 {
+	return u.UnixMilli()
+}
+
+// FromCQL converts the given CQL timestamp in milliseconds since the unix epoch to UTC.
+func FromCQL(millis int64) utc.UTC {
+	return utc.UnixMilli(millis)
+}
+
+// MarshalCQL implements the gocql.Marshaler interface.
+func (t Timestamp) MarshalCQL(info gocql.TypeInfo) ([]byte, error) {
+	if t.IsZero() {
+		return nil, nil
+	}
+	switch info.Type() {
+	case gocql.TypeTimestamp:
+		if WarnTruncation != nil && t.Nanosecond()%int(time.Millisecond) != 0 {
+			WarnTruncation(t.UTC)
+		}
+		return gocql.Marshal(info, ToCQL(t.UTC))
+	case gocql.TypeAscii, gocql.TypeText, gocql.TypeVarchar:
+		return gocql.Marshal(info, t.String())
+	}
+	return nil, errors.E("Timestamp.MarshalCQL", errors.K.Invalid, utc.ErrParse,
+		"reason", "unsupported CQL type",
+		"type", info.Type())
+}
+
+// UnmarshalCQL implements the gocql.Unmarshaler interface.
+func (t *Timestamp) UnmarshalCQL(info gocql.TypeInfo, data []byte) error {
+	if len(data) == 0 {
+		t.UTC = utc.Zero
+		return nil
+	}
+	e := errors.Template("Timestamp.UnmarshalCQL", errors.K.Invalid, utc.ErrParse, "type", info.Type())
+	switch info.Type() {
+	case gocql.TypeTimestamp:
+		var millis int64
+		if err := gocql.Unmarshal(info, data, &millis); err != nil {
+			return e(utc.WrapSentinel(utc.ErrParse, err))
+		}
+		t.UTC = FromCQL(millis)
+		return nil
+	case gocql.TypeAscii, gocql.TypeText, gocql.TypeVarchar:
+		u, err := utc.FromString(string(data))
+		if err != nil {
+			return e(err)
+		}
+		t.UTC = u
+		return nil
+	}
+	return e("reason", "unsupported CQL type")
+}
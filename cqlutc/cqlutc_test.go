@@ -0,0 +1,89 @@
+package cqlutc_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+	"github.com/eluv-io/utc-go/cqlutc"
+)
+
+var (
+	timestampType = gocql.NewNativeType(4, gocql.TypeTimestamp, "")
+	textType      = gocql.NewNativeType(4, gocql.TypeText, "")
+	intType       = gocql.NewNativeType(4, gocql.TypeInt, "")
+)
+
+func TestTimestamp(t *testing.T) {
+	u := utc.MustParse("2020-01-01T10:11:12.123Z")
+
+	data, err := gocql.Marshal(timestampType, cqlutc.New(u))
+	require.NoError(t, err)
+
+	// compatible with gocql's encoding of time.Time
+	expected, err := gocql.Marshal(timestampType, u.Time)
+	require.NoError(t, err)
+	require.Equal(t, expected, data)
+
+	var res cqlutc.Timestamp
+	require.NoError(t, gocql.Unmarshal(timestampType, data, &res))
+	require.True(t, u.Equal(res.UTC))
+
+	// before the epoch
+	old := utc.MustParse("1960-05-05T05:05:05.555Z")
+	data, err = gocql.Marshal(timestampType, cqlutc.New(old))
+	require.NoError(t, err)
+	require.NoError(t, gocql.Unmarshal(timestampType, data, &res))
+	require.True(t, old.Equal(res.UTC))
+
+	// text
+	data, err = gocql.Marshal(textType, cqlutc.New(u))
+	require.NoError(t, err)
+	require.Equal(t, "2020-01-01T10:11:12.123Z", string(data))
+	require.NoError(t, gocql.Unmarshal(textType, data, &res))
+	require.True(t, u.Equal(res.UTC))
+
+	// unsupported type
+	_, err = gocql.Marshal(intType, cqlutc.New(u))
+	require.ErrorIs(t, err, utc.ErrParse)
+	require.ErrorIs(t, gocql.Unmarshal(intType, []byte{0, 0, 0, 1}, &res), utc.ErrParse)
+}
+
+func TestTimestamp_zero(t *testing.T) {
+	data, err := gocql.Marshal(timestampType, cqlutc.Timestamp{})
+	require.NoError(t, err)
+	require.Nil(t, data)
+
+	res := cqlutc.New(utc.Now())
+	require.NoError(t, gocql.Unmarshal(timestampType, nil, &res))
+	require.True(t, res.IsZero())
+}
+
+func TestTimestamp_truncation(t *testing.T) {
+	var warned []utc.UTC
+	cqlutc.WarnTruncation = func(u utc.UTC) { warned = append(warned, u) }
+	defer func() { cqlutc.WarnTruncation = nil }()
+
+	_, err := gocql.Marshal(timestampType, cqlutc.New(utc.MustParse("2020-01-01T10:11:12.123Z")))
+	require.NoError(t, err)
+	require.Empty(t, warned)
+
+	u := utc.MustParse("2020-01-01T10:11:12.123456Z")
+	data, err := gocql.Marshal(timestampType, cqlutc.New(u))
+	require.NoError(t, err)
+	require.Len(t, warned, 1)
+	require.Equal(t, u, warned[0])
+
+	var res cqlutc.Timestamp
+	require.NoError(t, gocql.Unmarshal(timestampType, data, &res))
+	require.True(t, u.Truncate(time.Millisecond).Equal(res.UTC))
+}
+
+func TestToFromCQL(t *testing.T) {
+	u := utc.MustParse("2020-01-01T00:00:00.001Z")
+	require.Equal(t, int64(1577836800001), cqlutc.ToCQL(u))
+	require.True(t, u.Equal(cqlutc.FromCQL(1577836800001)))
+}
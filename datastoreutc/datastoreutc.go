@@ -0,0 +1,262 @@
+// Package datastoreutc persists utc.UTC values as native timestamps in Google Cloud Datastore and Firestore in
+// Datastore mode, using cloud.google.com/go/datastore.
+//
+// The datastore client has no extension point for custom value types: on its own, it saves a utc.UTC field as a nested
+// entity with a "Time" property. SaveStruct and LoadStruct wrap the client's functions of the same name and convert
+// UTC, *UTC and []UTC fields - including fields of nested, embedded and flattened structs - to and from timestamp
+// properties. Use them to implement the datastore.PropertyLoadSaver interface:
+//
+//	func (e *Entity) Load(ps []datastore.Property) error {
+//		return datastoreutc.LoadStruct(e, ps)
+//	}
+//
+//	func (e *Entity) Save() ([]datastore.Property, error) {
+//		return datastoreutc.SaveStruct(e)
+//	}
+//
+// In addition to timestamps, LoadStruct accepts string properties in the ISO 8601 format of utc.UTC and the nested
+// entities written by the datastore client without this package, so that existing data can be read and migrated.
+//
+// Note that datastore stores timestamps with microsecond precision.
+package datastoreutc
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/datastore"
+	"github.com/eluv-io/errors-go"
+
+	"github.com/eluv-io/utc-go"
+)
+
+// Value returns the given UTC as property value: a time.Time.
+func Value(u utc.UTC) interface{} {
+	return u.Time
+}
+
+// Property returns a property with the given name and UTC value.
+func Property(name string, u utc.UTC, noIndex bool) datastore.Property {
+	return datastore.Property{Name: name, Value: Value(u), NoIndex: noIndex}
+}
+
+// FromValue converts the given property value to UTC. It accepts time.Time, the ISO 8601 string format of utc.UTC,
+// the nested entity written by the datastore client for a UTC field and nil, which is converted to utc.Zero.
+func FromValue(v interface{}) (utc.UTC, error) {
+	switch t := v.(type) {
+	case nil:
+		return utc.Zero, nil
+	case time.Time:
+		return utc.New(t), nil
+	case string:
+		u, err := utc.FromString(t)
+		if err != nil {
+			return utc.Zero, errors.E("FromValue", errors.K.Invalid, err)
+		}
+		return u, nil
+	case *datastore.Entity:
+		if t != nil && len(t.Properties) == 1 && t.Properties[0].Name == "Time" {
+			if tm, ok := t.Properties[0].Value.(time.Time); ok {
+				return utc.New(tm), nil
+			}
+		}
+	}
+	return utc.Zero, errors.E("FromValue", errors.K.Invalid, utc.ErrParse,
+		"reason", "unsupported property value",
+		"type", reflect.TypeOf(v))
+}
+
+// SaveStruct returns the properties of the given struct pointer like datastore.SaveStruct, but with UTC fields saved
+// as timestamps.
+func SaveStruct(src interface{}) ([]datastore.Property, error) {
+	t, err := structType(src)
+	if err != nil {
+		return nil, errors.E("SaveStruct", errors.K.Invalid, err)
+	}
+	ps, err := datastore.SaveStruct(src)
+	if err != nil {
+		return nil, errors.E("SaveStruct", err)
+	}
+	ps, err = convertProperties(t, ps, true)
+	if err != nil {
+		return nil, errors.E("SaveStruct", err)
+	}
+	return ps, nil
+}
+
+// LoadStruct loads the given properties into the given struct pointer like datastore.LoadStruct. UTC fields are
+// loaded from timestamps, ISO 8601 strings or the nested entities written by the datastore client - see FromValue.
+func LoadStruct(dst interface{}, ps []datastore.Property) error {
+	t, err := structType(dst)
+	if err != nil {
+		return errors.E("LoadStruct", errors.K.Invalid, err)
+	}
+	ps, err = convertProperties(t, ps, false)
+	if err != nil {
+		return errors.E("LoadStruct", err)
+	}
+	err = datastore.LoadStruct(dst, ps)
+	if err != nil {
+		return errors.E("LoadStruct", err)
+	}
+	return nil
+}
+
+var (
+	typeOfUTC      = reflect.TypeOf(utc.UTC{})
+	typeOfTime     = reflect.TypeOf(time.Time{})
+	typeOfGeoPoint = reflect.TypeOf(datastore.GeoPoint{})
+	typeOfKey      = reflect.TypeOf(datastore.Key{})
+)
+
+func structType(p interface{}) (reflect.Type, error) {
+	t := reflect.TypeOf(p)
+	if t == nil || t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Struct {
+		return nil, datastore.ErrInvalidEntityType
+	}
+	return t.Elem(), nil
+}
+
+// field describes how the properties of a struct field are converted.
+type field struct {
+	typ     reflect.Type // the type of the field with pointers removed
+	rename  string       // for UTC fields of flattened structs: the name of the timestamp property
+	flatten bool         // true if the field is part of a flattened struct
+}
+
+// fields collects the fields of the given struct type that (may) contain UTC values, keyed by property name, following
+// the naming rules of the datastore client.
+func fields(t reflect.Type, prefix string, flatten bool, res map[string]field) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, opts, _ := strings.Cut(f.Tag.Get("datastore"), ",")
+		if name == "-" || (!f.IsExported() && !f.Anonymous) {
+			continue
+		}
+		ft := elemType(f.Type)
+		if ft.Kind() != reflect.Struct || ft == typeOfTime || ft == typeOfGeoPoint || ft == typeOfKey {
+			continue
+		}
+		if f.Anonymous && name == "" && ft != typeOfUTC {
+			// embedded structs are flattened without prefix
+			fields(ft, prefix, flatten, res)
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		name = prefix + name
+		switch {
+		case flatten && ft == typeOfUTC:
+			res[name+".Time"] = field{typ: ft, rename: name, flatten: true}
+		case flatten || hasOption(opts, "flatten"):
+			fields(ft, name+".", true, res)
+		default:
+			res[name] = field{typ: ft}
+		}
+	}
+}
+
+func hasOption(opts, opt string) bool {
+	for _, o := range strings.Split(opts, ",") {
+		if o == opt {
+			return true
+		}
+	}
+	return false
+}
+
+// elemType returns the type of the given slice, array or pointer type with all levels removed.
+func elemType(t reflect.Type) reflect.Type {
+	for {
+		switch t.Kind() {
+		case reflect.Ptr:
+			t = t.Elem()
+		case reflect.Slice, reflect.Array:
+			if t.Elem().Kind() == reflect.Uint8 {
+				return t
+			}
+			t = t.Elem()
+		default:
+			return t
+		}
+	}
+}
+
+// convertProperties converts the values of UTC fields in the given properties of the given struct type: to
+// timestamps if save is true, to the nested entities expected by the datastore client otherwise.
+func convertProperties(t reflect.Type, ps []datastore.Property, save bool) ([]datastore.Property, error) {
+	fs := make(map[string]field)
+	fields(t, "", false, fs)
+	if len(fs) == 0 {
+		return ps, nil
+	}
+
+	res := make([]datastore.Property, len(ps))
+	for i, p := range ps {
+		f, ok := fs[p.Name]
+		if !ok && !save {
+			// a timestamp or string property of a UTC field of a flattened struct
+			for _, fl := range fs {
+				if fl.flatten && fl.rename == p.Name {
+					f, ok = fl, true
+					p.Name += ".Time"
+					break
+				}
+			}
+		}
+		if ok {
+			var err error
+			if f.rename != "" && save {
+				p.Name = f.rename
+			}
+			p.Value, err = convertValue(f.typ, p.Value, save, f.flatten)
+			if err != nil {
+				return nil, errors.E("convert", errors.K.Invalid, err, "property", p.Name)
+			}
+		}
+		res[i] = p
+	}
+	return res, nil
+}
+
+// convertValue converts the given property value for a field of the given type (with pointers and slices removed).
+func convertValue(t reflect.Type, v interface{}, save bool, flat bool) (interface{}, error) {
+	if vs, ok := v.([]interface{}); ok {
+		res := make([]interface{}, len(vs))
+		for i, v := range vs {
+			var err error
+			res[i], err = convertValue(t, v, save, flat)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return res, nil
+	}
+	if v == nil {
+		return nil, nil
+	}
+
+	if t == typeOfUTC {
+		u, err := FromValue(v)
+		if err != nil {
+			return nil, err
+		}
+		if save || flat {
+			return Value(u), nil
+		}
+		return &datastore.Entity{Properties: []datastore.Property{{Name: "Time", Value: u.Time}}}, nil
+	}
+
+	// nested struct
+	e, ok := v.(*datastore.Entity)
+	if !ok || e == nil {
+		return v, nil
+	}
+	ps, err := convertProperties(t, e.Properties, save)
+	if err != nil {
+		return nil, err
+	}
+	return &datastore.Entity{Key: e.Key, Properties: ps}, nil
+}
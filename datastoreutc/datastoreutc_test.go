@@ -0,0 +1,157 @@
+package datastoreutc_test
+
+import (
+	"testing"
+	"time"
+
+	"cloud.google.com/go/datastore"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+	"github.com/eluv-io/utc-go/datastoreutc"
+)
+
+type Inner struct {
+	At utc.UTC
+	N  int
+}
+
+type Embedded struct {
+	Updated utc.UTC
+}
+
+type Entity struct {
+	Embedded
+	Name    string
+	Created utc.UTC `datastore:"created,noindex"`
+	Expires *utc.UTC
+	History []utc.UTC
+	Nested  Inner
+	Flat    Inner   `datastore:",flatten"`
+	Ignored utc.UTC `datastore:"-"`
+}
+
+func (e *Entity) Load(ps []datastore.Property) error {
+	return datastoreutc.LoadStruct(e, ps)
+}
+
+func (e *Entity) Save() ([]datastore.Property, error) {
+	return datastoreutc.SaveStruct(e)
+}
+
+var _ datastore.PropertyLoadSaver = (*Entity)(nil)
+
+func TestSaveLoad(t *testing.T) {
+	u := utc.MustParse("2020-01-01T10:11:12.123456Z")
+	exp := u.Add(time.Hour)
+	src := &Entity{
+		Embedded: Embedded{Updated: u.Add(time.Minute)},
+		Name:     "name",
+		Created:  u,
+		Expires:  &exp,
+		History:  []utc.UTC{u, u.Add(time.Second)},
+		Nested:   Inner{At: u.Add(2 * time.Minute), N: 1},
+		Flat:     Inner{At: u.Add(3 * time.Minute), N: 2},
+		Ignored:  u,
+	}
+
+	ps, err := src.Save()
+	require.NoError(t, err)
+
+	props := map[string]datastore.Property{}
+	for _, p := range ps {
+		props[p.Name] = p
+	}
+	require.Equal(t, u.Add(time.Minute).Time, props["Updated"].Value)
+	require.Equal(t, u.Time, props["created"].Value)
+	require.True(t, props["created"].NoIndex)
+	require.Equal(t, exp.Time, props["Expires"].Value)
+	require.Equal(t, []interface{}{u.Time, u.Add(time.Second).Time}, props["History"].Value)
+	require.Equal(t, u.Add(3*time.Minute).Time, props["Flat.At"].Value)
+	require.Equal(t, int64(2), props["Flat.N"].Value)
+	require.NotContains(t, props, "Ignored")
+
+	nested, ok := props["Nested"].Value.(*datastore.Entity)
+	require.True(t, ok)
+	require.Equal(t, []datastore.Property{
+		{Name: "At", Value: u.Add(2 * time.Minute).Time},
+		{Name: "N", Value: int64(1)},
+	}, nested.Properties)
+
+	var dst Entity
+	require.NoError(t, dst.Load(ps))
+	src.Ignored = utc.Zero
+	requireEqual(t, src, &dst)
+}
+
+func TestLoad_legacy(t *testing.T) {
+	u := utc.MustParse("2020-01-01T10:11:12.123Z")
+
+	// properties written by the datastore client without this package, or as strings
+	var dst Entity
+	require.NoError(t, dst.Load([]datastore.Property{
+		{Name: "Updated", Value: &datastore.Entity{Properties: []datastore.Property{{Name: "Time", Value: u.Time}}}},
+		{Name: "created", Value: u.String()},
+		{Name: "History", Value: []interface{}{u.String(), u.Time}},
+		{Name: "Flat.At.Time", Value: u.Time},
+		{Name: "Expires", Value: nil},
+	}))
+	require.True(t, u.Equal(dst.Updated))
+	require.True(t, u.Equal(dst.Created))
+	require.Len(t, dst.History, 2)
+	require.True(t, u.Equal(dst.History[0]))
+	require.True(t, u.Equal(dst.History[1]))
+	require.True(t, u.Equal(dst.Flat.At))
+	require.Nil(t, dst.Expires)
+
+	err := dst.Load([]datastore.Property{{Name: "created", Value: "not a date"}})
+	require.Error(t, err)
+	err = dst.Load([]datastore.Property{{Name: "created", Value: int64(1)}})
+	require.Error(t, err)
+}
+
+func TestSaveLoad_invalid(t *testing.T) {
+	_, err := datastoreutc.SaveStruct(Entity{})
+	require.Error(t, err)
+	require.Error(t, datastoreutc.LoadStruct(Entity{}, nil))
+}
+
+func TestFromValue(t *testing.T) {
+	u := utc.MustParse("2020-01-01T10:11:12.123456Z")
+
+	p := datastoreutc.Property("at", u, true)
+	require.Equal(t, datastore.Property{Name: "at", Value: u.Time, NoIndex: true}, p)
+
+	res, err := datastoreutc.FromValue(p.Value)
+	require.NoError(t, err)
+	require.True(t, u.Equal(res))
+
+	res, err = datastoreutc.FromValue(nil)
+	require.NoError(t, err)
+	require.True(t, res.IsZero())
+
+	_, err = datastoreutc.FromValue(1.5)
+	require.ErrorIs(t, err, utc.ErrParse)
+}
+
+// requireEqual compares the UTC fields of the given entities with Equal, since loaded values lack the monotonic clock
+// reading.
+func requireEqual(t *testing.T, exp, act *Entity) {
+	require.Equal(t, exp.Name, act.Name)
+	for _, pair := range [][2]utc.UTC{
+		{exp.Updated, act.Updated},
+		{exp.Created, act.Created},
+		{*exp.Expires, *act.Expires},
+		{exp.Nested.At, act.Nested.At},
+		{exp.Flat.At, act.Flat.At},
+		{exp.Ignored, act.Ignored},
+	} {
+		require.True(t, pair[0].Equal(pair[1]), "expected %s, actual %s", pair[0], pair[1])
+	}
+	require.Len(t, act.History, len(exp.History))
+	for i := range exp.History {
+		require.True(t, exp.History[i].Equal(act.History[i]))
+	}
+	require.Equal(t, exp.Nested.N, act.Nested.N)
+	require.Equal(t, exp.Flat.N, act.Flat.N)
+}
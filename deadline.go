@@ -0,0 +1,93 @@
+package utc
+
+import (
+	"context"
+	"time"
+
+	"github.com/eluv-io/errors-go"
+)
+
+// DeadlineHeader is the name of the HTTP header that carries the absolute deadline of a request - see SetDeadlineHeader.
+const DeadlineHeader = "X-Utc-Deadline"
+
+// FormatDeadline returns the deadline of the given context formatted as ISO 8601 with nanoseconds, or the empty string
+// and false if the context has no deadline. If the context carries a clock (see ContextWithClock), the deadline is
+// converted from the global clock to that clock.
+func FormatDeadline(ctx context.Context) (string, bool) {
+	d, ok := ctx.Deadline()
+	if !ok {
+		return "", false
+	}
+	deadline := New(d)
+	if ContextClock(ctx) != nil {
+		deadline = deadline.Add(ContextNow(ctx).Sub(Now()))
+	}
+	return deadline.Nano().String(), true
+}
+
+// ParseDeadline parses a deadline formatted with FormatDeadline.
+func ParseDeadline(s string) (UTC, error) {
+	u, err := FromString(s)
+	if err != nil {
+		return Zero, errors.E("ParseDeadline", errors.K.Invalid, err, "deadline", s)
+	}
+	return u, nil
+}
+
+// ContextWithPropagatedDeadline returns a copy of ctx with the given deadline received from a remote peer, shortened by
+// the given skew allowance to account for the difference between the clocks of the peer and the local clock. A negative
+// skew extends the deadline. The deadline is taken to be in the clock of ctx (see ContextWithClock) and is converted to
+// the global clock. If the deadline is Zero, the returned context has no deadline other than the one of the parent.
+//
+// Propagating absolute deadlines rather than remaining durations keeps the time spent in transit and in queues within
+// the budget of the original caller: every hop receives the same deadline instead of a timeout that restarts on
+// receipt.
+//
+// Like ContextWithDeadline, the returned context honors a TestClock installed as the global clock.
+func ContextWithPropagatedDeadline(
+	ctx context.Context,
+	deadline UTC,
+	skew time.Duration,
+) (context.Context, context.CancelFunc) {
+	if deadline.IsZero() {
+		return context.WithCancel(ctx)
+	}
+	if ContextClock(ctx) != nil {
+		deadline = deadline.Add(Now().Sub(ContextNow(ctx)))
+	}
+	return ContextWithDeadline(ctx, deadline.Add(-skew))
+}
+
+// SetDeadlineHeader sets the DeadlineHeader in the given HTTP header (e.g. an http.Header) to the deadline of the
+// given context - see FormatDeadline. It removes the header if the context has no deadline.
+func SetDeadlineHeader(h map[string][]string, ctx context.Context) {
+	d, ok := FormatDeadline(ctx)
+	if !ok {
+		delete(h, DeadlineHeader)
+		return
+	}
+	h[DeadlineHeader] = []string{d}
+}
+
+// ContextWithDeadlineHeader returns a copy of ctx with the deadline of the DeadlineHeader in the given HTTP header
+// (e.g. an http.Header), applying the given skew allowance - see ContextWithPropagatedDeadline. If the header is
+// absent, the returned context has no deadline other than the one of the parent. It returns an error if the header is
+// invalid, in which case the returned context is ctx wrapped with a cancel function.
+func ContextWithDeadlineHeader(
+	ctx context.Context,
+	h map[string][]string,
+	skew time.Duration,
+) (context.Context, context.CancelFunc, error) {
+	vals := h[DeadlineHeader]
+	if len(vals) == 0 || vals[0] == "" {
+		ctx, cancel := context.WithCancel(ctx)
+		return ctx, cancel, nil
+	}
+	deadline, err := ParseDeadline(vals[0])
+	if err != nil {
+		ctx, cancel := context.WithCancel(ctx)
+		return ctx, cancel, errors.E("ContextWithDeadlineHeader", errors.K.Invalid, err)
+	}
+	ctx, cancel := ContextWithPropagatedDeadline(ctx, deadline, skew)
+	return ctx, cancel, nil
+}
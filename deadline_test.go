@@ -0,0 +1,101 @@
+package utc_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+func TestFormatDeadline(t *testing.T) {
+	_, ok := utc.FormatDeadline(context.Background())
+	require.False(t, ok)
+
+	deadline := utc.MustParse("2020-01-01T10:00:00.123456789Z")
+	ctx, cancel := context.WithDeadline(context.Background(), deadline.Time)
+	defer cancel()
+	s, ok := utc.FormatDeadline(ctx)
+	require.True(t, ok)
+	require.Equal(t, "2020-01-01T10:00:00.123456789Z", s)
+
+	parsed, err := utc.ParseDeadline(s)
+	require.NoError(t, err)
+	require.Equal(t, deadline, parsed)
+
+	_, err = utc.ParseDeadline("invalid")
+	require.Error(t, err)
+}
+
+func TestContextWithPropagatedDeadline(t *testing.T) {
+	now := utc.MustParse("2020-01-01T10:00:00Z")
+	clock := utc.NewWallClock(now).MockNow()
+	defer clock.UnmockNow()
+
+	ctx, cancel := utc.ContextWithPropagatedDeadline(context.Background(), now.Add(time.Minute), time.Second)
+	defer cancel()
+	d, ok := ctx.Deadline()
+	require.True(t, ok)
+	require.Equal(t, now.Add(59*time.Second).Time, d)
+
+	clock.Add(59 * time.Second)
+	<-ctx.Done()
+	require.Equal(t, context.DeadlineExceeded, ctx.Err())
+
+	// Zero: no deadline
+	ctx, cancel = utc.ContextWithPropagatedDeadline(context.Background(), utc.Zero, time.Second)
+	defer cancel()
+	_, ok = ctx.Deadline()
+	require.False(t, ok)
+
+	// the deadline is converted from the clock of the context to the global clock
+	clock.Set(now)
+	ctx = utc.ContextWithClock(context.Background(), utc.NewOffsetClock(nil, time.Hour))
+	ctx, cancel = utc.ContextWithPropagatedDeadline(ctx, now.Add(time.Hour+time.Minute), 0)
+	defer cancel()
+	d, _ = ctx.Deadline()
+	require.Equal(t, now.Add(time.Minute).Time, d)
+	s, _ := utc.FormatDeadline(ctx)
+	require.Equal(t, "2020-01-01T11:01:00.000000000Z", s)
+}
+
+func TestDeadlineHeader(t *testing.T) {
+	now := utc.MustParse("2020-01-01T10:00:00Z")
+	clock := utc.NewWallClock(now).MockNow()
+	defer clock.UnmockNow()
+
+	h := http.Header{}
+	utc.SetDeadlineHeader(h, context.Background())
+	require.Empty(t, h.Get(utc.DeadlineHeader))
+
+	sender, cancel := utc.ContextWithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	utc.SetDeadlineHeader(h, sender)
+	require.Equal(t, "2020-01-01T10:01:00.000000000Z", h.Get(utc.DeadlineHeader))
+
+	// time spent in transit counts against the deadline
+	clock.Add(10 * time.Second)
+	receiver, cancel, err := utc.ContextWithDeadlineHeader(context.Background(), h, 100*time.Millisecond)
+	require.NoError(t, err)
+	defer cancel()
+	d, ok := receiver.Deadline()
+	require.True(t, ok)
+	require.Equal(t, now.Add(time.Minute-100*time.Millisecond).Time, d)
+
+	// absent header
+	receiver, cancel, err = utc.ContextWithDeadlineHeader(context.Background(), http.Header{}, time.Second)
+	require.NoError(t, err)
+	defer cancel()
+	_, ok = receiver.Deadline()
+	require.False(t, ok)
+
+	// invalid header
+	h.Set(utc.DeadlineHeader, "invalid")
+	receiver, cancel, err = utc.ContextWithDeadlineHeader(context.Background(), h, time.Second)
+	require.Error(t, err)
+	defer cancel()
+	require.NotNil(t, receiver)
+}
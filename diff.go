@@ -0,0 +1,52 @@
+package utc
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Diff returns a human-readable explanation of the difference between a and b for test failure messages, where the ISO
+// 8601 strings of two nearly identical times are hard to compare by eye:
+//
+//	a: 2020-01-01T10:00:00.000000000Z, b: 2020-01-01T10:02:13.004000000Z; b is 2m13.004s after a; wall equal: false; mono present: a yes / b no
+//
+// If both times have a monotonic clock reading that differs from the wall clock difference, the monotonic clock
+// difference is reported as well.
+//
+//	require.True(t, a.Equal(b), utc.Diff(a, b))
+func Diff(a, b UTC) string {
+	sb := strings.Builder{}
+	sb.WriteString("a: ")
+	sb.WriteString(a.Nano().String())
+	sb.WriteString(", b: ")
+	sb.WriteString(b.Nano().String())
+	sb.WriteString("; ")
+
+	wall := b.Time.Sub(a.Time)
+	switch {
+	case wall > 0:
+		sb.WriteString("b is " + wall.String() + " after a")
+	case wall < 0:
+		sb.WriteString("b is " + (-wall).String() + " before a")
+	default:
+		sb.WriteString("b equals a")
+	}
+	sb.WriteString("; wall equal: ")
+	sb.WriteString(strconv.FormatBool(a.Time.Equal(b.Time)))
+
+	aMono, bMono := hasMonotonic(a.mono), hasMonotonic(b.mono)
+	sb.WriteString("; mono present: a " + yesNo(aMono) + " / b " + yesNo(bMono))
+	if aMono && bMono {
+		if mono := b.mono.Sub(a.mono); mono != wall {
+			sb.WriteString("; mono difference: " + mono.String())
+		}
+	}
+	return sb.String()
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
@@ -0,0 +1,30 @@
+package utc_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+func TestDiff(t *testing.T) {
+	a := utc.MustParse("2020-01-01T10:00:00Z")
+	b := a.Add(2*time.Minute + 13*time.Second + 4*time.Millisecond)
+
+	require.Equal(t,
+		"a: 2020-01-01T10:00:00.000000000Z, b: 2020-01-01T10:02:13.004000000Z; b is 2m13.004s after a; wall equal: false; mono present: a no / b no",
+		utc.Diff(a, b))
+	require.Equal(t,
+		"a: 2020-01-01T10:02:13.004000000Z, b: 2020-01-01T10:00:00.000000000Z; b is 2m13.004s before a; wall equal: false; mono present: a no / b no",
+		utc.Diff(b, a))
+	require.Equal(t,
+		"a: 2020-01-01T10:00:00.000000000Z, b: 2020-01-01T10:00:00.000000000Z; b equals a; wall equal: true; mono present: a no / b no",
+		utc.Diff(a, a))
+
+	now := utc.Now()
+	require.Contains(t, utc.Diff(now, now.StripMono()), "; b equals a; wall equal: true; mono present: a yes / b no")
+	require.Contains(t, utc.Diff(now, now.Add(time.Second)), "; b is 1s after a; wall equal: false; mono present: a yes / b yes")
+	require.NotContains(t, utc.Diff(now, now.Add(time.Second)), "mono difference")
+}
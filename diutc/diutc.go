@@ -0,0 +1,57 @@
+// Package diutc provides constructors for injecting a utc.Clock or utc.FullClock with dependency injection frameworks,
+// so that services depend on an injected clock instead of the package-global utc.Now(), and tests can override it.
+//
+// With google/wire, add ProviderSet to the injector:
+//
+//	func initService() *Service {
+//		wire.Build(diutc.ProviderSet, NewService)
+//		return nil
+//	}
+//
+// With uber/fx, add Module to the application and Replace in tests:
+//
+//	app := fx.New(diutc.Module, fx.Provide(NewService), ...)
+//	...
+//	clock := utc.NewWallClock(utc.MustParse("2020-01-01"))
+//	app := fxtest.New(t, diutc.Module, diutc.Replace(clock), fx.Provide(NewService), ...)
+package diutc
+
+import (
+	"github.com/google/wire"
+	"go.uber.org/fx"
+
+	"github.com/eluv-io/utc-go"
+)
+
+// RealClock returns the clock of the utc package: it follows utc.Now() and its timers - see utc.NewFullClock. Note
+// that it therefore also follows a TestClock installed globally with MockNow.
+func RealClock() utc.FullClock {
+	return utc.NewFullClock(nil)
+}
+
+// ProvideFullClock is the provider of the FullClock: the RealClock.
+func ProvideFullClock() utc.FullClock {
+	return RealClock()
+}
+
+// ProvideClock is the provider of the Clock: the given FullClock.
+func ProvideClock(c utc.FullClock) utc.Clock {
+	return c
+}
+
+// ProviderSet is the google/wire provider set for utc.FullClock and utc.Clock. Use wire.NewSet with ProvideClock and
+// a provider of a test clock in order to inject a different clock.
+var ProviderSet = wire.NewSet(ProvideFullClock, ProvideClock)
+
+// Module is the uber/fx module providing utc.FullClock and utc.Clock.
+var Module = fx.Module("utc",
+	fx.Provide(ProvideFullClock, ProvideClock),
+)
+
+// Replace returns an fx option that replaces the clock provided by Module with the given clock, e.g. a TestClock. Both
+// utc.FullClock and utc.Clock are replaced.
+func Replace(c utc.FullClock) fx.Option {
+	return fx.Decorate(func(utc.FullClock) utc.FullClock {
+		return c
+	})
+}
@@ -0,0 +1,58 @@
+package diutc_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+
+	"github.com/eluv-io/utc-go"
+	"github.com/eluv-io/utc-go/diutc"
+)
+
+type service struct {
+	clock utc.Clock
+	full  utc.FullClock
+}
+
+func newService(clock utc.Clock, full utc.FullClock) *service {
+	return &service{clock: clock, full: full}
+}
+
+func TestModule(t *testing.T) {
+	var s *service
+	app := fxtest.New(t, diutc.Module, fx.Provide(newService), fx.Populate(&s))
+	app.RequireStart().RequireStop()
+
+	require.NotNil(t, s)
+	require.WithinDuration(t, time.Now(), s.clock.Now().Time, time.Minute)
+	require.WithinDuration(t, time.Now(), s.full.Now().Time, time.Minute)
+}
+
+func TestReplace(t *testing.T) {
+	d0 := utc.MustParse("2020-01-01T00:00:00Z")
+	clock := utc.NewWallClock(d0)
+
+	var s *service
+	app := fxtest.New(t, diutc.Module, diutc.Replace(clock), fx.Provide(newService), fx.Populate(&s))
+	app.RequireStart().RequireStop()
+
+	require.Equal(t, d0, s.clock.Now())
+	require.Equal(t, d0, s.full.Now())
+	clock.Add(time.Hour)
+	require.Equal(t, d0.Add(time.Hour), s.clock.Now())
+}
+
+func TestProviders(t *testing.T) {
+	require.NotNil(t, diutc.ProviderSet)
+
+	full := diutc.ProvideFullClock()
+	require.Equal(t, full, diutc.ProvideClock(full))
+
+	// the real clock follows the global clock
+	clock := utc.NewWallClock(utc.MustParse("2020-01-01T00:00:00Z"))
+	defer clock.MockNow().UnmockNow()
+	require.Equal(t, clock.Now(), diutc.RealClock().Now())
+}
@@ -0,0 +1,25 @@
+module github.com/eluv-io/utc-go/diutc
+
+go 1.21
+
+require (
+	github.com/eluv-io/utc-go v0.0.0
+	github.com/google/wire v0.5.0
+	github.com/stretchr/testify v1.8.4
+	go.uber.org/fx v1.20.1
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/eluv-io/errors-go v1.0.3 // indirect
+	github.com/eluv-io/stack v1.8.2 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	go.uber.org/atomic v1.7.0 // indirect
+	go.uber.org/dig v1.17.0 // indirect
+	go.uber.org/multierr v1.6.0 // indirect
+	go.uber.org/zap v1.23.0 // indirect
+	golang.org/x/sys v0.0.0-20220412211240-33da011f77ad // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/eluv-io/utc-go => ../
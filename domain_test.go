@@ -0,0 +1,102 @@
+package utc_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+func TestDomain(t *testing.T) {
+	d := utc.NewDomain("billing")
+	require.Equal(t, "billing", d.Name())
+	require.False(t, d.IsMocked())
+
+	var _ utc.Clock = d
+
+	// follows the global clock
+	global := utc.NewWallClock(utc.MustParse("2020-01-01T00:00:00Z")).MockNow()
+	require.Equal(t, global.Now(), d.Now())
+	global.UnmockNow()
+
+	// mocked independently of the global clock
+	now := utc.MustParse("2030-01-01T00:00:00Z")
+	clock := utc.NewWallClock(now)
+	restore := d.Mock(clock)
+	require.True(t, d.IsMocked())
+	require.False(t, clock.IsMock())
+	require.Equal(t, now, d.Now())
+	require.NotEqual(t, now, utc.Now())
+
+	other := utc.NewDomain("other")
+	require.False(t, other.IsMocked())
+	require.NotEqual(t, now, other.Now())
+
+	clock.Add(time.Hour)
+	require.Equal(t, time.Hour, d.Since(now))
+	require.Equal(t, -time.Hour, d.Until(now))
+
+	// nested mocks
+	inner := utc.NewWallClock(now.Add(24 * time.Hour))
+	restoreInner := d.Mock(inner)
+	require.Equal(t, now.Add(24*time.Hour), d.Now())
+	restoreInner()
+	require.Equal(t, now.Add(time.Hour), d.Now())
+
+	restore()
+	require.False(t, d.IsMocked())
+
+	d.Mock(clock)
+	d.Unmock()
+	require.False(t, d.IsMocked())
+}
+
+func TestDomain_timers(t *testing.T) {
+	now := utc.MustParse("2030-01-01T00:00:00Z")
+	clock := utc.NewWallClock(now)
+	d := utc.NewDomain("test")
+	defer d.Mock(clock)()
+
+	fired := false
+	d.AfterFunc(now.Add(time.Minute), func() { fired = true })
+	clock.Add(59 * time.Second)
+	require.False(t, fired)
+	clock.Add(time.Second)
+	require.True(t, fired)
+
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		d.Sleep(time.Hour)
+	}()
+	require.Eventually(t, func() bool {
+		clock.Add(time.Minute)
+		done := make(chan struct{})
+		go func() { wg.Wait(); close(done) }()
+		select {
+		case <-done:
+			return true
+		case <-time.After(time.Millisecond):
+			return false
+		}
+	}, 5*time.Second, time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.ErrorIs(t, d.WaitUntil(ctx, d.Now().Add(time.Hour)), context.Canceled)
+	require.NoError(t, d.WaitUntil(context.Background(), d.Now()))
+}
+
+func TestDomain_realTimers(t *testing.T) {
+	d := utc.NewDomain("real")
+	defer d.Mock(utc.ClockFn(utc.WallClock))()
+
+	start := time.Now()
+	d.Sleep(10 * time.Millisecond)
+	require.GreaterOrEqual(t, time.Since(start), 10*time.Millisecond)
+}
@@ -0,0 +1,140 @@
+package utc
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DriftReport is an estimate of the drift of the system's wall clock relative to the monotonic clock, see
+// DriftReporter.
+type DriftReport struct {
+	Drift   time.Duration // current offset of the wall clock from the time expected by the monotonic clock
+	Rate    float64       // drift rate in seconds per second, e.g. 1e-5 for 10 ppm - 0 with less than 2 samples
+	Samples int           // number of samples the rate is based on
+	Elapsed time.Duration // monotonic time covered by the samples
+}
+
+// DriftReporter estimates the drift of the system's wall clock. At creation, it anchors the wall clock to the
+// monotonic clock. Each sample computes the drift as the difference between the wall clock and the wall time expected
+// from the anchor and the progression of the monotonic clock, and the drift rate as the slope of a linear regression
+// over the most recent samples.
+//
+// Adjustments of the wall clock by NTP show up as drift, as do steps of the wall clock - see JumpMonitor for detecting
+// the latter. The reporter works on the system clock and is not affected by mocked clocks. It is safe for concurrent
+// use, e.g. from a health check while Run is taking samples.
+type DriftReporter struct {
+	window int
+	sample func() clockSample
+
+	mu      sync.Mutex
+	anchor  clockSample
+	samples []driftSample // ring buffer of the most recent samples
+	next    int           // index of the next sample in the ring buffer
+	count   int           // number of valid samples in the ring buffer
+}
+
+type driftSample struct {
+	mono  time.Duration // monotonic time since the anchor
+	drift time.Duration
+}
+
+// NewDriftReporter creates a DriftReporter that estimates the drift rate from the given number of most recent
+// samples. A window smaller than 2 is set to 2.
+func NewDriftReporter(window int) *DriftReporter {
+	return newDriftReporter(window, sampleClock)
+}
+
+func newDriftReporter(window int, sample func() clockSample) *DriftReporter {
+	if window < 2 {
+		window = 2
+	}
+	r := &DriftReporter{
+		window: window,
+		sample: sample,
+	}
+	r.Reset()
+	return r
+}
+
+// Reset discards all samples and re-anchors the wall clock to the monotonic clock.
+func (r *DriftReporter) Reset() {
+	anchor := r.sample()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.anchor = anchor
+	r.samples = make([]driftSample, r.window)
+	r.next = 0
+	r.count = 0
+}
+
+// Sample takes a sample of the clocks and returns the updated report.
+func (r *DriftReporter) Sample() DriftReport {
+	cur := r.sample()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	mono := cur.mono - r.anchor.mono
+	expected := r.anchor.wall.Add(mono)
+	r.samples[r.next] = driftSample{mono: mono, drift: cur.wall.Sub(expected)}
+	r.next = (r.next + 1) % r.window
+	if r.count < r.window {
+		r.count++
+	}
+	return r.report()
+}
+
+// Report returns the report based on the samples taken so far, without taking a new sample.
+func (r *DriftReporter) Report() DriftReport {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.report()
+}
+
+// Run takes a sample at the given interval until the context is done.
+func (r *DriftReporter) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.Sample()
+		}
+	}
+}
+
+func (r *DriftReporter) report() DriftReport {
+	if r.count == 0 {
+		return DriftReport{}
+	}
+	first := (r.next - r.count + r.window) % r.window
+	last := (r.next - 1 + r.window) % r.window
+	res := DriftReport{
+		Drift:   r.samples[last].drift,
+		Samples: r.count,
+		Elapsed: r.samples[last].mono - r.samples[first].mono,
+	}
+	if r.count < 2 {
+		return res
+	}
+
+	// least squares slope of drift over monotonic time, relative to the first sample for numeric stability
+	var sumX, sumY, sumXX, sumXY float64
+	for i := 0; i < r.count; i++ {
+		s := r.samples[(first+i)%r.window]
+		x := (s.mono - r.samples[first].mono).Seconds()
+		y := (s.drift - r.samples[first].drift).Seconds()
+		sumX += x
+		sumY += y
+		sumXX += x * x
+		sumXY += x * y
+	}
+	n := float64(r.count)
+	if den := n*sumXX - sumX*sumX; den != 0 {
+		res.Rate = (n*sumXY - sumX*sumY) / den
+	}
+	return res
+}
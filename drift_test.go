@@ -0,0 +1,59 @@
+package utc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDriftReporter(t *testing.T) {
+	clocks := &fakeClocks{wall: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	r := newDriftReporter(4, clocks.sample)
+	require.Equal(t, DriftReport{}, r.Report())
+
+	clocks.advance(time.Second)
+	rep := r.Sample()
+	require.Equal(t, time.Duration(0), rep.Drift)
+	require.Equal(t, 0.0, rep.Rate)
+	require.Equal(t, 1, rep.Samples)
+
+	// wall clock runs fast by 100 ppm
+	for i := 0; i < 10; i++ {
+		clocks.advance(10 * time.Second)
+		clocks.wall = clocks.wall.Add(time.Millisecond)
+		rep = r.Sample()
+	}
+	require.Equal(t, 10*time.Millisecond, rep.Drift)
+	require.InDelta(t, 1e-4, rep.Rate, 1e-9)
+	require.Equal(t, 4, rep.Samples)
+	require.Equal(t, 30*time.Second, rep.Elapsed)
+	require.Equal(t, rep, r.Report())
+
+	// wall clock runs slow by 50 ppm
+	for i := 0; i < 4; i++ {
+		clocks.advance(20 * time.Second)
+		clocks.wall = clocks.wall.Add(-time.Millisecond)
+		rep = r.Sample()
+	}
+	require.Equal(t, 6*time.Millisecond, rep.Drift)
+	require.InDelta(t, -5e-5, rep.Rate, 1e-9)
+
+	r.Reset()
+	require.Equal(t, DriftReport{}, r.Report())
+	clocks.advance(time.Second)
+	require.Equal(t, time.Duration(0), r.Sample().Drift)
+}
+
+func TestDriftReporter_Run(t *testing.T) {
+	r := NewDriftReporter(10)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	r.Run(ctx, time.Millisecond)
+
+	rep := r.Report()
+	require.Greater(t, rep.Samples, 1)
+	require.Less(t, rep.Drift, time.Second)
+	require.Greater(t, rep.Drift, -time.Second)
+}
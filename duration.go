@@ -0,0 +1,334 @@
+package utc
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eluv-io/errors-go"
+)
+
+// DurationValue is a time.Duration that marshals to and from a human-friendly string in text, JSON and YAML (through
+// the encoding.TextMarshaler interfaces): "1h30m0s". Unmarshaling accepts all formats of ParseDuration (e.g. "2w" or
+// "1y6mo"), ISO 8601 durations ("PT1H30M") and, in JSON, numbers interpreted as nanoseconds.
+//
+// DurationValue is meant for config structs:
+//
+//	type Config struct {
+//		Timeout utc.DurationValue `json:"timeout"`
+//	}
+type DurationValue struct {
+	time.Duration
+}
+
+// DurationISO8601 is like DurationValue, but marshals to ISO 8601 durations: "PT1H30M".
+type DurationISO8601 struct {
+	DurationValue
+}
+
+// NewDurationValue creates a new DurationValue.
+func NewDurationValue(d time.Duration) DurationValue {
+	return DurationValue{Duration: d}
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (d DurationValue) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (d *DurationValue) UnmarshalText(data []byte) error {
+	s := string(data)
+	var err error
+	if strings.HasPrefix(s, "P") || strings.HasPrefix(s, "-P") {
+		d.Duration, err = ParseISO8601Duration(s)
+	} else {
+		d.Duration, err = ParseDuration(s)
+	}
+	if err != nil {
+		return errors.E("DurationValue.UnmarshalText", errors.K.Invalid, err, "duration", s)
+	}
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (d DurationValue) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + d.String() + `"`), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. Like UTC.UnmarshalJSON, JSON null is a no-op.
+func (d *DurationValue) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	if len(data) > 0 && data[0] != '"' {
+		var nanos int64
+		if err := json.Unmarshal(data, &nanos); err != nil {
+			return errors.E("DurationValue.UnmarshalJSON", errors.K.Invalid, WrapSentinel(ErrParse, err))
+		}
+		d.Duration = time.Duration(nanos)
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return errors.E("DurationValue.UnmarshalJSON", errors.K.Invalid, WrapSentinel(ErrParse, err))
+	}
+	return d.UnmarshalText([]byte(s))
+}
+
+// String returns the duration formatted as ISO 8601 duration.
+func (d DurationISO8601) String() string {
+	return FormatISO8601Duration(d.Duration)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (d DurationISO8601) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (d DurationISO8601) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + d.String() + `"`), nil
+}
+
+// FormatISO8601Duration formats the given duration as ISO 8601 duration with hours, minutes and seconds, e.g.
+// PT1H30M or PT0.5S. Days are not used since they are ambiguous in some contexts - 48 hours are formatted as PT48H.
+func FormatISO8601Duration(d time.Duration) string {
+	if d == 0 {
+		return "PT0S"
+	}
+	sb := strings.Builder{}
+	u := uint64(d)
+	if d < 0 {
+		sb.WriteByte('-')
+		u = -u
+	}
+	sb.WriteString("PT")
+	if h := u / uint64(time.Hour); h > 0 {
+		sb.WriteString(strconv.FormatUint(h, 10) + "H")
+	}
+	if m := u / uint64(time.Minute) % 60; m > 0 {
+		sb.WriteString(strconv.FormatUint(m, 10) + "M")
+	}
+	if ns := u % uint64(time.Minute); ns > 0 {
+		sb.WriteString(strconv.FormatUint(ns/uint64(time.Second), 10))
+		if frac := ns % uint64(time.Second); frac > 0 {
+			sb.WriteString("." + strings.TrimRight(strconv.FormatUint(uint64(time.Second)+frac, 10)[1:], "0"))
+		}
+		sb.WriteByte('S')
+	}
+	return sb.String()
+}
+
+// ParseISO8601Duration parses an ISO 8601 duration with weeks, days, hours, minutes and seconds, e.g. P1DT12H or
+// PT0.5S. A leading minus sign denotes a negative duration. Days are 24 hours and weeks 7 days. Years and months are
+// rejected, since their duration depends on the date they apply to. Only the seconds may have a fraction.
+func ParseISO8601Duration(s string) (time.Duration, error) {
+	e := errors.Template("ParseISO8601Duration", errors.K.Invalid, ErrParse, "duration", s)
+
+	str := s
+	neg := false
+	if strings.HasPrefix(str, "-") {
+		neg = true
+		str = str[1:]
+	}
+	if !strings.HasPrefix(str, "P") || len(str) < 3 {
+		return 0, e("reason", "invalid format")
+	}
+	str = str[1:]
+
+	var res time.Duration
+	inTime := false
+	units := "WDHMS"
+	for len(str) > 0 {
+		if str[0] == 'T' {
+			if inTime || len(str) == 1 {
+				return 0, e("reason", "invalid format")
+			}
+			inTime = true
+			str = str[1:]
+			continue
+		}
+		idx := strings.IndexAny(str, "YWDHMS")
+		if idx <= 0 {
+			return 0, e("reason", "invalid format")
+		}
+		num, unit := str[:idx], str[idx]
+		str = str[idx+1:]
+
+		var factor time.Duration
+		switch {
+		case unit == 'Y' || (unit == 'M' && !inTime):
+			return 0, e("reason", "years and months are not supported")
+		case unit == 'W' && !inTime:
+			factor = 7 * oneDay
+		case unit == 'D' && !inTime:
+			factor = oneDay
+		case unit == 'H' && inTime:
+			factor = time.Hour
+		case unit == 'M' && inTime:
+			factor = time.Minute
+		case unit == 'S' && inTime:
+			factor = time.Second
+		default:
+			return 0, e("reason", "invalid unit", "unit", string(unit))
+		}
+		// units must be in order and appear at most once
+		pos := strings.IndexByte(units, unit)
+		if pos < 0 {
+			return 0, e("reason", "invalid unit order", "unit", string(unit))
+		}
+		units = units[pos+1:]
+
+		var frac time.Duration
+		if dot := strings.IndexAny(num, ".,"); dot >= 0 {
+			if unit != 'S' || len(num) == dot+1 || len(num)-dot-1 > 9 {
+				return 0, e("reason", "invalid fraction")
+			}
+			digits := num[dot+1:]
+			f, err := strconv.ParseUint(digits+strings.Repeat("0", 9-len(digits)), 10, 64)
+			if err != nil {
+				return 0, e(WrapSentinel(ErrParse, err), "reason", "invalid fraction")
+			}
+			frac = time.Duration(f)
+			num = num[:dot]
+		}
+		n, err := strconv.ParseInt(num, 10, 64)
+		if err != nil || n < 0 {
+			return 0, e(WrapSentinel(ErrParse, err), "reason", "invalid number")
+		}
+		if n > int64((1<<63-1-res-frac)/factor) {
+			return 0, e(ErrOutOfRange, "reason", "duration overflow")
+		}
+		res += time.Duration(n)*factor + frac
+	}
+	if neg {
+		res = -res
+	}
+	return res, nil
+}
+
+// durationUnits are the units supported by ParseDuration, in descending order.
+var durationUnits = []struct {
+	name string
+	d    time.Duration
+}{
+	{"y", 365 * oneDay},
+	{"mo", 30 * oneDay},
+	{"w", 7 * oneDay},
+	{"d", oneDay},
+	{"h", time.Hour},
+	{"m", time.Minute},
+	{"s", time.Second},
+	{"ms", time.Millisecond},
+	{"us", time.Microsecond},
+	{"µs", time.Microsecond},
+	{"ns", time.Nanosecond},
+}
+
+// ParseDuration parses a duration string like time.ParseDuration, but additionally supports the units of days,
+// weeks, months and years, similar to Prometheus durations: "1d", "2w", "1y6mo", "1d12h30m".
+//
+// The duration is a sequence of numbers with a unit suffix and an optional leading sign. Numbers may have a decimal
+// fraction: "1.5d". Like with time.ParseDuration, units may appear in any order and more than once: "30s1m" is 90
+// seconds. Since a time.Duration is a fixed amount of time, the calendar units use the following fixed conventions:
+//
+//   - d: a day is 24 hours
+//   - w: a week is 7 days
+//   - mo: a month is 30 days
+//   - y: a year is 365 days
+//
+// Use AddDate or DiffCalendar for calendar arithmetic that takes the actual length of months and years into account.
+// The other supported units are "h", "m", "s", "ms", "us" (or "µs") and "ns".
+func ParseDuration(s string) (time.Duration, error) {
+	e := errors.Template("ParseDuration", errors.K.Invalid, ErrParse, "duration", s)
+
+	str := s
+	neg := false
+	if str != "" && (str[0] == '-' || str[0] == '+') {
+		neg = str[0] == '-'
+		str = str[1:]
+	}
+	if str == "0" {
+		return 0, nil
+	}
+	if str == "" {
+		return 0, e("reason", "invalid format")
+	}
+
+	var res time.Duration
+	for str != "" {
+		// number
+		idx := 0
+		for idx < len(str) && (str[idx] >= '0' && str[idx] <= '9' || str[idx] == '.') {
+			idx++
+		}
+		num := str[:idx]
+		str = str[idx:]
+		if num == "" || num == "." {
+			return 0, e("reason", "missing number")
+		}
+
+		// unit
+		unitIdx := -1
+		for i, unit := range durationUnits {
+			// longest match: "ms" and "mo" take precedence over "m"
+			if strings.HasPrefix(str, unit.name) && (unitIdx < 0 || len(unit.name) > len(durationUnits[unitIdx].name)) {
+				unitIdx = i
+			}
+		}
+		if unitIdx < 0 {
+			return 0, e("reason", "missing or unknown unit", "at", str)
+		}
+		unit := durationUnits[unitIdx]
+		str = str[len(unit.name):]
+
+		d, err := scaleDuration(num, unit.d)
+		if err == nil && d > 1<<63-1-res {
+			err = ErrOutOfRange
+		}
+		if err != nil {
+			return 0, e(WrapSentinel(ErrParse, err), "reason", "invalid number or overflow", "number", num)
+		}
+		res += d
+	}
+	if neg {
+		res = -res
+	}
+	return res, nil
+}
+
+// scaleDuration returns the given decimal number times the given unit.
+func scaleDuration(num string, unit time.Duration) (time.Duration, error) {
+	intPart, fracPart, _ := strings.Cut(num, ".")
+	var res time.Duration
+	if intPart != "" {
+		n, err := strconv.ParseInt(intPart, 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		if n > int64((1<<63-1)/unit) {
+			return 0, ErrOutOfRange
+		}
+		res = time.Duration(n) * unit
+	}
+	if len(fracPart) > 18 {
+		fracPart = fracPart[:18]
+	}
+	if fracPart != "" {
+		f, err := strconv.ParseInt(fracPart, 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		scale := 1.0
+		for range fracPart {
+			scale *= 10
+		}
+		res += time.Duration(float64(f) * (float64(unit) / scale))
+	}
+	if res < 0 {
+		return 0, ErrOutOfRange
+	}
+	return res, nil
+}
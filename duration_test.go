@@ -0,0 +1,154 @@
+package utc_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+type durationConfig struct {
+	Timeout utc.DurationValue   `json:"timeout"`
+	Period  utc.DurationISO8601 `json:"period"`
+}
+
+func TestDurationValue_JSON(t *testing.T) {
+	cfg := durationConfig{
+		Timeout: utc.NewDurationValue(90 * time.Minute),
+		Period:  utc.DurationISO8601{DurationValue: utc.NewDurationValue(36*time.Hour + 500*time.Millisecond)},
+	}
+	bts, err := json.Marshal(cfg)
+	require.NoError(t, err)
+	require.Equal(t, `{"timeout":"1h30m0s","period":"PT36H0.5S"}`, string(bts))
+
+	var res durationConfig
+	require.NoError(t, json.Unmarshal(bts, &res))
+	require.Equal(t, cfg, res)
+
+	tests := []struct {
+		jsn  string
+		want time.Duration
+	}{
+		{`"1h30m"`, 90 * time.Minute},
+		{`"PT1H30M"`, 90 * time.Minute},
+		{`"-PT1M"`, -time.Minute},
+		{`"P1D"`, 24 * time.Hour},
+		{`1500000000`, 1500 * time.Millisecond},
+	}
+	for _, test := range tests {
+		t.Run(test.jsn, func(t *testing.T) {
+			var d utc.DurationValue
+			require.NoError(t, json.Unmarshal([]byte(test.jsn), &d))
+			require.Equal(t, test.want, d.Duration)
+		})
+	}
+
+	// null is a no-op
+	res = cfg
+	require.NoError(t, json.Unmarshal([]byte(`{"timeout":null,"period":null}`), &res))
+	require.Equal(t, cfg, res)
+	d := utc.NewDurationValue(time.Minute)
+	require.NoError(t, d.UnmarshalJSON([]byte("null")))
+	require.Equal(t, time.Minute, d.Duration)
+
+	for _, jsn := range []string{`"1x"`, `"P1Y"`, `1.5`, `{}`, `"`} {
+		var d utc.DurationValue
+		require.Error(t, json.Unmarshal([]byte(jsn), &d), jsn)
+	}
+}
+
+func TestDurationValue_Text(t *testing.T) {
+	d := utc.NewDurationValue(1500 * time.Millisecond)
+	bts, err := d.MarshalText()
+	require.NoError(t, err)
+	require.Equal(t, "1.5s", string(bts))
+
+	var res utc.DurationValue
+	require.NoError(t, res.UnmarshalText(bts))
+	require.Equal(t, d, res)
+}
+
+func TestISO8601Duration(t *testing.T) {
+	tests := []struct {
+		s    string
+		d    time.Duration
+		norm string
+	}{
+		{"PT0S", 0, "PT0S"},
+		{"PT1H30M", 90 * time.Minute, "PT1H30M"},
+		{"PT90M", 90 * time.Minute, "PT1H30M"},
+		{"PT0.000000001S", 1, "PT0.000000001S"},
+		{"PT1,5S", 1500 * time.Millisecond, "PT1.5S"},
+		{"P1W", 7 * 24 * time.Hour, "PT168H"},
+		{"P1DT1S", 24*time.Hour + time.Second, "PT24H1S"},
+		{"-P2DT3H4M5.6S", -(51*time.Hour + 4*time.Minute + 5600*time.Millisecond), "-PT51H4M5.6S"},
+		{"PT2562047H47M16.854775807S", time.Duration(1<<63 - 1), "PT2562047H47M16.854775807S"},
+	}
+	for _, test := range tests {
+		t.Run(test.s, func(t *testing.T) {
+			d, err := utc.ParseISO8601Duration(test.s)
+			require.NoError(t, err)
+			assert.Equal(t, test.d, d)
+			assert.Equal(t, test.norm, utc.FormatISO8601Duration(d))
+		})
+	}
+
+	for _, s := range []string{
+		"", "P", "PT", "P1DT", "1H", "P1H", "PT1D", "P1Y", "P1M", "PT1S1M", "PT1H1H", "P1.5D", "PT1.S",
+		"PT1.0000000001S", "PT-1S", "PTS", "PT2562048H", "P1DX",
+	} {
+		_, err := utc.ParseISO8601Duration(s)
+		require.Error(t, err, s)
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	day := 24 * time.Hour
+	tests := []struct {
+		s    string
+		want time.Duration
+	}{
+		{"0", 0},
+		{"1d", day},
+		{"2w", 14 * day},
+		{"1y6mo", 365*day + 180*day},
+		{"1d12h30m", day + 12*time.Hour + 30*time.Minute},
+		{"1.5d", 36 * time.Hour},
+		{"-1.5h", -90 * time.Minute},
+		{"+5m", 5 * time.Minute},
+		{"1m30s500ms", 90*time.Second + 500*time.Millisecond},
+		{"1s2ms3us4ns", time.Second + 2*time.Millisecond + 3*time.Microsecond + 4},
+		{"3µs", 3 * time.Microsecond},
+		{".5s", 500 * time.Millisecond},
+		{"2562047h47m16.854775807s", 1<<63 - 1},
+		// any order and repeated units, like time.ParseDuration
+		{"30s1m", 90 * time.Second},
+		{"1d1y", 366 * day},
+		{"1h1h", 2 * time.Hour},
+		{"1us1µs", 2 * time.Microsecond},
+	}
+	for _, test := range tests {
+		t.Run(test.s, func(t *testing.T) {
+			d, err := utc.ParseDuration(test.s)
+			require.NoError(t, err)
+			require.Equal(t, test.want, d)
+
+			if std, err := time.ParseDuration(test.s); err == nil {
+				require.Equal(t, std, d)
+			}
+		})
+	}
+
+	for _, s := range []string{"", "-", "d", "1", "1x", ".s", "1..5s", "300y", "2562048h"} {
+		_, err := utc.ParseDuration(s)
+		require.Error(t, err, s)
+	}
+
+	var d utc.DurationValue
+	require.NoError(t, json.Unmarshal([]byte(`"1y6mo"`), &d))
+	require.Equal(t, 545*day, d.Duration)
+}
@@ -0,0 +1,34 @@
+// Package entutc provides the column types for utc.UTC fields in ent schemas, so that timestamps are stored with a
+// fixed fractional second precision on all dialects:
+//
+//	func (Event) Fields() []ent.Field {
+//		return []ent.Field{
+//			field.Time("created").
+//				GoType(utc.SQLMillis{}).
+//				SchemaType(entutc.SchemaType(3)),
+//		}
+//	}
+//
+// utc.UTC, utc.SQLMillis and utc.SQLMicros implement the driver.Valuer and sql.Scanner interfaces and are therefore
+// valid ent GoTypes for time fields: they are the codec that ent uses to store and load the values, with utc.SQLMillis
+// and utc.SQLMicros truncating values to the precision of the column. Without a schema type, ent creates columns with
+// the dialect's default timestamp type, whose precision varies: e.g. timestamp on MySQL truncates to seconds.
+package entutc
+
+import (
+	"strconv"
+
+	"entgo.io/ent/dialect"
+)
+
+// SchemaType returns the timestamp column types with the given fractional second precision for the dialects supported
+// by ent, for use with the SchemaType option of time fields: datetime(p) on MySQL, timestamptz(p) on PostgreSQL and
+// datetime on SQLite, which stores timestamps as text with the precision of the value.
+func SchemaType(precision int) map[string]string {
+	p := "(" + strconv.Itoa(precision) + ")"
+	return map[string]string{
+		dialect.MySQL:    "datetime" + p,
+		dialect.Postgres: "timestamptz" + p,
+		dialect.SQLite:   "datetime",
+	}
+}
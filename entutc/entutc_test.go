@@ -0,0 +1,35 @@
+package entutc_test
+
+import (
+	"testing"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/schema/field"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+	"github.com/eluv-io/utc-go/entutc"
+)
+
+func TestSchemaType(t *testing.T) {
+	require.Equal(t, map[string]string{
+		dialect.MySQL:    "datetime(3)",
+		dialect.Postgres: "timestamptz(3)",
+		dialect.SQLite:   "datetime",
+	}, entutc.SchemaType(3))
+}
+
+func TestField(t *testing.T) {
+	for _, typ := range []any{utc.UTC{}, utc.SQLMillis{}, utc.SQLMicros{}} {
+		d := field.Time("created").
+			GoType(typ).
+			SchemaType(entutc.SchemaType(6)).
+			Descriptor()
+		require.NoError(t, d.Err)
+		require.Equal(t, "timestamptz(6)", d.SchemaType[dialect.Postgres])
+		require.True(t, d.Info.ValueScanner())
+	}
+
+	d := field.Time("created").GoType(utc.UTC{}).Default(utc.Now).Descriptor()
+	require.NoError(t, d.Err)
+}
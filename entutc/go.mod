@@ -0,0 +1,21 @@
+module github.com/eluv-io/utc-go/entutc
+
+go 1.23
+
+require (
+	entgo.io/ent v0.14.5
+	github.com/eluv-io/utc-go v0.0.0
+	github.com/stretchr/testify v1.8.4
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/eluv-io/errors-go v1.0.3 // indirect
+	github.com/eluv-io/stack v1.8.2 // indirect
+	github.com/google/uuid v1.3.0 // indirect
+	github.com/kr/text v0.2.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/eluv-io/utc-go => ../
@@ -0,0 +1,246 @@
+package utc
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eluv-io/errors-go"
+)
+
+// UTCUnixSec is a UTC that marshals to the number of seconds since the unix epoch in JSON and text: 1646370367.
+// Precision finer than seconds is truncated. The zero value marshals to JSON null and empty text.
+//
+// Unmarshaling accepts numbers (with an optional decimal fraction, e.g. 1646370367.123) as well as ISO 8601 strings.
+// Numbers in JSON strings are interpreted as epoch seconds, too.
+type UTCUnixSec struct {
+	UTC
+}
+
+// UTCUnixMilli is a UTC that marshals to the number of milliseconds since the unix epoch in JSON and text:
+// 1646370367123. Precision finer than milliseconds is truncated. The zero value marshals to JSON null and empty text.
+//
+// Unmarshaling accepts numbers (with an optional decimal fraction, e.g. 1646370367123.456) as well as ISO 8601
+// strings. Numbers in JSON strings are interpreted as epoch milliseconds, too.
+type UTCUnixMilli struct {
+	UTC
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (u UTCUnixSec) MarshalJSON() ([]byte, error) {
+	if u.IsZero() {
+		return []byte("null"), nil
+	}
+	return strconv.AppendInt(nil, u.Unix(), 10), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (u *UTCUnixSec) UnmarshalJSON(data []byte) error {
+	return unmarshalEpochJSON("UTCUnixSec.UnmarshalJSON", &u.UTC, data, time.Second)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (u UTCUnixSec) MarshalText() ([]byte, error) {
+	if u.IsZero() {
+		return nil, nil
+	}
+	return strconv.AppendInt(nil, u.Unix(), 10), nil
+}
+
+// AppendText implements the encoding.TextAppender interface.
+func (u UTCUnixSec) AppendText(b []byte) ([]byte, error) {
+	if u.IsZero() {
+		return b, nil
+	}
+	return strconv.AppendInt(b, u.Unix(), 10), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (u *UTCUnixSec) UnmarshalText(data []byte) error {
+	return unmarshalEpochText("UTCUnixSec.UnmarshalText", &u.UTC, data, time.Second)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (u UTCUnixMilli) MarshalJSON() ([]byte, error) {
+	if u.IsZero() {
+		return []byte("null"), nil
+	}
+	return strconv.AppendInt(nil, u.UnixMilli(), 10), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (u *UTCUnixMilli) UnmarshalJSON(data []byte) error {
+	return unmarshalEpochJSON("UTCUnixMilli.UnmarshalJSON", &u.UTC, data, time.Millisecond)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (u UTCUnixMilli) MarshalText() ([]byte, error) {
+	if u.IsZero() {
+		return nil, nil
+	}
+	return strconv.AppendInt(nil, u.UnixMilli(), 10), nil
+}
+
+// AppendText implements the encoding.TextAppender interface.
+func (u UTCUnixMilli) AppendText(b []byte) ([]byte, error) {
+	if u.IsZero() {
+		return b, nil
+	}
+	return strconv.AppendInt(b, u.UnixMilli(), 10), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (u *UTCUnixMilli) UnmarshalText(data []byte) error {
+	return unmarshalEpochText("UTCUnixMilli.UnmarshalText", &u.UTC, data, time.Millisecond)
+}
+
+// UnixDecimal returns the number of seconds since the unix epoch as decimal string with the given number of fraction
+// digits, e.g. "1700000000.123456" for 6 digits, as used in HAProxy logs, S3 event records and by Python's
+// time.time(). The digits are clamped to [0, 9]; finer precision is truncated towards the past.
+func (u UTC) UnixDecimal(digits int) string {
+	return string(u.AppendUnixDecimal(nil, digits))
+}
+
+// AppendUnixDecimal appends the decimal representation of the seconds since the unix epoch to b - see UnixDecimal.
+func (u UTC) AppendUnixDecimal(b []byte, digits int) []byte {
+	digits = min(max(digits, 0), 9)
+	unit := int64(math.Pow10(9 - digits))
+	sec := u.Unix()
+	frac := int64(u.Nanosecond()) / unit
+	if sec < 0 && frac > 0 {
+		// the fraction is positive, the decimal representation has the sign of the whole number: -2 + 0.5 = -1.5
+		b = append(b, '-')
+		b = strconv.AppendUint(b, uint64(-(sec + 1)), 10)
+		frac = int64(math.Pow10(digits)) - frac
+	} else {
+		b = strconv.AppendInt(b, sec, 10)
+	}
+	if digits == 0 {
+		return b
+	}
+	b = append(b, '.')
+	fs := strconv.FormatInt(frac, 10)
+	for i := len(fs); i < digits; i++ {
+		b = append(b, '0')
+	}
+	return append(b, fs...)
+}
+
+// ParseUnixDecimal parses a decimal number of seconds since the unix epoch with optional sign and fraction, e.g.
+// "1700000000.123456". Fraction digits beyond nanoseconds are truncated.
+func ParseUnixDecimal(s string) (UTC, error) {
+	res, ok := parseEpoch(strings.TrimSpace(s), time.Second)
+	if !ok {
+		return Zero, errors.E("ParseUnixDecimal", errors.K.Invalid, ErrParse,
+			"reason", "invalid decimal epoch seconds",
+			"value", s)
+	}
+	return res, nil
+}
+
+// unmarshalEpochJSON decodes JSON null (a no-op), a JSON number or a JSON string into u.
+func unmarshalEpochJSON(op string, u *UTC, data []byte, unit time.Duration) error {
+	if string(data) == "null" {
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return errors.E(op, errors.K.Invalid, WrapSentinel(ErrParse, err))
+		}
+		data = []byte(s)
+	}
+	return unmarshalEpochText(op, u, data, unit)
+}
+
+// unmarshalEpochText decodes an empty string (the zero value), an epoch number in the given unit or an ISO 8601
+// string into u.
+func unmarshalEpochText(op string, u *UTC, data []byte, unit time.Duration) error {
+	s := strings.TrimSpace(string(data))
+	if s == "" {
+		*u = Zero
+		return nil
+	}
+	if res, ok := parseEpoch(s, unit); ok {
+		*u = res
+		return nil
+	}
+	res, err := FromString(s)
+	if err != nil {
+		return errors.E(op, errors.K.Invalid, err, "value", s)
+	}
+	*u = res
+	return nil
+}
+
+// parseEpoch parses a decimal number with optional sign and fraction as time since the unix epoch in the given unit.
+// Fraction digits beyond nanoseconds are ignored.
+func parseEpoch(s string, unit time.Duration) (UTC, bool) {
+	neg := strings.HasPrefix(s, "-")
+	if neg {
+		s = s[1:]
+	}
+	intPart, fracPart, hasFrac := strings.Cut(s, ".")
+	if !isDigits(intPart) || hasFrac && !isDigits(fracPart) {
+		return Zero, false
+	}
+	n, err := strconv.ParseInt(intPart, 10, 64)
+	if err != nil {
+		return Zero, false
+	}
+	perSec := int64(time.Second / unit)
+	sec := n / perSec
+	nsec := n % perSec * int64(unit)
+	if hasFrac {
+		if len(fracPart) > 9 {
+			fracPart = fracPart[:9]
+		}
+		f, _ := strconv.ParseInt(fracPart+strings.Repeat("0", 9-len(fracPart)), 10, 64)
+		nsec += f * int64(unit) / int64(time.Second)
+	}
+	if neg {
+		sec, nsec = -sec, -nsec
+	}
+	return Unix(sec, nsec), true
+}
+
+// isDigits returns true if s is not empty and consists of ASCII digits only.
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// EpochNanos returns the number of nanoseconds since the unix epoch as unsigned integer, as used for span and log
+// timestamps by OpenTelemetry. Zero returns 0, which denotes an unset timestamp in OpenTelemetry. Instants before the
+// epoch return 0, instants after the maximum representable value (in the year 2554) return math.MaxUint64.
+func (u UTC) EpochNanos() uint64 {
+	if u.IsZero() {
+		return 0
+	}
+	sec := u.Unix()
+	if sec < 0 {
+		return 0
+	}
+	if uint64(sec) > (math.MaxUint64-uint64(u.Nanosecond()))/uint64(time.Second) {
+		return math.MaxUint64
+	}
+	return uint64(sec)*uint64(time.Second) + uint64(u.Nanosecond())
+}
+
+// FromEpochNanos returns the UTC for the given number of nanoseconds since the unix epoch, the inverse of EpochNanos.
+// 0 returns Zero.
+func FromEpochNanos(nanos uint64) UTC {
+	if nanos == 0 {
+		return Zero
+	}
+	return Unix(int64(nanos/uint64(time.Second)), int64(nanos%uint64(time.Second)))
+}
@@ -0,0 +1,154 @@
+package utc_test
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+func TestUTCUnixSec(t *testing.T) {
+	u := utc.UTCUnixSec{UTC: utc.MustParse("2022-03-04T05:06:07.123Z")}
+
+	jsn, err := json.Marshal(u)
+	require.NoError(t, err)
+	require.Equal(t, "1646370367", string(jsn))
+
+	txt, err := u.MarshalText()
+	require.NoError(t, err)
+	require.Equal(t, "1646370367", string(txt))
+
+	tests := []struct {
+		jsn  string
+		want string
+	}{
+		{`1646370367`, "2022-03-04T05:06:07.000Z"},
+		{`1646370367.123`, "2022-03-04T05:06:07.123Z"},
+		{`"1646370367"`, "2022-03-04T05:06:07.000Z"},
+		{`"2022-03-04T05:06:07.123Z"`, "2022-03-04T05:06:07.123Z"},
+		{`0`, "1970-01-01T00:00:00.000Z"},
+		{`-1.5`, "1969-12-31T23:59:58.500Z"},
+		{`""`, utc.Zero.String()},
+	}
+	for _, test := range tests {
+		t.Run(test.jsn, func(t *testing.T) {
+			var res utc.UTCUnixSec
+			require.NoError(t, json.Unmarshal([]byte(test.jsn), &res))
+			require.Equal(t, test.want, res.String())
+		})
+	}
+}
+
+func TestUTCUnixMilli(t *testing.T) {
+	u := utc.UTCUnixMilli{UTC: utc.MustParse("2022-03-04T05:06:07.123456Z")}
+
+	jsn, err := json.Marshal(u)
+	require.NoError(t, err)
+	require.Equal(t, "1646370367123", string(jsn))
+
+	tests := []struct {
+		jsn  string
+		want string
+	}{
+		{`1646370367123`, "2022-03-04T05:06:07.123Z"},
+		{`1646370367123.456`, "2022-03-04T05:06:07.123456Z"},
+		{`"1646370367123"`, "2022-03-04T05:06:07.123Z"},
+		{`"2022-03-04T05:06:07.123Z"`, "2022-03-04T05:06:07.123Z"},
+		{`-1`, "1969-12-31T23:59:59.999Z"},
+	}
+	for _, test := range tests {
+		t.Run(test.jsn, func(t *testing.T) {
+			var res utc.UTCUnixMilli
+			require.NoError(t, json.Unmarshal([]byte(test.jsn), &res))
+			require.True(t, utc.MustParse(test.want).Equal(res.UTC), res.String())
+		})
+	}
+}
+
+func TestEpoch_zeroAndNull(t *testing.T) {
+	type wrapper struct {
+		Sec   utc.UTCUnixSec   `json:"sec"`
+		Milli utc.UTCUnixMilli `json:"milli"`
+	}
+	jsn, err := json.Marshal(wrapper{})
+	require.NoError(t, err)
+	require.Equal(t, `{"sec":null,"milli":null}`, string(jsn))
+
+	var res wrapper
+	require.NoError(t, json.Unmarshal(jsn, &res))
+	require.True(t, res.Sec.IsZero())
+	require.True(t, res.Milli.IsZero())
+}
+
+func TestEpoch_invalid(t *testing.T) {
+	for _, jsn := range []string{`"abc"`, `1.2.3`, `"1e9"`, `true`, `"--1"`} {
+		var sec utc.UTCUnixSec
+		require.Error(t, json.Unmarshal([]byte(jsn), &sec), jsn)
+		var milli utc.UTCUnixMilli
+		require.Error(t, json.Unmarshal([]byte(jsn), &milli), jsn)
+	}
+}
+
+func TestEpochNanos(t *testing.T) {
+	u := utc.MustParse("2022-03-04T05:06:07.123456789Z")
+	require.Equal(t, uint64(1646370367123456789), u.EpochNanos())
+	require.True(t, u.Equal(utc.FromEpochNanos(1646370367123456789)))
+
+	require.Equal(t, uint64(0), utc.Zero.EpochNanos())
+	require.True(t, utc.FromEpochNanos(0).IsZero())
+	require.Equal(t, uint64(0), utc.MustParse("1969-12-31T23:59:59Z").EpochNanos())
+	require.Equal(t, uint64(1), utc.Unix(0, 1).EpochNanos())
+	require.Equal(t, uint64(math.MaxUint64), utc.Max.EpochNanos())
+
+	// beyond the range of time.Duration and UnixNano
+	far := utc.FromEpochNanos(math.MaxUint64)
+	require.Equal(t, 2554, far.Year())
+	require.Equal(t, uint64(math.MaxUint64), far.EpochNanos())
+}
+
+func TestUnixDecimal(t *testing.T) {
+	u := utc.MustParse("2023-11-14T22:13:20.123456789Z")
+	require.Equal(t, "1700000000", u.UnixDecimal(0))
+	require.Equal(t, "1700000000.123", u.UnixDecimal(3))
+	require.Equal(t, "1700000000.123456", u.UnixDecimal(6))
+	require.Equal(t, "1700000000.123456789", u.UnixDecimal(9))
+	require.Equal(t, "1700000000.123456789", u.UnixDecimal(12))
+	require.Equal(t, "1700000000", u.UnixDecimal(-1))
+	require.Equal(t, "1700000000.000001", utc.Unix(1700000000, 1000).UnixDecimal(6))
+	require.Equal(t, "x=1700000000.12", string(u.AppendUnixDecimal([]byte("x="), 2)))
+
+	// before the epoch
+	require.Equal(t, "-1.500", utc.Unix(-2, 5e8).UnixDecimal(3))
+	require.Equal(t, "-2", utc.Unix(-2, 5e8).UnixDecimal(0))
+	require.Equal(t, "-2.000", utc.Unix(-2, 0).UnixDecimal(3))
+	require.Equal(t, "-0.250", utc.Unix(-1, 75e7).UnixDecimal(3))
+
+	for _, s := range []string{
+		"1700000000.123456",
+		"1700000000",
+		"-1.5",
+		"-0.25",
+		"0.000000001",
+	} {
+		res, err := utc.ParseUnixDecimal(s)
+		require.NoError(t, err, s)
+		digits := 0
+		if i := strings.Index(s, "."); i >= 0 {
+			digits = len(s) - i - 1
+		}
+		require.Equal(t, s, res.UnixDecimal(digits))
+	}
+
+	res, err := utc.ParseUnixDecimal(" 1700000000.1234567891 ")
+	require.NoError(t, err)
+	require.True(t, utc.Unix(1700000000, 123456789).Equal(res))
+
+	for _, s := range []string{"", "abc", "1.", ".5", "1.5e3", "+1", "1,5"} {
+		_, err := utc.ParseUnixDecimal(s)
+		require.Error(t, err, s)
+	}
+}
@@ -0,0 +1,56 @@
+package utc
+
+import (
+	"github.com/eluv-io/errors-go"
+)
+
+// Sentinel errors wrapped by the errors of the parsing and validation functions of this package, so that callers can
+// branch with errors.Is instead of inspecting the fields of the errors:
+//
+//	u, err := utc.FromString(s)
+//	if errors.Is(err, utc.ErrParse) {
+//		...
+//	}
+//
+// The returned errors remain *errors.Error values of github.com/eluv-io/errors-go with their op, kind, fields and
+// original cause, if any: errors.Is and errors.As also match the original cause, e.g. a *time.ParseError.
+var (
+	// ErrParse is wrapped by errors for input that cannot be parsed or decoded, e.g. malformed time, duration or range
+	// strings, invalid leap second tables or values of unsupported types.
+	ErrParse = errors.Str("utc: parse error")
+	// ErrOutOfRange is wrapped by errors for values that are well-formed but outside of the supported or allowed range,
+	// e.g. years beyond 9999 or times that do not fit into an int64 encoding.
+	ErrOutOfRange = errors.Str("utc: out of range")
+	// ErrInvalidLength is wrapped by errors for binary or fixed-width encodings of the wrong length.
+	ErrInvalidLength = errors.Str("utc: invalid length")
+)
+
+// WrapSentinel returns an error that matches both the given sentinel error and the given cause with errors.Is and
+// errors.As, and has the message of the cause. It returns the sentinel if the cause is nil, and the cause itself if it
+// already wraps any of the sentinel errors, so that the classification of nested errors is retained. Use it as the cause
+// of an *errors.Error, which holds a single cause only, in adapters for other libraries:
+//
+//	return errors.E("UnmarshalCQL", errors.K.Invalid, utc.WrapSentinel(utc.ErrParse, err))
+func WrapSentinel(sentinel, cause error) error {
+	if cause == nil {
+		return sentinel
+	}
+	if errors.Is(cause, ErrParse) || errors.Is(cause, ErrOutOfRange) || errors.Is(cause, ErrInvalidLength) {
+		return cause
+	}
+	return &sentinelError{sentinel: sentinel, cause: cause}
+}
+
+// sentinelError is the error returned by WrapSentinel.
+type sentinelError struct {
+	sentinel error
+	cause    error
+}
+
+func (e *sentinelError) Error() string {
+	return e.cause.Error()
+}
+
+func (e *sentinelError) Unwrap() []error {
+	return []error{e.cause, e.sentinel}
+}
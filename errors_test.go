@@ -0,0 +1,87 @@
+package utc_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/errors-go"
+	"github.com/eluv-io/utc-go"
+)
+
+func TestSentinelErrors(t *testing.T) {
+	parse := func(_ interface{}, err error) error { return err }
+
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"FromString", parse(utc.FromString("2020-13-01")), utc.ErrParse},
+		{"FromString leap second", parse(utc.FromString("2020-01-01T10:00:60Z")), utc.ErrParse},
+		{"FromString expanded year", parse(utc.FromString("+1234567890-01-01T00:00:00Z")), utc.ErrOutOfRange},
+		{"Parse", parse(utc.Parse(time.RFC1123, "2020")), utc.ErrParse},
+		{"ParseAny", parse(utc.ParseAny("yesterday")), utc.ErrParse},
+		{"ParseZoned", parse(utc.ParseZoned("x")), utc.ErrParse},
+		{"ParseSlug", parse(utc.ParseSlug("x")), utc.ErrParse},
+		{"ParseHTTPDate", parse(utc.ParseHTTPDate("x")), utc.ErrParse},
+		{"ParseRetryAfter", parse(utc.ParseRetryAfter("99999999999999999999", nil)), utc.ErrOutOfRange},
+		{"ParseGeneralizedTime", parse(utc.ParseGeneralizedTime("x")), utc.ErrParse},
+		{"ParseUnixDecimal", parse(utc.ParseUnixDecimal("x")), utc.ErrParse},
+		{"ParseDeadline", parse(utc.ParseDeadline("x")), utc.ErrParse},
+		{"ParseDuration", parse(utc.ParseDuration("1x")), utc.ErrParse},
+		{"ParseDuration overflow", parse(utc.ParseDuration("9999999999h")), utc.ErrOutOfRange},
+		{"ParseISO8601Duration", parse(utc.ParseISO8601Duration("PT")), utc.ErrParse},
+		{"ParseISO8601Duration overflow", parse(utc.ParseISO8601Duration("PT9999999999H")), utc.ErrOutOfRange},
+		{"ParseTimeRange", parse(utc.ParseTimeRange("now-1x", "now", nil)), utc.ErrParse},
+		{"ParseTimeRange order", parse(utc.ParseTimeRange("now", "now-1d", nil)), utc.ErrOutOfRange},
+		{"ParseTimeRange overflow", parse(utc.ParseTimeRange("now-3000000h", "now", nil)), utc.ErrOutOfRange},
+		{"ParseLeapSeconds", parse(utc.ParseLeapSeconds(strings.NewReader("x"))), utc.ErrParse},
+		{"ValidateRFC3339", utc.ValidateRFC3339("2020-01-01"), utc.ErrParse},
+		{"DecodeCompact length", parse(utc.DecodeCompact("x", utc.Base62Millis)), utc.ErrInvalidLength},
+		{"DecodeCompact value", parse(utc.DecodeCompact("!!!!!!!!!", utc.Base62Millis)), utc.ErrParse},
+		{"UnmarshalJSON", json.Unmarshal([]byte(`"x"`), new(utc.UTC)), utc.ErrParse},
+		{"UnmarshalJSON no string", json.Unmarshal([]byte(`1`), new(utc.UTC)), utc.ErrParse},
+		{"UnmarshalBinary", new(utc.UTC).UnmarshalBinary([]byte{1}), utc.ErrInvalidLength},
+		{"Zoned.UnmarshalBinary", new(utc.Zoned).UnmarshalBinary([]byte{1}), utc.ErrInvalidLength},
+		{"Scan", new(utc.UTC).Scan("x"), utc.ErrParse},
+		{"Scan unsupported type", new(utc.UTC).Scan(42), utc.ErrParse},
+		{"Sscan unsupported verb", parse(fmt.Sscanf("x", "%d", utc.ScanInto(new(utc.UTC)))), utc.ErrParse},
+		{"SetLeapSeconds", utc.SetLeapSeconds(utc.LeapSecondTable{}), utc.ErrParse},
+		{"DurationValue.UnmarshalJSON", new(utc.DurationValue).UnmarshalJSON([]byte(`"x`)), utc.ErrParse},
+		{"DurationValue.UnmarshalJSON nanos", json.Unmarshal([]byte(`1.5`), new(utc.DurationValue)), utc.ErrParse},
+		{"AddChecked", parse(utc.Max.AddChecked(time.Hour)), utc.ErrOutOfRange},
+		{"ValidateISO8601", utc.Max.Add(time.Hour).ValidateISO8601(), utc.ErrOutOfRange},
+		{"ToUTC64", parse(utc.ToUTC64(utc.Max)), utc.ErrOutOfRange},
+		{"ToTimestamp", parse(utc.Max.ToTimestamp(utc.Nanosecond)), utc.ErrOutOfRange},
+		{"NewCheckedNonZero", parse(utc.NewCheckedNonZero(time.Time{})), utc.ErrOutOfRange},
+		{"NewFiscalCalendar", parse(utc.NewFiscalCalendar(time.January, 29)), utc.ErrOutOfRange},
+		{"NewFrameRate", parse(utc.NewFrameRate(0, 1)), utc.ErrOutOfRange},
+	}
+	sentinels := []error{utc.ErrParse, utc.ErrOutOfRange, utc.ErrInvalidLength}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Error(t, tt.err)
+			for _, sentinel := range sentinels {
+				require.Equal(t, sentinel == tt.want, errors.Is(tt.err, sentinel), "%s: %v", sentinel, tt.err)
+			}
+			// the errors remain errors-go errors
+			require.True(t, errors.IsKind(errors.K.Invalid, tt.err) || errors.IsKind(errors.K.Other, tt.err), tt.err)
+		})
+	}
+}
+
+func TestSentinelErrors_cause(t *testing.T) {
+	_, err := utc.Parse(time.RFC3339, "2020-01-01")
+	require.ErrorIs(t, err, utc.ErrParse)
+
+	// the original cause is retained
+	var perr *time.ParseError
+	require.ErrorAs(t, err, &perr)
+	require.Equal(t, "2020-01-01", perr.Value)
+	require.Contains(t, err.Error(), perr.Error())
+}
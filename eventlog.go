@@ -0,0 +1,173 @@
+package utc
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/eluv-io/errors-go"
+)
+
+// Event is an event recorded in an EventLog.
+type Event struct {
+	At    UTC
+	Label string
+}
+
+// String returns the event formatted as "2006-01-02T15:04:05.000Z label".
+func (e Event) String() string {
+	return e.At.String() + " " + e.Label
+}
+
+// EventLog records labeled events with the time of a Clock, typically a TestClock or the clock of a Simulator, and
+// checks their ordering and spacing. It keeps timing assertions in tests short:
+//
+//	log := utc.NewEventLog(clock)
+//	...
+//	log.Record("started")
+//	...
+//	require.NoError(t, log.CheckOrder("started", "retried", "done"))
+//	require.NoError(t, log.CheckSpacing(1, 2, 500*time.Millisecond, 0))
+//
+// An EventLog is safe for concurrent use.
+type EventLog struct {
+	clock  Clock
+	mu     sync.Mutex
+	events []Event
+}
+
+// NewEventLog creates an EventLog recording events with the time of the given clock, or of utc.Now() if nil.
+func NewEventLog(clock Clock) *EventLog {
+	return &EventLog{clock: clock}
+}
+
+// Record records an event with the given label at the current time of the clock and returns it.
+func (l *EventLog) Record(label string) Event {
+	e := Event{At: clockNow(l.clock), Label: label}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = append(l.events, e)
+	return e
+}
+
+// RecordFn returns a function that records an event with the given label when called, e.g. for use as callback.
+func (l *EventLog) RecordFn(label string) func() {
+	return func() { l.Record(label) }
+}
+
+// Events returns a copy of the recorded events in the order of recording.
+func (l *EventLog) Events() []Event {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]Event(nil), l.events...)
+}
+
+// Labels returns the labels of the recorded events in the order of recording.
+func (l *EventLog) Labels() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	res := make([]string, len(l.events))
+	for i, e := range l.events {
+		res[i] = e.Label
+	}
+	return res
+}
+
+// Len returns the number of recorded events.
+func (l *EventLog) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.events)
+}
+
+// Index returns the index of the first event with the given label, or -1 if there is no such event.
+func (l *EventLog) Index(label string) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.index(label)
+}
+
+// index returns the index of the first event with the given label, or -1. The caller must hold the lock.
+func (l *EventLog) index(label string) int {
+	for i, e := range l.events {
+		if e.Label == label {
+			return i
+		}
+	}
+	return -1
+}
+
+// Reset discards all recorded events.
+func (l *EventLog) Reset() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = nil
+}
+
+// String returns the recorded events, one per line.
+func (l *EventLog) String() string {
+	sb := strings.Builder{}
+	for _, e := range l.Events() {
+		sb.WriteString(e.String())
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// CheckOrder returns an error unless events with the given labels were recorded in the given order, possibly with
+// other events in between. Each label matches the first event with that label after the event matched by the
+// previous label.
+func (l *EventLog) CheckOrder(labels ...string) error {
+	events := l.Events()
+	pos := 0
+	for _, label := range labels {
+		found := false
+		for ; pos < len(events); pos++ {
+			if events[pos].Label == label {
+				found = true
+				pos++
+				break
+			}
+		}
+		if !found {
+			return errors.E("EventLog.CheckOrder", errors.K.Invalid,
+				"reason", "event not found in order",
+				"label", label,
+				"expected", labels,
+				"events", l.Labels())
+		}
+	}
+	return nil
+}
+
+// CheckSpacing returns an error unless the time between the events with index i and j is within [min, max]. A max of
+// 0 means no upper limit. The spacing is negative if event j was recorded at an earlier time than event i.
+func (l *EventLog) CheckSpacing(i, j int, min, max time.Duration) error {
+	events := l.Events()
+	e := errors.Template("EventLog.CheckSpacing", errors.K.Invalid, "i", i, "j", j)
+	if i < 0 || i >= len(events) || j < 0 || j >= len(events) {
+		return e("reason", "index out of range", "len", len(events))
+	}
+	spacing := events[j].At.Sub(events[i].At)
+	if spacing < min || (max != 0 && spacing > max) {
+		return e("reason", "spacing out of range",
+			"spacing", spacing,
+			"min", min,
+			"max", max,
+			"event_i", events[i].String(),
+			"event_j", events[j].String())
+	}
+	return nil
+}
+
+// Spacing returns the time between the first events with the given labels. It returns false if either event was not
+// recorded.
+func (l *EventLog) Spacing(from, to string) (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	i, j := l.index(from), l.index(to)
+	if i < 0 || j < 0 {
+		return 0, false
+	}
+	return l.events[j].At.Sub(l.events[i].At), true
+}
@@ -0,0 +1,78 @@
+package utc_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+func TestEventLog(t *testing.T) {
+	sim := utc.NewSimulator(utc.MustParse("2020-01-01T00:00:00Z"))
+	log := utc.NewEventLog(sim.Clock())
+
+	log.Record("start")
+	sim.After(time.Second, log.RecordFn("retry"))
+	sim.After(1500*time.Millisecond, log.RecordFn("retry"))
+	sim.After(3*time.Second, log.RecordFn("done"))
+	sim.RunFor(time.Minute)
+
+	require.Equal(t, 4, log.Len())
+	require.Equal(t, []string{"start", "retry", "retry", "done"}, log.Labels())
+	require.Equal(t, "2020-01-01T00:00:01.500Z retry", log.Events()[2].String())
+	require.Equal(t, "2020-01-01T00:00:00.000Z start\n"+
+		"2020-01-01T00:00:01.000Z retry\n"+
+		"2020-01-01T00:00:01.500Z retry\n"+
+		"2020-01-01T00:00:03.000Z done\n", log.String())
+
+	require.NoError(t, log.CheckOrder("start", "retry", "retry", "done"))
+	require.NoError(t, log.CheckOrder("start", "done"))
+	require.Error(t, log.CheckOrder("done", "start"))
+	require.Error(t, log.CheckOrder("start", "retry", "retry", "retry"))
+	require.Error(t, log.CheckOrder("unknown"))
+
+	require.NoError(t, log.CheckSpacing(1, 2, 500*time.Millisecond, 0))
+	require.NoError(t, log.CheckSpacing(1, 2, 500*time.Millisecond, 500*time.Millisecond))
+	require.Error(t, log.CheckSpacing(1, 2, 501*time.Millisecond, 0))
+	require.Error(t, log.CheckSpacing(0, 3, 0, 2*time.Second))
+	require.Error(t, log.CheckSpacing(0, 4, 0, 0))
+	require.NoError(t, log.CheckSpacing(3, 0, -3*time.Second, -time.Second))
+
+	require.Equal(t, 1, log.Index("retry"))
+	require.Equal(t, -1, log.Index("unknown"))
+	d, ok := log.Spacing("start", "done")
+	require.True(t, ok)
+	require.Equal(t, 3*time.Second, d)
+	_, ok = log.Spacing("start", "unknown")
+	require.False(t, ok)
+
+	log.Reset()
+	require.Equal(t, 0, log.Len())
+}
+
+func TestEventLog_SpacingConcurrent(t *testing.T) {
+	log := utc.NewEventLog(utc.NewWallClock(utc.MustParse("2020-01-01T00:00:00Z")))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100_000; i++ {
+			log.Record("start")
+			log.Record("done")
+			log.Reset()
+		}
+	}()
+	for {
+		select {
+		case <-done:
+			return
+		default:
+			// the events must not be reset between looking up the labels and their times
+			if d, ok := log.Spacing("start", "done"); ok {
+				require.Equal(t, time.Duration(0), d)
+			}
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package utc
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// EWMA is an exponentially-weighted moving average of observed values, e.g. latencies, whose decay is based on the
+// time of a Clock rather than on the number of observations: an observation loses half of its weight with every
+// half-life that passes. Irregular observations are therefore weighted correctly, and adaptive behavior like load
+// shedding thresholds can be tested by advancing a TestClock. With a nil clock, it uses utc.Now() and hence follows a
+// mocked global clock. An EWMA is safe for concurrent use.
+type EWMA struct {
+	clock    Clock
+	halfLife time.Duration
+
+	mu     sync.Mutex
+	sum    float64 // the decayed sum of the observed values
+	weight float64 // the decayed number of observations
+	last   UTC     // the time of the last update
+}
+
+// NewEWMA creates an EWMA with the given half-life based on the given clock, or on utc.Now() if nil. It panics if the
+// half-life is not positive.
+func NewEWMA(clock Clock, halfLife time.Duration) *EWMA {
+	if halfLife <= 0 {
+		panic("utc.NewEWMA: non-positive half-life")
+	}
+	return &EWMA{clock: clock, halfLife: halfLife}
+}
+
+// Observe adds the given value to the average.
+func (e *EWMA) Observe(v float64) {
+	now := clockNow(e.clock)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	f := e.decay(now)
+	e.sum = e.sum*f + v
+	e.weight = e.weight*f + 1
+}
+
+// ObserveDuration adds the given duration in seconds to the average - see Observe and Duration.
+func (e *EWMA) ObserveDuration(d time.Duration) {
+	e.Observe(d.Seconds())
+}
+
+// Value returns the current average, or 0 if nothing was observed yet. The average does not decay without
+// observations: it is the weighted average of the past observations, with older observations weighted less.
+func (e *EWMA) Value() float64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.weight == 0 {
+		return 0
+	}
+	return e.sum / e.weight
+}
+
+// Duration returns the current average as duration, for averages of durations observed with ObserveDuration.
+func (e *EWMA) Duration() time.Duration {
+	return time.Duration(e.Value() * float64(time.Second))
+}
+
+// Reset discards all observations.
+func (e *EWMA) Reset() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.sum, e.weight, e.last = 0, 0, Zero
+}
+
+// decay returns the decay factor for the time since the last update and sets the time of the last update to now.
+// Must be called with the lock held.
+func (e *EWMA) decay(now UTC) float64 {
+	f := decayFactor(e.last, now, e.halfLife)
+	e.last = Latest(e.last, now)
+	return f
+}
+
+// EWMARate is an exponentially-weighted moving average of the rate of events, e.g. requests per second, whose decay is
+// based on the time of a Clock - see EWMA. Unlike the average of an EWMA, the rate decays towards 0 while no events
+// are added. An EWMARate is safe for concurrent use.
+type EWMARate struct {
+	clock    Clock
+	halfLife time.Duration
+
+	mu   sync.Mutex
+	rate float64 // events per second at the time of the last update
+	last UTC     // the time of the last update
+}
+
+// NewEWMARate creates an EWMARate with the given half-life based on the given clock, or on utc.Now() if nil. It panics
+// if the half-life is not positive.
+func NewEWMARate(clock Clock, halfLife time.Duration) *EWMARate {
+	if halfLife <= 0 {
+		panic("utc.NewEWMARate: non-positive half-life")
+	}
+	return &EWMARate{clock: clock, halfLife: halfLife}
+}
+
+// Add records n events at the current time of the clock.
+func (r *EWMARate) Add(n float64) {
+	now := clockNow(r.clock)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rate = r.rate*decayFactor(r.last, now, r.halfLife) + n*math.Ln2/r.halfLife.Seconds()
+	r.last = Latest(r.last, now)
+}
+
+// Rate returns the current rate in events per second. For events at a constant rate, it converges to that rate within
+// a few half-lives.
+func (r *EWMARate) Rate() float64 {
+	now := clockNow(r.clock)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rate * decayFactor(r.last, now, r.halfLife)
+}
+
+// Reset discards all events.
+func (r *EWMARate) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rate, r.last = 0, Zero
+}
+
+// decayFactor returns the factor by which a value decays with the given half-life between last and now: 1 if last is
+// Zero or now is not after last.
+func decayFactor(last, now UTC, halfLife time.Duration) float64 {
+	if last.IsZero() || !now.After(last) {
+		return 1
+	}
+	return math.Exp2(-float64(now.Sub(last)) / float64(halfLife))
+}
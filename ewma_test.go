@@ -0,0 +1,87 @@
+package utc_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+func TestEWMA(t *testing.T) {
+	clock := utc.NewWallClock(utc.MustParse("2020-01-01T10:00:00Z"))
+
+	e := utc.NewEWMA(clock, time.Second)
+	require.Equal(t, 0.0, e.Value())
+
+	e.Observe(10)
+	require.Equal(t, 10.0, e.Value())
+
+	// no decay without observations
+	clock.Add(time.Hour)
+	require.Equal(t, 10.0, e.Value())
+
+	// observations at the same instant are weighted equally
+	e.Reset()
+	e.Observe(10)
+	e.Observe(20)
+	require.InDelta(t, 15.0, e.Value(), 1e-9)
+
+	// after one half-life, the old observations weigh half as much as the new one
+	clock.Add(time.Second)
+	e.Observe(40)
+	require.InDelta(t, (30*0.5+40)/(2*0.5+1), e.Value(), 1e-9)
+
+	// long after, the new observation dominates
+	clock.Add(time.Minute)
+	e.Observe(100)
+	require.InDelta(t, 100.0, e.Value(), 1e-6)
+}
+
+func TestEWMA_duration(t *testing.T) {
+	clock := utc.NewWallClock(utc.MustParse("2020-01-01T10:00:00Z"))
+
+	e := utc.NewEWMA(clock, time.Second)
+	e.ObserveDuration(100 * time.Millisecond)
+	e.ObserveDuration(300 * time.Millisecond)
+	require.Equal(t, 200*time.Millisecond, e.Duration())
+}
+
+func TestEWMA_globalClock(t *testing.T) {
+	clock := utc.NewWallClock(utc.MustParse("2020-01-01T10:00:00Z")).MockNow()
+	defer clock.UnmockNow()
+
+	e := utc.NewEWMA(nil, time.Second)
+	e.Observe(0)
+	clock.Add(time.Second)
+	e.Observe(3)
+	require.InDelta(t, 2.0, e.Value(), 1e-9)
+}
+
+func TestEWMARate(t *testing.T) {
+	clock := utc.NewWallClock(utc.MustParse("2020-01-01T10:00:00Z"))
+
+	r := utc.NewEWMARate(clock, 10*time.Second)
+	require.Equal(t, 0.0, r.Rate())
+
+	// converges to a constant rate of 100 events per second
+	for i := 0; i < 10000; i++ {
+		clock.Add(10 * time.Millisecond)
+		r.Add(1)
+	}
+	require.InDelta(t, 100, r.Rate(), 1)
+
+	// decays to half the rate after a half-life without events
+	rate := r.Rate()
+	clock.Add(10 * time.Second)
+	require.InDelta(t, rate/2, r.Rate(), 1e-9)
+
+	r.Reset()
+	require.Equal(t, 0.0, r.Rate())
+}
+
+func TestEWMA_nonPositiveHalfLife(t *testing.T) {
+	require.Panics(t, func() { utc.NewEWMA(nil, 0) })
+	require.Panics(t, func() { utc.NewEWMARate(nil, -time.Second) })
+}
@@ -0,0 +1,151 @@
+package utc
+
+import (
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/eluv-io/errors-go"
+)
+
+// StringRangePolicy defines how UTC.String formats instants with a year outside of [0000, 9999], which cannot be
+// represented in the regular ISO 8601 format. Use UTC.StringChecked in order to get an error for such instants.
+type StringRangePolicy int32
+
+const (
+	// StringRangeClamp clamps the year to 0000 or 9999, while retaining all other fields, e.g. 9999-01-01T00:00:00.000Z
+	// for the year 10000.
+	StringRangeClamp StringRangePolicy = iota
+	// StringRangeExpand formats the year with the ISO 8601 expanded year representation like ExpandedYear, e.g.
+	// +010000-01-01T00:00:00.000Z
+	StringRangeExpand
+)
+
+var stringRangePolicy atomic.Int32
+
+// SetStringRangePolicy sets the policy used by UTC.String for years outside of [0000, 9999]. The default is
+// StringRangeClamp. The policy does not affect marshaling, which fails for such years - see ExpandedYear.
+func SetStringRangePolicy(p StringRangePolicy) {
+	stringRangePolicy.Store(int32(p))
+}
+
+// GetStringRangePolicy returns the current string range policy.
+func GetStringRangePolicy() StringRangePolicy {
+	return StringRangePolicy(stringRangePolicy.Load())
+}
+
+// expandedYearDigits is the minimum number of year digits of the expanded year representation.
+const expandedYearDigits = 6
+
+// ExpandedYear is a UTC that marshals years outside of [0000, 9999] with the ISO 8601 expanded year representation
+// ±YYYYYY instead of failing, e.g. +010000-01-01T00:00:00.000Z or -000001-01-01T00:00:00.000Z. The year has a sign and
+// at least six digits, which is the representation used by JavaScript's Date.toISOString. Years within [0000, 9999]
+// are marshaled like UTC.
+//
+// Parsing of the expanded representation is supported by FromString and therefore by all unmarshal methods of UTC.
+// The binary encoding is the same as for UTC and remains limited to years [0000, 9999].
+type ExpandedYear struct {
+	UTC
+}
+
+// Expanded returns this UTC as ExpandedYear.
+func (u UTC) Expanded() ExpandedYear {
+	return ExpandedYear{UTC: u}
+}
+
+// String returns the time formatted in ISO 8601 format, with an expanded year if the year is outside of [0000, 9999].
+func (u ExpandedYear) String() string {
+	return string(u.appendExpanded(nil))
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (u ExpandedYear) MarshalJSON() ([]byte, error) {
+	if u.IsZero() {
+		return []byte(`""`), nil
+	}
+	b := u.appendExpanded(append(make([]byte, 0, len(iso8601Template)+5), '"'))
+	return append(b, '"'), nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (u ExpandedYear) MarshalText() ([]byte, error) {
+	if u.IsZero() {
+		return nil, nil
+	}
+	return u.appendExpanded(make([]byte, 0, len(iso8601Template)+3)), nil
+}
+
+// AppendText implements the encoding.TextAppender interface.
+func (u ExpandedYear) AppendText(b []byte) ([]byte, error) {
+	if u.IsZero() {
+		return b, nil
+	}
+	return u.appendExpanded(b), nil
+}
+
+// appendExpanded appends the ISO 8601 representation of this UTC to b, using the expanded year representation for
+// years outside of [0000, 9999].
+func (u ExpandedYear) appendExpanded(b []byte) []byte {
+	if u.IsValid() {
+		return u.appendISO8601(b)
+	}
+	year := u.Year()
+	if year < 0 {
+		b = append(b, '-')
+		year = -year
+	} else {
+		b = append(b, '+')
+	}
+	var digits [20]byte
+	y := strconv.AppendInt(digits[:0], int64(year), 10)
+	for i := len(y); i < expandedYearDigits; i++ {
+		b = append(b, '0')
+	}
+	b = append(b, y...)
+
+	// format the remainder with a leap year in range in order to retain February 29th
+	_, month, day := u.Date()
+	hour, min, sec := u.Clock()
+	var buf [len(iso8601Template)]byte
+	rest := New(time.Date(2000, month, day, hour, min, sec, u.Nanosecond(), time.UTC)).appendISO8601(buf[:0])
+	return append(b, rest[4:]...)
+}
+
+// parseExpandedYear parses the given time string with an ISO 8601 expanded year representation ±YYYYY... followed by
+// any of the supported formats without year, e.g. +010000-01-01T00:00:00.000Z. Returns false if the string does not
+// start with an expanded year.
+func parseExpandedYear(s string) (time.Time, bool, error) {
+	if len(s) < 6 || (s[0] != '+' && s[0] != '-') {
+		return time.Time{}, false, nil
+	}
+	end := 1
+	for end < len(s) && s[end] >= '0' && s[end] <= '9' {
+		end++
+	}
+	digits := end - 1
+	if digits < 4 || (end < len(s) && s[end] != '-') {
+		return time.Time{}, false, nil
+	}
+	e := errors.Template("parseExpandedYear", errors.K.Invalid, ErrParse, "utc", s)
+	if digits > 9 {
+		return time.Time{}, true, e(ErrOutOfRange, "reason", "too many year digits")
+	}
+	year, err := strconv.Atoi(s[1:end])
+	if err != nil {
+		return time.Time{}, true, e(WrapSentinel(ErrParse, err))
+	}
+	if s[0] == '-' {
+		year = -year
+	}
+
+	// parse the remainder with a leap year in range in order to accept February 29th
+	t, err := parseTime("2000" + s[end:])
+	if err != nil {
+		return time.Time{}, true, e(WrapSentinel(ErrParse, err))
+	}
+	if t.Month() == time.February && t.Day() == 29 && !IsLeapYear(year) {
+		return time.Time{}, true, e("reason", "day out of range")
+	}
+	res := time.Date(year, t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	return res, true, nil
+}
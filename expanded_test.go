@@ -0,0 +1,121 @@
+package utc_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+func TestExpandedYear_Marshal(t *testing.T) {
+	tests := []struct {
+		u    utc.UTC
+		want string
+	}{
+		{utc.New(time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC)), "+010000-01-01T00:00:00.000Z"},
+		{utc.New(time.Date(275760, 9, 13, 0, 0, 0, 0, time.UTC)), "+275760-09-13T00:00:00.000Z"},
+		{utc.New(time.Date(12345676, 2, 29, 1, 2, 3, 4_000_000, time.UTC)), "+12345676-02-29T01:02:03.004Z"},
+		{utc.New(time.Date(-1, 12, 31, 23, 59, 59, 999_000_000, time.UTC)), "-000001-12-31T23:59:59.999Z"},
+		{utc.New(time.Date(-271821, 4, 20, 0, 0, 0, 0, time.UTC)), "-271821-04-20T00:00:00.000Z"},
+		{utc.MustParse("2021-12-25T12:20:00.000Z"), "2021-12-25T12:20:00.000Z"},
+		{utc.Max, "9999-12-31T23:59:59.999Z"},
+		{utc.Min, "0000-01-01T00:00:00.000Z"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			e := tt.u.Expanded()
+			require.Equal(t, tt.want, e.String())
+
+			txt, err := e.MarshalText()
+			require.NoError(t, err)
+			require.Equal(t, tt.want, string(txt))
+
+			jsn, err := json.Marshal(e)
+			require.NoError(t, err)
+			require.Equal(t, `"`+tt.want+`"`, string(jsn))
+
+			var res utc.ExpandedYear
+			require.NoError(t, json.Unmarshal(jsn, &res))
+			require.True(t, tt.u.Truncate(time.Millisecond).Equal(res.UTC), res)
+
+			var u utc.UTC
+			require.NoError(t, u.UnmarshalText(txt))
+			require.True(t, tt.u.Truncate(time.Millisecond).Equal(u))
+		})
+	}
+
+	jsn, err := json.Marshal(utc.ExpandedYear{})
+	require.NoError(t, err)
+	require.Equal(t, `""`, string(jsn))
+
+	// UTC still refuses to marshal out-of-range years
+	_, err = json.Marshal(utc.Max.Add(time.Hour))
+	require.Error(t, err)
+}
+
+func TestExpandedYear_Parse(t *testing.T) {
+	tests := []struct {
+		s    string
+		want time.Time
+	}{
+		{"+010000-01-01", time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"+10000-01-01T10:00:00Z", time.Date(10000, 1, 1, 10, 0, 0, 0, time.UTC)},
+		{"+010000-01-01T10:00:00.123456789+02:00", time.Date(10000, 1, 1, 8, 0, 0, 123456789, time.UTC)},
+		{"+002021-12-25T12:20:00.000Z", time.Date(2021, 12, 25, 12, 20, 0, 0, time.UTC)},
+		{"-000001-03-01T00:00:00Z", time.Date(-1, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"-000004-02-29T00:00:00Z", time.Date(-4, 2, 29, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			u, err := utc.FromString(tt.s)
+			require.NoError(t, err)
+			require.True(t, tt.want.Equal(u.Time), u.Time)
+		})
+	}
+
+	for _, s := range []string{
+		"+010001-02-29T00:00:00Z", // not a leap year
+		"+0100000000000-01-01T00:00:00Z",
+		"+010000-13-01T00:00:00Z",
+		"+010000",
+		"+1-01-01T00:00:00Z",
+	} {
+		_, err := utc.FromString(s)
+		require.Error(t, err, s)
+	}
+
+	z, err := utc.ParseZoned("+010000-01-01T10:00:00+02:00")
+	require.NoError(t, err)
+	require.Equal(t, 7200, z.Offset())
+	require.Equal(t, 10000, z.ZonedTime().Year())
+}
+
+func TestUTC_StringRangePolicy(t *testing.T) {
+	defer utc.SetStringRangePolicy(utc.GetStringRangePolicy())
+
+	future := utc.New(time.Date(10000, 3, 1, 0, 0, 0, 0, time.UTC))
+	past := utc.New(time.Date(-1, 3, 1, 0, 0, 0, 0, time.UTC))
+	valid := utc.MustParse("2021-12-25T12:20:00.000Z")
+
+	require.Equal(t, utc.StringRangeClamp, utc.GetStringRangePolicy())
+	require.Equal(t, "9999-03-01T00:00:00.000Z", future.String())
+	require.Equal(t, "0000-03-01T00:00:00.000Z", past.String())
+
+	utc.SetStringRangePolicy(utc.StringRangeExpand)
+	require.Equal(t, "+010000-03-01T00:00:00.000Z", future.String())
+	require.Equal(t, "-000001-03-01T00:00:00.000Z", past.String())
+	require.Equal(t, "2021-12-25T12:20:00.000Z", valid.String())
+	require.Equal(t, "9999-12-31T23:59:59.999Z", utc.Max.String())
+	require.Equal(t, "0000-01-01T00:00:00.000Z", utc.Min.String())
+
+	for _, u := range []utc.UTC{future, past} {
+		_, err := u.StringChecked()
+		require.Error(t, err)
+	}
+	s, err := valid.StringChecked()
+	require.NoError(t, err)
+	require.Equal(t, "2021-12-25T12:20:00.000Z", s)
+}
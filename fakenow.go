@@ -0,0 +1,62 @@
+package utc
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	"github.com/eluv-io/errors-go"
+)
+
+// EnvFakeNow is the name of the environment variable read by InitFakeNowFromEnv.
+const EnvFakeNow = "UTC_FAKE_NOW"
+
+// InitFakeNowFromEnv mocks Now() according to the environment variable UTC_FAKE_NOW. It is meant for end-to-end tests
+// of compiled binaries, where mocking in code is not possible. The variable has no effect unless this function is
+// called, e.g. in main() of a test binary, or unless the binary is built with the build tag "utc_fake_now", which
+// calls it at initialization of the package.
+//
+// The following values are supported:
+//
+//   - an ISO 8601 timestamp (e.g. 2020-01-01T00:00:00Z) or the number of seconds since the unix epoch (e.g.
+//     1577836800): Now() is frozen at the given time
+//   - a duration with leading sign in the format of ParseDuration (e.g. +36h or -1y): Now() returns the current time
+//     offset by the given duration
+//
+// Returns true if Now() was mocked, false if the variable is not set or empty, and an error if the value is invalid.
+func InitFakeNowFromEnv() (bool, error) {
+	val := strings.TrimSpace(os.Getenv(EnvFakeNow))
+	if val == "" {
+		return false, nil
+	}
+	clock, err := parseFakeNow(val)
+	if err != nil {
+		return false, errors.E("InitFakeNowFromEnv", errors.K.Invalid, err, "env", EnvFakeNow, "value", val)
+	}
+	setClock(clock)
+	return true, nil
+}
+
+// parseFakeNow returns the clock for the given value of UTC_FAKE_NOW.
+func parseFakeNow(val string) (Clock, error) {
+	if val[0] == '+' || val[0] == '-' {
+		offset, err := ParseDuration(val)
+		if err != nil {
+			return nil, err
+		}
+		return ClockFn(func() UTC {
+			return now().Add(offset)
+		}), nil
+	}
+	frozen, ok := parseEpoch(val, time.Second)
+	if !ok {
+		var err error
+		frozen, err = FromString(val)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return ClockFn(func() UTC {
+		return frozen
+	}), nil
+}
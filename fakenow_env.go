@@ -0,0 +1,9 @@
+//go:build utc_fake_now
+
+package utc
+
+func init() {
+	if _, err := InitFakeNowFromEnv(); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,43 @@
+package utc_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+func TestInitFakeNowFromEnv(t *testing.T) {
+	defer utc.ResetNow()
+
+	t.Setenv(utc.EnvFakeNow, "")
+	ok, err := utc.InitFakeNowFromEnv()
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	for _, val := range []string{"2020-01-01T00:00:00Z", "1577836800", " 2020-01-01 "} {
+		t.Setenv(utc.EnvFakeNow, val)
+		ok, err = utc.InitFakeNowFromEnv()
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, "2020-01-01T00:00:00.000Z", utc.Now().String(), val)
+		utc.ResetNow()
+	}
+
+	t.Setenv(utc.EnvFakeNow, "-1y")
+	ok, err = utc.InitFakeNowFromEnv()
+	require.NoError(t, err)
+	require.True(t, ok)
+	diff := time.Now().Sub(utc.Now().Time)
+	require.InDelta(t, float64(365*24*time.Hour), float64(diff), float64(time.Minute))
+	utc.ResetNow()
+
+	for _, val := range []string{"blub", "+1x", "2020-13-01"} {
+		t.Setenv(utc.EnvFakeNow, val)
+		ok, err = utc.InitFakeNowFromEnv()
+		require.Error(t, err, val)
+		require.False(t, ok)
+	}
+}
@@ -0,0 +1,88 @@
+package utc
+
+import (
+	"time"
+
+	"github.com/eluv-io/errors-go"
+)
+
+// FiscalCalendar defines fiscal years that start on a given day of a given month, e.g. July 1st or October 1st, and
+// their quarters of three months each.
+//
+// By default, a fiscal year is named after the calendar year in which it ends: with a start on October 1st, fiscal year
+// 2024 is [2023-10-01, 2024-10-01). Set NamedByStart to name it after the calendar year in which it starts instead.
+// Fiscal years starting on January 1st coincide with calendar years in both cases.
+//
+// The zero value is a fiscal calendar with fiscal years equal to calendar years.
+type FiscalCalendar struct {
+	StartMonth   time.Month // the month of the start of the fiscal year, January if 0
+	StartDay     int        // the day of the month of the start of the fiscal year, 1 through 28 - 1 if 0
+	NamedByStart bool       // name fiscal years after the calendar year in which they start
+}
+
+// NewFiscalCalendar returns a FiscalCalendar with fiscal years starting on the given day of the given month. The day
+// must be in [1, 28] in order to exist in every month.
+func NewFiscalCalendar(startMonth time.Month, startDay int) (FiscalCalendar, error) {
+	if startMonth < time.January || startMonth > time.December {
+		return FiscalCalendar{}, errors.E("NewFiscalCalendar", errors.K.Invalid, ErrOutOfRange,
+			"reason", "invalid month",
+			"month", startMonth)
+	}
+	if startDay < 1 || startDay > 28 {
+		return FiscalCalendar{}, errors.E("NewFiscalCalendar", errors.K.Invalid, ErrOutOfRange,
+			"reason", "day outside of range [1,28]",
+			"day", startDay)
+	}
+	return FiscalCalendar{StartMonth: startMonth, StartDay: startDay}, nil
+}
+
+// FiscalYear returns the fiscal year of the given instant.
+func (c FiscalCalendar) FiscalYear(u UTC) int {
+	year := u.Year()
+	if u.Before(c.start(year, 0)) {
+		year--
+	}
+	return year + c.nameOffset()
+}
+
+// FiscalQuarter returns the fiscal year and the quarter within the fiscal year, 1 through 4, of the given instant.
+func (c FiscalCalendar) FiscalQuarter(u UTC) (year, quarter int) {
+	year = c.FiscalYear(u)
+	quarter = 4
+	for quarter > 1 && u.Before(c.QuarterRange(year, quarter).Start) {
+		quarter--
+	}
+	return year, quarter
+}
+
+// YearRange returns the range of the given fiscal year.
+func (c FiscalCalendar) YearRange(year int) Range {
+	startYear := year - c.nameOffset()
+	return Range{Start: c.start(startYear, 0), End: c.start(startYear+1, 0)}
+}
+
+// QuarterRange returns the range of the given quarter, 1 through 4, of the given fiscal year.
+func (c FiscalCalendar) QuarterRange(year, quarter int) Range {
+	startYear := year - c.nameOffset()
+	return Range{Start: c.start(startYear, 3*(quarter-1)), End: c.start(startYear, 3*quarter)}
+}
+
+// start returns the start of the fiscal year starting in the given calendar year, plus the given number of months.
+func (c FiscalCalendar) start(year int, months int) UTC {
+	month, day := c.StartMonth, c.StartDay
+	if month == 0 {
+		month = time.January
+	}
+	if day == 0 {
+		day = 1
+	}
+	return New(time.Date(year, month+time.Month(months), day, 0, 0, 0, 0, time.UTC))
+}
+
+// nameOffset returns the difference between the name of a fiscal year and the calendar year in which it starts.
+func (c FiscalCalendar) nameOffset() int {
+	if c.NamedByStart || c.start(0, 0).YearDay() == 1 {
+		return 0
+	}
+	return 1
+}
@@ -0,0 +1,66 @@
+package utc_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+func TestFiscalCalendar(t *testing.T) {
+	october, err := utc.NewFiscalCalendar(time.October, 1)
+	require.NoError(t, err)
+	july, err := utc.NewFiscalCalendar(time.July, 1)
+	require.NoError(t, err)
+	julyByStart := july
+	julyByStart.NamedByStart = true
+	april6 := utc.FiscalCalendar{StartMonth: time.April, StartDay: 6, NamedByStart: true}
+
+	for _, test := range []struct {
+		cal     utc.FiscalCalendar
+		date    string
+		year    int
+		quarter int
+	}{
+		{utc.FiscalCalendar{}, "2023-12-31T23:59:59Z", 2023, 4},
+		{utc.FiscalCalendar{}, "2024-01-01", 2024, 1},
+		{utc.FiscalCalendar{}, "2024-05-01", 2024, 2},
+		{october, "2023-09-30T23:59:59Z", 2023, 4},
+		{october, "2023-10-01", 2024, 1},
+		{october, "2024-01-01", 2024, 2},
+		{october, "2024-09-01", 2024, 4},
+		{july, "2024-06-30", 2024, 4},
+		{july, "2024-07-01", 2025, 1},
+		{july, "2025-03-31", 2025, 3},
+		{julyByStart, "2024-06-30", 2023, 4},
+		{julyByStart, "2024-07-01", 2024, 1},
+		{april6, "2024-04-05", 2023, 4},
+		{april6, "2024-04-06", 2024, 1},
+		{april6, "2024-07-06", 2024, 2},
+		{april6, "2025-01-05", 2024, 3},
+	} {
+		u := utc.MustParse(test.date)
+		year, quarter := test.cal.FiscalQuarter(u)
+		require.Equal(t, test.year, test.cal.FiscalYear(u), test.date)
+		require.Equal(t, test.year, year, test.date)
+		require.Equal(t, test.quarter, quarter, test.date)
+		require.True(t, test.cal.YearRange(year).Contains(u), test.date)
+		require.True(t, test.cal.QuarterRange(year, quarter).Contains(u), test.date)
+	}
+
+	require.Equal(t, "2023-10-01T00:00:00.000Z/2024-10-01T00:00:00.000Z", october.YearRange(2024).String())
+	require.Equal(t, "2024-01-01T00:00:00.000Z/2024-04-01T00:00:00.000Z", october.QuarterRange(2024, 2).String())
+	require.Equal(t, "2024-07-01T00:00:00.000Z/2024-10-01T00:00:00.000Z", october.QuarterRange(2024, 4).String())
+	require.Equal(t, "2024-07-01T00:00:00.000Z/2025-07-01T00:00:00.000Z", july.YearRange(2025).String())
+	require.Equal(t, "2024-07-01T00:00:00.000Z/2025-07-01T00:00:00.000Z", julyByStart.YearRange(2024).String())
+	require.Equal(t, "2024-01-01T00:00:00.000Z/2025-01-01T00:00:00.000Z", utc.FiscalCalendar{}.YearRange(2024).String())
+
+	_, err = utc.NewFiscalCalendar(0, 1)
+	require.Error(t, err)
+	_, err = utc.NewFiscalCalendar(time.February, 29)
+	require.Error(t, err)
+	_, err = utc.NewFiscalCalendar(time.March, 0)
+	require.Error(t, err)
+}
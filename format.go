@@ -0,0 +1,78 @@
+package utc
+
+import (
+	"strings"
+)
+
+// zoneTokens are the layout elements of package time that format the time zone, longest first among elements with a
+// common prefix.
+var zoneTokens = []string{
+	"Z07:00:00", "Z070000", "Z0700", "Z07:00", "Z07",
+	"-07:00:00", "-070000", "-0700", "-07:00", "-07",
+	"MST",
+}
+
+// FormatUTC is like Format, but guarantees that the time zone is formatted as "Z": layout elements that would print a
+// numeric offset (like "-07:00", which prints "+00:00") or a zone name (like "MST", which prints "UTC") are replaced
+// with "Z", and "Z" is appended to layouts without time zone element that do not end with a literal "Z".
+//
+//	u.FormatUTC("2006-01-02T15:04:05-07:00") // 2021-09-09T01:46:40Z
+//	u.FormatUTC("2006-01-02 15:04:05")       // 2021-09-09 01:46:40Z
+//
+// Use it instead of the Format method of the embedded time.Time when formatting with layouts from configuration or from
+// other libraries.
+func (u UTC) FormatUTC(layout string) string {
+	return u.Time.Format(NormalizeLayout(layout))
+}
+
+// AppendFormatUTC is like FormatUTC, but appends the formatted time to b.
+func (u UTC) AppendFormatUTC(b []byte, layout string) []byte {
+	return u.Time.AppendFormat(b, NormalizeLayout(layout))
+}
+
+// NormalizeLayout returns the given layout with time zone elements replaced with "Z", or with "Z" appended if the
+// layout has neither a time zone element nor a trailing literal "Z" - see FormatUTC.
+func NormalizeLayout(layout string) string {
+	var sb strings.Builder
+	found := false
+	for i := 0; i < len(layout); {
+		token := zoneToken(layout[i:])
+		if token == "" {
+			i++
+			continue
+		}
+		if !found {
+			sb.Grow(len(layout))
+			found = true
+		}
+		sb.WriteString(layout[:i])
+		sb.WriteByte('Z')
+		layout = layout[i+len(token):]
+		i = 0
+	}
+	if !found {
+		if strings.HasSuffix(layout, "Z") {
+			// literal Z
+			return layout
+		}
+		return layout + "Z"
+	}
+	sb.WriteString(layout)
+	return sb.String()
+}
+
+// zoneToken returns the time zone element at the start of s, or the empty string if there is none.
+func zoneToken(s string) string {
+	switch {
+	case len(s) == 0:
+		return ""
+	case s[0] != 'Z' && s[0] != '-' && s[0] != 'M':
+		return ""
+	}
+	for _, token := range zoneTokens {
+		if strings.HasPrefix(s, token) {
+			return token
+		}
+	}
+	return ""
+}
@@ -0,0 +1,49 @@
+package utc_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+func TestFormatUTC(t *testing.T) {
+	u := utc.MustParse("2021-09-09T01:46:40.123Z")
+	for _, test := range []struct {
+		layout string
+		want   string
+	}{
+		{utc.ISO8601, "2021-09-09T01:46:40.123Z"},
+		{time.RFC3339, "2021-09-09T01:46:40Z"},
+		{"2006-01-02T15:04:05-07:00", "2021-09-09T01:46:40Z"},
+		{"2006-01-02T15:04:05-0700", "2021-09-09T01:46:40Z"},
+		{"2006-01-02T15:04:05-07", "2021-09-09T01:46:40Z"},
+		{"2006-01-02T15:04:05-07:00:00", "2021-09-09T01:46:40Z"},
+		{"2006-01-02T15:04:05Z0700", "2021-09-09T01:46:40Z"},
+		{"2006-01-02T15:04:05Z07", "2021-09-09T01:46:40Z"},
+		{"2006-01-02T15:04:05Z", "2021-09-09T01:46:40Z"},
+		{"2006-01-02 15:04:05", "2021-09-09 01:46:40Z"},
+		{"2006-01-02", "2021-09-09Z"},
+		{time.RFC1123, "Thu, 09 Sep 2021 01:46:40 Z"},
+		{"Monday, Jan 2 15:04 MST", "Thursday, Sep 9 01:46 Z"},
+		{"-07:00 2006 -07:00", "Z 2021 Z"},
+	} {
+		require.Equal(t, test.want, u.FormatUTC(test.layout), test.layout)
+		require.Equal(t, "x"+test.want, string(u.AppendFormatUTC([]byte("x"), test.layout)), test.layout)
+	}
+
+	// the embedded Format prints offsets and zone names
+	require.Equal(t, "2021-09-09T01:46:40+00:00", u.Format("2006-01-02T15:04:05-07:00"))
+	require.Equal(t, "Thu, 09 Sep 2021 01:46:40 UTC", u.Format(time.RFC1123))
+	require.Equal(t, "Thu, 09 Sep 2021 01:46:40 GMT", u.Format(http.TimeFormat))
+}
+
+func TestNormalizeLayout(t *testing.T) {
+	require.Equal(t, "2006-01-02T15:04:05.000Z", utc.NormalizeLayout(utc.ISO8601))
+	require.Equal(t, "2006-01-02T15:04:05Z", utc.NormalizeLayout(time.RFC3339))
+	require.Equal(t, "Mon Jan _2 15:04:05 Z 2006", utc.NormalizeLayout(time.UnixDate))
+	require.Equal(t, "15:04Z", utc.NormalizeLayout("15:04"))
+}
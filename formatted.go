@@ -0,0 +1,85 @@
+package utc
+
+// FormattedUTC is a UTC that caches its ISO 8601 string representation. It is meant for values that are formatted
+// many times, e.g. a per-request timestamp that is written into every log line. The string is computed once in
+// NewFormatted and reused by String, MarshalText, AppendText and MarshalJSON.
+//
+// FormattedUTC is immutable: all arithmetic methods of the embedded UTC (Add, Truncate, Round...) return a plain UTC
+// without cached string, which has to be wrapped again with NewFormatted if needed. The unmarshal and scan methods
+// update the cached string. Do not assign the embedded UTC directly, since the cached string would become stale - use
+// NewFormatted instead.
+type FormattedUTC struct {
+	UTC
+	str string
+}
+
+// NewFormatted creates a FormattedUTC for the given UTC.
+func NewFormatted(u UTC) FormattedUTC {
+	return FormattedUTC{UTC: u, str: u.String()}
+}
+
+// String returns the cached ISO 8601 representation: 2006-01-02T15:04:05.000Z
+func (f FormattedUTC) String() string {
+	if f.str == "" {
+		// the zero value of FormattedUTC has no cached string
+		return f.UTC.String()
+	}
+	return f.str
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (f FormattedUTC) MarshalJSON() ([]byte, error) {
+	if f.IsZero() {
+		return []byte(`""`), nil
+	}
+	b, err := f.AppendText(append(make([]byte, 0, len(iso8601Template)+2), '"'))
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '"'), nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (f FormattedUTC) MarshalText() ([]byte, error) {
+	if f.IsZero() {
+		return nil, nil
+	}
+	return f.AppendText(make([]byte, 0, len(iso8601Template)))
+}
+
+// AppendText implements the encoding.TextAppender interface.
+func (f FormattedUTC) AppendText(b []byte) ([]byte, error) {
+	if f.IsZero() {
+		return b, nil
+	}
+	if err := f.ValidateISO8601(); err != nil {
+		return nil, err
+	}
+	return append(b, f.String()...), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (f *FormattedUTC) UnmarshalJSON(data []byte) error {
+	return f.update(f.UTC.UnmarshalJSON(data))
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (f *FormattedUTC) UnmarshalText(data []byte) error {
+	return f.update(f.UTC.UnmarshalText(data))
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (f *FormattedUTC) UnmarshalBinary(data []byte) error {
+	return f.update(f.UTC.UnmarshalBinary(data))
+}
+
+// Scan implements the sql.Scanner interface.
+func (f *FormattedUTC) Scan(src interface{}) error {
+	return f.update(f.UTC.Scan(src))
+}
+
+// update recomputes the cached string after the embedded UTC has been modified and returns the given error.
+func (f *FormattedUTC) update(err error) error {
+	f.str = f.UTC.String()
+	return err
+}
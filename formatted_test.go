@@ -0,0 +1,60 @@
+package utc_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+func TestFormattedUTC(t *testing.T) {
+	f := utc.NewFormatted(utc.New(oneBillion))
+	require.Equal(t, oneBillionString, f.String())
+	require.Equal(t, 0.0, testing.AllocsPerRun(100, func() {
+		_ = f.String()
+	}))
+
+	jsn, err := json.Marshal(f)
+	require.NoError(t, err)
+	require.Equal(t, `"`+oneBillionString+`"`, string(jsn))
+
+	txt, err := f.MarshalText()
+	require.NoError(t, err)
+	require.Equal(t, oneBillionString, string(txt))
+
+	// arithmetic returns a plain UTC
+	later := f.Add(time.Second)
+	require.Equal(t, "2001-09-09T01:46:41.000Z", later.String())
+	require.Equal(t, oneBillionString, f.String())
+}
+
+func TestFormattedUTC_unmarshal(t *testing.T) {
+	f := utc.NewFormatted(utc.New(oneBillion))
+
+	require.NoError(t, json.Unmarshal([]byte(`"2022-03-04T05:06:07.123Z"`), &f))
+	require.Equal(t, "2022-03-04T05:06:07.123Z", f.String())
+
+	require.NoError(t, f.UnmarshalText([]byte("2023-03-04T05:06:07.123Z")))
+	require.Equal(t, "2023-03-04T05:06:07.123Z", f.String())
+
+	bin, err := utc.New(oneBillion).MarshalBinary()
+	require.NoError(t, err)
+	require.NoError(t, f.UnmarshalBinary(bin))
+	require.Equal(t, oneBillionString, f.String())
+
+	require.NoError(t, f.Scan(nil))
+	require.Equal(t, utc.Zero.String(), f.String())
+	require.True(t, f.IsZero())
+}
+
+func TestFormattedUTC_zero(t *testing.T) {
+	var f utc.FormattedUTC
+	require.Equal(t, utc.Zero.String(), f.String())
+
+	jsn, err := json.Marshal(f)
+	require.NoError(t, err)
+	require.Equal(t, `""`, string(jsn))
+}
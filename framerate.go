@@ -0,0 +1,170 @@
+package utc
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/eluv-io/errors-go"
+)
+
+// FrameRate is a frame rate expressed as the rational number Num/Den frames per second, e.g. 30000/1001 for NTSC
+// 29.97 fps. All conversions between frames and time are done with exact integer arithmetic in order to avoid the drift
+// that results from float math or from accumulating a rounded frame duration over long content.
+//
+// The conversions require a valid frame rate (see Validate). With an invalid frame rate like the zero value FrameRate{},
+// they return 0 frames or a zero duration, and AddFrames returns the given time unchanged.
+type FrameRate struct {
+	Num int64 // numerator
+	Den int64 // denominator
+}
+
+// FrameRounding defines how a duration that does not fall exactly on a frame boundary is converted to a frame count.
+type FrameRounding int
+
+const (
+	FrameFloor   FrameRounding = iota // round down to the frame that contains the instant
+	FrameCeil                         // round up to the next frame boundary
+	FrameNearest                      // round to the nearest frame boundary, half frames are rounded up
+)
+
+var (
+	FPS24    = FrameRate{Num: 24, Den: 1}
+	FPS25    = FrameRate{Num: 25, Den: 1}
+	FPS30    = FrameRate{Num: 30, Den: 1}
+	FPS50    = FrameRate{Num: 50, Den: 1}
+	FPS60    = FrameRate{Num: 60, Den: 1}
+	FPS23976 = FrameRate{Num: 24000, Den: 1001}
+	FPS2997  = FrameRate{Num: 30000, Den: 1001}
+	FPS5994  = FrameRate{Num: 60000, Den: 1001}
+)
+
+var bigNanosPerSec = big.NewInt(int64(time.Second))
+
+// NewFrameRate creates a new frame rate of num/den frames per second.
+func NewFrameRate(num, den int64) (FrameRate, error) {
+	r := FrameRate{Num: num, Den: den}
+	if err := r.Validate(); err != nil {
+		return FrameRate{}, err
+	}
+	return r, nil
+}
+
+// Validate validates that numerator and denominator of the frame rate are strictly positive.
+func (r FrameRate) Validate() error {
+	if !r.valid() {
+		return errors.E("FrameRate.Validate", errors.K.Invalid, ErrOutOfRange,
+			"reason", "numerator and denominator must be positive",
+			"num", r.Num,
+			"den", r.Den)
+	}
+	return nil
+}
+
+// valid returns true if numerator and denominator of the frame rate are strictly positive.
+func (r FrameRate) valid() bool {
+	return r.Num > 0 && r.Den > 0
+}
+
+// String returns the frame rate as "num/den".
+func (r FrameRate) String() string {
+	return strconv.FormatInt(r.Num, 10) + "/" + strconv.FormatInt(r.Den, 10)
+}
+
+// FrameDuration returns the duration of a single frame, rounded up to the next nanosecond. Do not multiply the result
+// in order to compute the duration of multiple frames - use Duration() instead.
+func (r FrameRate) FrameDuration() time.Duration {
+	return r.Duration(1)
+}
+
+// Duration returns the duration of n frames, rounded up to the next nanosecond. The result is the offset of the start
+// of frame n relative to the start of frame 0. The result is saturated at the minimum and maximum time.Duration.
+func (r FrameRate) Duration(n int64) time.Duration {
+	if !r.valid() {
+		return 0
+	}
+	return time.Duration(saturateInt64(r.nanos(n)))
+}
+
+// Frames converts the given duration to a number of frames using the given rounding rule. The result is saturated at
+// math.MinInt64 and math.MaxInt64.
+func (r FrameRate) Frames(d time.Duration, rounding FrameRounding) int64 {
+	return r.frames(big.NewInt(int64(d)), rounding)
+}
+
+// FramesBetween returns the number of frame boundaries crossed between start and end, i.e. the index of the frame that
+// contains end if frame 0 starts at start. The result is negative if end is before start, and saturated like the
+// result of Frames.
+func (r FrameRate) FramesBetween(start, end UTC) int64 {
+	return r.frames(nanosBetween(start, end), FrameFloor)
+}
+
+// AddFrames returns the start time of frame n, given that frame 0 starts at u. The result is rounded up to the next
+// nanosecond, hence FramesBetween(u, AddFrames(u, n)) == n for any n.
+func (r FrameRate) AddFrames(u UTC, n int64) UTC {
+	if !r.valid() {
+		return u
+	}
+	nanos := r.nanos(n)
+	if nanos.IsInt64() {
+		return u.Add(time.Duration(nanos.Int64()))
+	}
+	sec, nsec := new(big.Int).QuoRem(nanos, bigNanosPerSec, new(big.Int))
+	return New(time.Unix(u.Unix()+sec.Int64(), int64(u.Nanosecond())+nsec.Int64()))
+}
+
+// nanos returns the offset of frame n in nanoseconds, rounded up: ceil(n * den * 1e9 / num)
+func (r FrameRate) nanos(n int64) *big.Int {
+	x := big.NewInt(n)
+	x.Mul(x, big.NewInt(r.Den))
+	x.Mul(x, bigNanosPerSec)
+	return divRound(x, big.NewInt(r.Num), FrameCeil)
+}
+
+// frames returns the number of frames in the given number of nanoseconds: nanos * num / (den * 1e9)
+func (r FrameRate) frames(nanos *big.Int, rounding FrameRounding) int64 {
+	if !r.valid() {
+		return 0
+	}
+	x := new(big.Int).Mul(nanos, big.NewInt(r.Num))
+	y := new(big.Int).Mul(big.NewInt(r.Den), bigNanosPerSec)
+	return saturateInt64(divRound(x, y, rounding))
+}
+
+// saturateInt64 returns x as int64, saturated at math.MinInt64 and math.MaxInt64.
+func saturateInt64(x *big.Int) int64 {
+	switch {
+	case x.IsInt64():
+		return x.Int64()
+	case x.Sign() > 0:
+		return math.MaxInt64
+	default:
+		return math.MinInt64
+	}
+}
+
+// nanosBetween returns end - start in nanoseconds, without overflowing for large differences.
+func nanosBetween(start, end UTC) *big.Int {
+	res := big.NewInt(end.Unix() - start.Unix())
+	res.Mul(res, bigNanosPerSec)
+	return res.Add(res, big.NewInt(int64(end.Nanosecond()-start.Nanosecond())))
+}
+
+// divRound returns x/y rounded according to the given rounding rule. y must be positive.
+func divRound(x, y *big.Int, rounding FrameRounding) *big.Int {
+	// Div implements Euclidean division: with y > 0 the quotient is rounded towards negative infinity.
+	q, m := new(big.Int).DivMod(x, y, new(big.Int))
+	if m.Sign() == 0 {
+		return q
+	}
+	switch rounding {
+	case FrameCeil:
+		q.Add(q, big.NewInt(1))
+	case FrameNearest:
+		if m.Lsh(m, 1).Cmp(y) >= 0 {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return q
+}
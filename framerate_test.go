@@ -0,0 +1,103 @@
+package utc_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+func TestNewFrameRate(t *testing.T) {
+	r, err := utc.NewFrameRate(30000, 1001)
+	require.NoError(t, err)
+	require.Equal(t, utc.FPS2997, r)
+	require.Equal(t, "30000/1001", r.String())
+
+	for _, nd := range [][2]int64{{0, 1}, {1, 0}, {-25, 1}, {25, -1}} {
+		_, err = utc.NewFrameRate(nd[0], nd[1])
+		require.Error(t, err, nd)
+	}
+}
+
+func TestFrameRate_Duration(t *testing.T) {
+	assert.Equal(t, 40*time.Millisecond, utc.FPS25.FrameDuration())
+	assert.Equal(t, time.Second, utc.FPS25.Duration(25))
+	assert.Equal(t, 33_366_667*time.Nanosecond, utc.FPS2997.FrameDuration())
+	// 30000 frames of 29.97 fps content last exactly 1001 seconds - no drift
+	assert.Equal(t, 1001*time.Second, utc.FPS2997.Duration(30_000))
+	assert.Equal(t, 1001_000*time.Second, utc.FPS2997.Duration(30_000_000))
+	assert.Equal(t, -time.Second, utc.FPS24.Duration(-24))
+
+	// saturated at the range of time.Duration
+	assert.Equal(t, time.Duration(math.MaxInt64), utc.FPS25.Duration(math.MaxInt64))
+	assert.Equal(t, time.Duration(math.MinInt64), utc.FPS25.Duration(math.MinInt64))
+	assert.Equal(t, time.Duration(math.MaxInt64), utc.FrameRate{Num: 1, Den: math.MaxInt64}.FrameDuration())
+}
+
+func TestFrameRate_invalid(t *testing.T) {
+	start := utc.MustParse("2021-12-25T12:20:00.000Z")
+	for _, rate := range []utc.FrameRate{{}, {Num: 25}, {Den: 1}, {Num: -25, Den: 1}, {Num: 25, Den: -1}} {
+		t.Run(rate.String(), func(t *testing.T) {
+			require.Error(t, rate.Validate())
+			require.Equal(t, time.Duration(0), rate.Duration(25))
+			require.Equal(t, time.Duration(0), rate.FrameDuration())
+			require.Equal(t, int64(0), rate.Frames(time.Second, utc.FrameCeil))
+			require.Equal(t, int64(0), rate.FramesBetween(start, start.Add(time.Hour)))
+			require.Equal(t, start, rate.AddFrames(start, 25))
+		})
+	}
+}
+
+func TestFrameRate_Frames(t *testing.T) {
+	tests := []struct {
+		d        time.Duration
+		rounding utc.FrameRounding
+		want     int64
+	}{
+		{time.Second, utc.FrameFloor, 25},
+		{time.Second, utc.FrameCeil, 25},
+		{time.Second, utc.FrameNearest, 25},
+		{59 * time.Millisecond, utc.FrameFloor, 1},
+		{59 * time.Millisecond, utc.FrameCeil, 2},
+		{59 * time.Millisecond, utc.FrameNearest, 1},
+		{60 * time.Millisecond, utc.FrameNearest, 2},
+		{-59 * time.Millisecond, utc.FrameFloor, -2},
+		{-59 * time.Millisecond, utc.FrameCeil, -1},
+		{-59 * time.Millisecond, utc.FrameNearest, -1},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprint(test.d, test.rounding), func(t *testing.T) {
+			assert.Equal(t, test.want, utc.FPS25.Frames(test.d, test.rounding))
+		})
+	}
+
+	// saturated at the range of int64
+	fast := utc.FrameRate{Num: math.MaxInt64, Den: 1}
+	assert.Equal(t, int64(math.MaxInt64), fast.Frames(time.Hour, utc.FrameFloor))
+	assert.Equal(t, int64(math.MinInt64), fast.Frames(-time.Hour, utc.FrameFloor))
+}
+
+func TestFrameRate_AddFrames(t *testing.T) {
+	start := utc.MustParse("2021-12-25T12:20:00.000Z")
+	rates := []utc.FrameRate{utc.FPS24, utc.FPS25, utc.FPS23976, utc.FPS2997, utc.FPS5994, {Num: 1, Den: 3}}
+	frames := []int64{0, 1, 2, 1000, 107_892, 1_000_000_007, -1, -1001}
+	for _, rate := range rates {
+		for _, n := range frames {
+			t.Run(fmt.Sprint(rate, "_", n), func(t *testing.T) {
+				u := rate.AddFrames(start, n)
+				require.Equal(t, n, rate.FramesBetween(start, u))
+				require.Equal(t, n, rate.FramesBetween(start, u.Add(rate.FrameDuration()-2)))
+				require.Equal(t, n-1, rate.FramesBetween(start, u.Add(-1)))
+			})
+		}
+	}
+
+	// very long content does not overflow time.Duration
+	far := utc.FPS2997.AddFrames(start, 400*365*24*3600*30)
+	require.Equal(t, int64(400*365*24*3600*30), utc.FPS2997.FramesBetween(start, far))
+}
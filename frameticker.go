@@ -0,0 +1,126 @@
+package utc
+
+import (
+	"sync"
+)
+
+// FrameTick is a tick of a FrameTicker.
+type FrameTick struct {
+	Frame int64 // the index of the frame, 0 being the frame that starts when the ticker is created
+	Time  UTC   // the exact start time of the frame
+}
+
+// FrameTicker is like a time.Ticker that ticks at the frame boundaries of a frame rate, for simulating players and
+// frame-accurate schedulers. The start time of each frame is computed from the start time of frame 0 with exact integer
+// arithmetic - see FrameRate.AddFrames - hence ticks do not drift even for rates like 30000/1001 whose frame duration
+// is not a whole number of nanoseconds.
+//
+// It is driven by the global clock: if a TestClock is the global clock when the ticker is created, the ticks are
+// delivered when the TestClock is advanced - see AfterFunc. Like time.Ticker, it drops ticks for slow receivers. If the
+// clock jumps past several frame boundaries, a single tick is delivered for the last of them; the frame index of the
+// tick reveals the number of dropped frames.
+type FrameTicker struct {
+	C <-chan FrameTick // the channel on which the ticks are delivered
+
+	c     chan FrameTick
+	fps   FrameRate
+	start UTC
+
+	mu      sync.Mutex
+	seq     uint64      // incremented on every schedule
+	stop    func() bool // stops the pending timer
+	stopped bool
+}
+
+// NewFrameTicker returns a new FrameTicker for the given frame rate, with frame 0 starting at the current time of the
+// global clock. The first tick is delivered at the start of frame 1. Stop the ticker to release its resources. It
+// panics if the frame rate is invalid - see FrameRate.Validate.
+func NewFrameTicker(fps FrameRate) *FrameTicker {
+	if fps.Validate() != nil {
+		panic("utc.NewFrameTicker: invalid frame rate")
+	}
+	c := make(chan FrameTick, 1)
+	t := &FrameTicker{
+		C:     c,
+		c:     c,
+		fps:   fps,
+		start: Now(),
+	}
+	t.schedule(1)
+	return t
+}
+
+// Start returns the start time of frame 0.
+func (t *FrameTicker) Start() UTC {
+	return t.start
+}
+
+// FrameRate returns the frame rate of the ticker.
+func (t *FrameTicker) FrameRate() FrameRate {
+	return t.fps
+}
+
+// schedule schedules the tick for the given frame, or for the first frame that starts after the current time if the
+// given frame has already started.
+func (t *FrameTicker) schedule(frame int64) {
+	t.mu.Lock()
+	if t.stopped {
+		t.mu.Unlock()
+		return
+	}
+	t.seq++
+	seq := t.seq
+	t.mu.Unlock()
+
+	frame = max(frame, t.fps.FramesBetween(t.start, Now())+1)
+	at := t.fps.AddFrames(t.start, frame)
+
+	// arm without holding the lock: timers of a TestClock may fire synchronously
+	armed := false // guarded by mu
+	stop := AfterFunc(at, func() {
+		// the clock may have jumped past further frame boundaries
+		tick := FrameTick{Frame: max(frame, t.fps.FramesBetween(t.start, Now()))}
+		tick.Time = t.fps.AddFrames(t.start, tick.Frame)
+
+		t.mu.Lock()
+		if t.stopped {
+			t.mu.Unlock()
+			return
+		}
+		select {
+		case t.c <- tick:
+		default:
+		}
+		arming := !armed
+		t.mu.Unlock()
+		if arming {
+			// fired while arming on a TestClock following the wall clock - see Ticker.schedule
+			go t.schedule(tick.Frame + 1)
+			return
+		}
+		t.schedule(tick.Frame + 1)
+	})
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	armed = true
+	switch {
+	case t.stopped:
+		stop()
+	case t.seq == seq:
+		t.stop = stop
+	default:
+		// the timer fired synchronously and the next tick was scheduled in the meantime
+	}
+}
+
+// Stop turns off the ticker. No more ticks are sent after Stop returns. Stop does not close the channel.
+func (t *FrameTicker) Stop() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.stopped = true
+	if t.stop != nil {
+		t.stop()
+		t.stop = nil
+	}
+}
@@ -0,0 +1,89 @@
+package utc_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+func TestFrameTicker(t *testing.T) {
+	now := utc.MustParse("2020-01-01T10:00:00Z")
+	clock := utc.NewWallClock(now).MockNow()
+	defer clock.UnmockNow()
+
+	ticker := utc.NewFrameTicker(utc.FPS2997)
+	defer ticker.Stop()
+	require.Equal(t, now, ticker.Start())
+	require.Equal(t, utc.FPS2997, ticker.FrameRate())
+
+	// frame 1 starts at 33366667ns (1001/30000s rounded up)
+	clock.Add(33366666)
+	select {
+	case <-ticker.C:
+		require.Fail(t, "tick too early")
+	default:
+	}
+	clock.Add(1)
+	require.Equal(t, utc.FrameTick{Frame: 1, Time: now.Add(33366667)}, <-ticker.C)
+
+	// no drift: after 30000 frames, exactly 1001 seconds have passed
+	for i := int64(2); i <= 30000; i++ {
+		clock.Set(utc.FPS2997.AddFrames(now, i))
+		tick := <-ticker.C
+		require.Equal(t, i, tick.Frame)
+		require.Equal(t, clock.Now(), tick.Time)
+	}
+	require.Equal(t, now.Add(1001*time.Second), clock.Now())
+
+	// a jump results in a single tick for the last started frame
+	clock.Add(time.Second + time.Millisecond)
+	require.Equal(t, utc.FrameTick{Frame: 30030, Time: now.Add(1002*time.Second + time.Millisecond)}, <-ticker.C)
+	select {
+	case <-ticker.C:
+		require.Fail(t, "unexpected tick")
+	default:
+	}
+
+	// ticks continue at the frame boundaries after the jump
+	clock.Set(utc.FPS2997.AddFrames(now, 30031))
+	require.Equal(t, int64(30031), (<-ticker.C).Frame)
+
+	ticker.Stop()
+	clock.Add(time.Hour)
+	select {
+	case <-ticker.C:
+		require.Fail(t, "tick after stop")
+	default:
+	}
+}
+
+func TestFrameTicker_invalid(t *testing.T) {
+	require.Panics(t, func() { utc.NewFrameTicker(utc.FrameRate{}) })
+}
+
+func TestFrameTicker_wallClock(t *testing.T) {
+	// a TestClock following the wall clock fires due timers synchronously when they are armed
+	clock := utc.NewTestClock().MockNow()
+	defer clock.UnmockNow()
+
+	ticker := utc.NewFrameTicker(utc.FrameRate{Num: 1000000, Den: 1})
+	first := <-ticker.C
+	second := <-ticker.C
+	require.Greater(t, second.Frame, first.Frame)
+	ticker.Stop()
+
+	// drain a tick sent before Stop
+	select {
+	case <-ticker.C:
+	default:
+	}
+	time.Sleep(time.Millisecond)
+	select {
+	case <-ticker.C:
+		require.Fail(t, "tick after stop")
+	default:
+	}
+}
@@ -0,0 +1,113 @@
+package utc
+
+import (
+	"reflect"
+)
+
+// Freeze prepares snapshot and golden file tests of outputs that embed timestamps: it installs a TestClock set to the
+// given time as global clock and sets all non-zero UTC values reachable from the given targets to that time - see
+// VisitUTC. Zero values are left unchanged, so that unset timestamps remain visible in the output. The returned
+// function restores the global clock.
+//
+//	reset := utc.Freeze(utc.MustParse("2020-01-01"), &response)
+//	defer reset()
+//	golden.Assert(t, response)
+func Freeze(at UTC, targets ...interface{}) (restore func()) {
+	clock := NewWallClock(at).MockNow()
+	FreezeValues(at, targets...)
+	return clock.UnmockNow
+}
+
+// FreezeValues sets all non-zero UTC values reachable from the given targets to the given time, without touching the
+// global clock - see Freeze.
+func FreezeValues(at UTC, targets ...interface{}) {
+	for _, target := range targets {
+		VisitUTC(target, func(u *UTC) {
+			if !u.IsZero() {
+				*u = at
+			}
+		})
+	}
+}
+
+// VisitUTC calls fn with a pointer to every UTC value reachable from v: v itself if it is a *UTC, and otherwise the
+// UTC values in exported struct fields, slices, arrays, map values and interfaces reachable through pointers from v.
+// Types that embed UTC, like UTCNano, are visited through their embedded UTC field. Pointer cycles are followed only
+// once.
+//
+// Only values that can be modified are visited: pass a pointer to a struct rather than the struct itself. UTC values in
+// map values and interfaces are visited on a copy that is stored back after fn returns.
+func VisitUTC(v interface{}, fn func(u *UTC)) {
+	w := &utcVisitor{fn: fn, seen: make(map[visitKey]bool)}
+	w.visit(reflect.ValueOf(v))
+}
+
+var typeOfUTC = reflect.TypeOf(UTC{})
+
+type visitKey struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+type utcVisitor struct {
+	fn   func(u *UTC)
+	seen map[visitKey]bool
+}
+
+func (w *utcVisitor) visit(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return
+		}
+		key := visitKey{ptr: v.Pointer(), typ: v.Type()}
+		if w.seen[key] {
+			return
+		}
+		w.seen[key] = true
+		w.visit(v.Elem())
+	case reflect.Interface:
+		if v.IsNil() {
+			return
+		}
+		e := v.Elem()
+		if e.Kind() == reflect.Ptr {
+			w.visit(e)
+			return
+		}
+		if !v.CanSet() {
+			return
+		}
+		c := reflect.New(e.Type()).Elem()
+		c.Set(e)
+		w.visit(c)
+		v.Set(c)
+	case reflect.Struct:
+		if v.Type() == typeOfUTC {
+			if v.CanSet() {
+				w.fn(v.Addr().Interface().(*UTC))
+			}
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if f := v.Field(i); f.CanSet() {
+				w.visit(f)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			w.visit(v.Index(i))
+		}
+	case reflect.Map:
+		if v.IsNil() {
+			return
+		}
+		keys := v.MapKeys()
+		for _, k := range keys {
+			c := reflect.New(v.Type().Elem()).Elem()
+			c.Set(v.MapIndex(k))
+			w.visit(c)
+			v.SetMapIndex(k, c)
+		}
+	}
+}
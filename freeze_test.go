@@ -0,0 +1,88 @@
+package utc_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+type frozenItem struct {
+	Created utc.UTC     `json:"created"`
+	Updated *utc.UTC    `json:"updated,omitempty"`
+	Deleted utc.UTC     `json:"deleted"`
+	Nano    utc.UTCNano `json:"nano"`
+	private utc.UTC
+}
+
+type frozenDoc struct {
+	Items  []frozenItem           `json:"items"`
+	ByName map[string]utc.UTC     `json:"by_name"`
+	Any    interface{}            `json:"any"`
+	Nested map[string]*frozenItem `json:"nested"`
+	Arr    [2]utc.UTC             `json:"arr"`
+	Self   *frozenDoc             `json:"-"`
+	Extra  map[string]interface{} `json:"extra"`
+}
+
+func TestFreeze(t *testing.T) {
+	now := utc.Now()
+	updated := now.Add(time.Hour)
+	doc := &frozenDoc{
+		Items: []frozenItem{
+			{Created: now, Updated: &updated, Nano: now.Nano(), private: now},
+			{Created: now.Add(time.Minute)},
+		},
+		ByName: map[string]utc.UTC{"a": now, "b": utc.Zero},
+		Any:    now,
+		Nested: map[string]*frozenItem{"x": {Created: now}},
+		Arr:    [2]utc.UTC{now, now},
+		Extra:  map[string]interface{}{"ts": now, "n": 1},
+	}
+	doc.Self = doc
+
+	at := utc.MustParse("2020-01-01T00:00:00Z")
+	reset := utc.Freeze(at, doc)
+	require.Equal(t, at, utc.Now())
+	reset()
+	require.NotEqual(t, at, utc.Now())
+
+	require.Equal(t, at, doc.Items[0].Created)
+	require.Equal(t, at, *doc.Items[0].Updated)
+	require.Equal(t, at, doc.Items[0].Nano.UTC)
+	require.Equal(t, now, doc.Items[0].private) // unexported fields are not visited
+	require.Equal(t, at, doc.Items[1].Created)
+	require.True(t, doc.Items[1].Deleted.IsZero())
+	require.Equal(t, map[string]utc.UTC{"a": at, "b": utc.Zero}, doc.ByName)
+	require.Equal(t, at, doc.Any)
+	require.Equal(t, at, doc.Nested["x"].Created)
+	require.Equal(t, [2]utc.UTC{at, at}, doc.Arr)
+	require.Equal(t, map[string]interface{}{"ts": at, "n": 1}, doc.Extra)
+
+	bb, err := json.Marshal(doc.Items[1])
+	require.NoError(t, err)
+	require.Equal(t, `{"created":"2020-01-01T00:00:00.000Z","deleted":"","nano":""}`, string(bb))
+}
+
+func TestVisitUTC(t *testing.T) {
+	u := utc.Now()
+	count := 0
+	utc.VisitUTC(&u, func(p *utc.UTC) {
+		count++
+		*p = utc.Zero
+	})
+	require.Equal(t, 1, count)
+	require.True(t, u.IsZero())
+
+	// values that cannot be modified are not visited
+	utc.VisitUTC(utc.Now(), func(p *utc.UTC) { count++ })
+	utc.VisitUTC(nil, func(p *utc.UTC) { count++ })
+	utc.VisitUTC((*frozenDoc)(nil), func(p *utc.UTC) { count++ })
+	require.Equal(t, 1, count)
+
+	utc.FreezeValues(utc.Max, &u)
+	require.True(t, u.IsZero())
+}
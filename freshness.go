@@ -0,0 +1,81 @@
+package utc
+
+import (
+	"time"
+)
+
+// HTTPFreshness computes the age and freshness of a cached HTTP response according to the age calculation algorithm
+// of RFC 9111, section 4.2.
+//
+//	f := utc.HTTPFreshness{
+//		RequestTime:  requestTime,
+//		ResponseTime: responseTime,
+//		Date:         date,        // parsed with ParseHTTPDate
+//		Age:          age,         // Age header
+//		MaxAge:       maxAge,      // max-age or s-maxage directive of Cache-Control
+//		HasMaxAge:    true,
+//	}
+//	if f.IsFresh(clock) {
+//		...
+//	}
+//
+// Heuristic freshness (RFC 9111, section 4.2.2) is not applied: responses without max-age and Expires have a
+// freshness lifetime of zero.
+type HTTPFreshness struct {
+	RequestTime  UTC           // the time the request was sent
+	ResponseTime UTC           // the time the response was received
+	Date         UTC           // the value of the Date header - ResponseTime is used if Zero
+	Age          time.Duration // the value of the Age header, if any
+	MaxAge       time.Duration // the value of the s-maxage or max-age directive of the Cache-Control header
+	HasMaxAge    bool          // true if MaxAge is set
+	Expires      UTC           // the value of the Expires header - ignored if Zero or if HasMaxAge is set
+}
+
+// date returns the Date of the response, or the ResponseTime if the Date is not set.
+func (f HTTPFreshness) date() UTC {
+	if f.Date.IsZero() {
+		return f.ResponseTime
+	}
+	return f.Date
+}
+
+// Lifetime returns the freshness lifetime of the response: MaxAge if set, otherwise Expires - Date, or zero.
+func (f HTTPFreshness) Lifetime() time.Duration {
+	switch {
+	case f.HasMaxAge:
+		return max(f.MaxAge, 0)
+	case !f.Expires.IsZero():
+		return max(f.Expires.Sub(f.date()), 0)
+	}
+	return 0
+}
+
+// InitialAge returns the corrected initial age of the response at the time it was received.
+func (f HTTPFreshness) InitialAge() time.Duration {
+	apparentAge := max(f.ResponseTime.Sub(f.date()), 0)
+	responseDelay := f.ResponseTime.Sub(f.RequestTime)
+	correctedAgeValue := f.Age + responseDelay
+	return max(apparentAge, correctedAgeValue)
+}
+
+// CurrentAge returns the current age of the response at the current time of the given clock (utc.Now() if nil).
+func (f HTTPFreshness) CurrentAge(clock Clock) time.Duration {
+	residentTime := clockNow(clock).Sub(f.ResponseTime)
+	return f.InitialAge() + residentTime
+}
+
+// Deadline returns the time at which the response becomes stale.
+func (f HTTPFreshness) Deadline() UTC {
+	return f.ResponseTime.Add(f.Lifetime() - f.InitialAge())
+}
+
+// IsFresh returns true if the response is fresh at the current time of the given clock (utc.Now() if nil).
+func (f HTTPFreshness) IsFresh(clock Clock) bool {
+	return f.Lifetime() > f.CurrentAge(clock)
+}
+
+// Staleness returns the duration for which the response has been stale at the current time of the given clock
+// (utc.Now() if nil), or a negative duration indicating the remaining freshness if the response is still fresh.
+func (f HTTPFreshness) Staleness(clock Clock) time.Duration {
+	return f.CurrentAge(clock) - f.Lifetime()
+}
@@ -0,0 +1,65 @@
+package utc_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+func TestHTTPFreshness(t *testing.T) {
+	req := utc.MustParse("2020-01-01T10:00:00Z")
+	f := utc.HTTPFreshness{
+		RequestTime:  req,
+		ResponseTime: req.Add(2 * time.Second),
+		Date:         req.Add(time.Second),
+		Age:          10 * time.Second,
+		MaxAge:       time.Minute,
+		HasMaxAge:    true,
+	}
+
+	// apparent age: 1s, corrected age value: 10s + 2s response delay
+	require.Equal(t, 12*time.Second, f.InitialAge())
+	require.Equal(t, time.Minute, f.Lifetime())
+	require.Equal(t, "2020-01-01T10:00:50.000Z", f.Deadline().String())
+
+	clock := utc.NewWallClock(req.Add(2 * time.Second))
+	require.Equal(t, 12*time.Second, f.CurrentAge(clock))
+	require.True(t, f.IsFresh(clock))
+	require.Equal(t, -48*time.Second, f.Staleness(clock))
+
+	clock.Set(f.Deadline())
+	require.False(t, f.IsFresh(clock))
+	require.Equal(t, time.Duration(0), f.Staleness(clock))
+
+	clock.Add(5 * time.Second)
+	require.Equal(t, 5*time.Second, f.Staleness(clock))
+}
+
+func TestHTTPFreshness_expires(t *testing.T) {
+	date := utc.MustParse("2020-01-01T10:00:00Z")
+	f := utc.HTTPFreshness{
+		RequestTime:  date,
+		ResponseTime: date,
+		Date:         date.Add(-30 * time.Second), // server clock ahead of ours: apparent age 30s
+		Expires:      date.Add(time.Hour),
+	}
+	require.Equal(t, time.Hour+30*time.Second, f.Lifetime())
+	require.Equal(t, 30*time.Second, f.InitialAge())
+	require.Equal(t, date.Add(time.Hour), f.Deadline())
+
+	// max-age takes precedence over Expires
+	f.HasMaxAge = true
+	require.Equal(t, time.Duration(0), f.Lifetime())
+	require.False(t, f.IsFresh(utc.NewWallClock(date)))
+
+	// no freshness information
+	require.Equal(t, time.Duration(0), utc.HTTPFreshness{ResponseTime: date}.Lifetime())
+
+	// missing Date: the response time is used
+	f = utc.HTTPFreshness{RequestTime: date, ResponseTime: date, Expires: date.Add(time.Hour)}
+	require.Equal(t, time.Hour, f.Lifetime())
+	require.Equal(t, time.Duration(0), f.InitialAge())
+}
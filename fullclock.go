@@ -0,0 +1,304 @@
+package utc
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// FullClock is a Clock with timers. Libraries that need timers, but want to depend on an interface only rather than on
+// the package functions, accept a FullClock, so that applications can pass in a TestClock or Domain in tests. The
+// minimal Clock interface remains the interface for code that only needs the current time.
+//
+// TestClock and *Domain implement FullClock. NewFullClock turns any other Clock, or the global clock, into a FullClock.
+type FullClock interface {
+	Clock
+
+	// AfterFunc calls fn when the clock reaches the given instant and returns a function that stops the timer - see
+	// utc.AfterFunc.
+	AfterFunc(u UTC, fn func()) (stop func() bool)
+
+	// Sleep blocks until the clock has advanced by the given duration.
+	Sleep(d time.Duration)
+
+	// After returns a channel on which the time of the clock is sent once the clock has advanced by the given duration.
+	After(d time.Duration) <-chan UTC
+
+	// NewTimer returns a Timer that sends the time of the clock on its channel once the clock has advanced by the given
+	// duration.
+	NewTimer(d time.Duration) *Timer
+
+	// NewTicker returns a Ticker that sends the time of the clock on its channel in intervals of the given duration.
+	NewTicker(d time.Duration) *Ticker
+}
+
+var (
+	_ FullClock = TestClock{}
+	_ FullClock = (*Domain)(nil)
+	_ FullClock = fullClock{}
+)
+
+// NewFullClock returns a FullClock backed by the given clock. A nil clock denotes the global clock: the returned
+// FullClock then follows utc.Now() and its mocks. If the clock already implements FullClock, it is returned as is.
+// Timers of other clocks are standard runtime timers, computed from the clock's current time.
+func NewFullClock(c Clock) FullClock {
+	if fc, ok := c.(FullClock); ok {
+		return fc
+	}
+	return fullClock{clock: c}
+}
+
+// fullClock implements FullClock for any Clock.
+type fullClock struct {
+	clock Clock
+}
+
+func (f fullClock) Now() UTC {
+	return clockNow(f.clock)
+}
+
+func (f fullClock) AfterFunc(u UTC, fn func()) (stop func() bool) {
+	return clockAfterFunc(f.clock, u, fn)
+}
+
+func (f fullClock) Sleep(d time.Duration) {
+	clockSleep(f.clock, d)
+}
+
+func (f fullClock) After(d time.Duration) <-chan UTC {
+	return newTimer(f.clock, d).C
+}
+
+func (f fullClock) NewTimer(d time.Duration) *Timer {
+	return newTimer(f.clock, d)
+}
+
+func (f fullClock) NewTicker(d time.Duration) *Ticker {
+	return newTicker(f.clock, d)
+}
+
+// Sleep blocks until the time of this TestClock has advanced by the given duration, i.e. until another goroutine sets
+// or advances the clock accordingly - see AfterFunc.
+func (c TestClock) Sleep(d time.Duration) {
+	clockSleep(c, d)
+}
+
+// After returns a channel on which the time of this TestClock is sent once the clock has advanced by the given
+// duration.
+func (c TestClock) After(d time.Duration) <-chan UTC {
+	return newTimer(c, d).C
+}
+
+// NewTimer returns a Timer that fires once this TestClock has advanced by the given duration.
+func (c TestClock) NewTimer(d time.Duration) *Timer {
+	return newTimer(c, d)
+}
+
+// NewTicker returns a Ticker that ticks whenever this TestClock has advanced by the given interval.
+func (c TestClock) NewTicker(d time.Duration) *Ticker {
+	return newTicker(c, d)
+}
+
+// After returns a channel on which the time of the domain is sent once the domain's clock has advanced by the given
+// duration.
+func (d *Domain) After(dur time.Duration) <-chan UTC {
+	return newTimer(d, dur).C
+}
+
+// NewTimer returns a Timer that fires once the domain's clock has advanced by the given duration.
+func (d *Domain) NewTimer(dur time.Duration) *Timer {
+	return newTimer(d, dur)
+}
+
+// NewTicker returns a Ticker that ticks in intervals of the given duration of the domain's clock.
+func (d *Domain) NewTicker(dur time.Duration) *Ticker {
+	return newTicker(d, dur)
+}
+
+// clockSleep blocks until the given clock has advanced by the given duration.
+func clockSleep(clock Clock, d time.Duration) {
+	_ = waitUntil(context.Background(), clockNow(clock).Add(d), func(u UTC, fn func()) func() bool {
+		return clockAfterFunc(clock, u, fn)
+	})
+}
+
+// Timer is like time.Timer, but driven by a Clock - see FullClock.NewTimer. Like time.Timer before Go 1.23, the channel
+// has a buffer of one, and Stop and Reset do not drain it.
+type Timer struct {
+	C <-chan UTC // the channel on which the time is delivered
+
+	c     chan UTC
+	clock Clock
+
+	mu   sync.Mutex
+	gen  uint64      // incremented on Stop and Reset, invalidates a concurrently armed timer
+	stop func() bool // stops the pending timer of the clock
+}
+
+func newTimer(clock Clock, d time.Duration) *Timer {
+	c := make(chan UTC, 1)
+	t := &Timer{C: c, c: c, clock: clock}
+	t.Reset(d)
+	return t
+}
+
+// Stop prevents the timer from firing. It returns true if the call stops the timer, false if the timer has already
+// expired or been stopped.
+func (t *Timer) Stop() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.gen++
+	return t.stopPending()
+}
+
+// Reset changes the timer to expire after the given duration, measured from the current time of the clock. It returns
+// true if the timer had been active, false if it had expired or been stopped.
+func (t *Timer) Reset(d time.Duration) bool {
+	t.mu.Lock()
+	t.gen++
+	gen := t.gen
+	active := t.stopPending()
+	t.mu.Unlock()
+
+	// arm without holding the lock: timers of a TestClock may fire synchronously
+	stop := clockAfterFunc(t.clock, clockNow(t.clock).Add(d), func() {
+		select {
+		case t.c <- clockNow(t.clock):
+		default:
+		}
+	})
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.gen == gen {
+		t.stop = stop
+	} else {
+		// stopped or reset in the meantime
+		stop()
+	}
+	return active
+}
+
+func (t *Timer) stopPending() bool {
+	if t.stop == nil {
+		return false
+	}
+	active := t.stop()
+	t.stop = nil
+	return active
+}
+
+// Ticker is like time.Ticker, but driven by a Clock - see FullClock.NewTicker. Like time.Ticker, it drops ticks for
+// slow receivers. If the clock jumps past several ticks, e.g. when a TestClock is advanced by a multiple of the
+// interval, a single tick is delivered and the next interval starts at the current time.
+type Ticker struct {
+	C <-chan UTC // the channel on which the ticks are delivered
+
+	c      chan UTC
+	clock  Clock
+	jitter float64    // the jitter fraction of the intervals of a JitteredTicker, none if 0
+	rng    *rand.Rand // the source of the jitter, the global source of math/rand if nil - guarded by mu
+
+	mu   sync.Mutex
+	d    time.Duration
+	gen  uint64      // incremented on Stop and Reset, invalidates the timers of previous schedules
+	seq  uint64      // incremented on every schedule
+	stop func() bool // stops the pending timer of the clock
+}
+
+func newTicker(clock Clock, d time.Duration) *Ticker {
+	if d <= 0 {
+		panic("utc.NewTicker: non-positive interval")
+	}
+	c := make(chan UTC, 1)
+	t := &Ticker{C: c, c: c, clock: clock, d: d}
+	t.schedule(clockNow(clock), 0)
+	return t
+}
+
+// Stop turns off the ticker. No more ticks are sent after Stop returns. Stop does not close the channel.
+func (t *Ticker) Stop() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.gen++
+	if t.stop != nil {
+		t.stop()
+		t.stop = nil
+	}
+}
+
+// Reset stops the ticker and resets its interval to the given duration, starting at the current time of the clock. It
+// panics if d is not positive.
+func (t *Ticker) Reset(d time.Duration) {
+	if d <= 0 {
+		panic("utc.Ticker.Reset: non-positive interval")
+	}
+	t.mu.Lock()
+	t.gen++
+	gen := t.gen
+	t.d = d
+	if t.stop != nil {
+		t.stop()
+		t.stop = nil
+	}
+	t.mu.Unlock()
+
+	t.schedule(clockNow(t.clock), gen)
+}
+
+// schedule schedules the next tick one interval after the given instant, unless the ticker was stopped or reset since
+// the given generation.
+func (t *Ticker) schedule(prev UTC, gen uint64) {
+	t.mu.Lock()
+	if t.gen != gen {
+		t.mu.Unlock()
+		return
+	}
+	d := t.d
+	if t.jitter > 0 {
+		d = max(Jitter(d, t.jitter, t.rng), 1)
+	}
+	t.seq++
+	seq := t.seq
+	t.mu.Unlock()
+
+	next := prev.Add(d)
+	if now := clockNow(t.clock); !next.After(now) {
+		next = now.Add(d)
+	}
+	// arm without holding the lock: timers of a TestClock may fire synchronously
+	armed := false // guarded by mu
+	stop := clockAfterFunc(t.clock, next, func() {
+		t.mu.Lock()
+		if t.gen != gen {
+			t.mu.Unlock()
+			return
+		}
+		select {
+		case t.c <- clockNow(t.clock):
+		default:
+		}
+		arming := !armed
+		t.mu.Unlock()
+		if arming {
+			// fired while arming, i.e. on a TestClock following the wall clock that passed the instant in the meantime:
+			// don't recurse, the interval may be shorter than the time it takes to arm the timer
+			go t.schedule(next, gen)
+			return
+		}
+		t.schedule(next, gen)
+	})
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	armed = true
+	switch {
+	case t.gen != gen:
+		stop()
+	case t.seq == seq:
+		t.stop = stop
+	default:
+		// the timer fired synchronously and the next tick was scheduled in the meantime
+	}
+}
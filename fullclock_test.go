@@ -0,0 +1,187 @@
+package utc_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+func TestFullClock_timer(t *testing.T) {
+	d0 := utc.MustParse("2020-01-01T00:00:00Z")
+	clock := utc.NewWallClock(d0)
+
+	timer := clock.NewTimer(time.Minute)
+	clock.Add(59 * time.Second)
+	requireNoTick(t, timer.C)
+	clock.Add(time.Second)
+	require.Equal(t, d0.Add(time.Minute), requireTick(t, timer.C))
+	require.False(t, timer.Stop())
+
+	require.False(t, timer.Reset(time.Minute))
+	require.True(t, timer.Reset(2*time.Minute))
+	clock.Add(time.Minute)
+	requireNoTick(t, timer.C)
+	require.True(t, timer.Stop())
+	clock.Add(time.Hour)
+	requireNoTick(t, timer.C)
+
+	// immediate expiration
+	timer = clock.NewTimer(0)
+	requireTick(t, timer.C)
+
+	after := clock.After(time.Second)
+	clock.Add(time.Second)
+	requireTick(t, after)
+}
+
+func TestFullClock_ticker(t *testing.T) {
+	d0 := utc.MustParse("2020-01-01T00:00:00Z")
+	clock := utc.NewWallClock(d0)
+
+	ticker := clock.NewTicker(time.Minute)
+	requireNoTick(t, ticker.C)
+	for i := 1; i <= 3; i++ {
+		clock.Add(time.Minute)
+		require.Equal(t, d0.Add(time.Duration(i)*time.Minute), requireTick(t, ticker.C))
+	}
+
+	// a large jump delivers a single tick, the next interval starts at the current time
+	clock.Add(10 * time.Minute)
+	require.Equal(t, d0.Add(13*time.Minute), requireTick(t, ticker.C))
+	requireNoTick(t, ticker.C)
+	clock.Add(time.Minute)
+	require.Equal(t, d0.Add(14*time.Minute), requireTick(t, ticker.C))
+
+	// slow receivers: ticks are dropped
+	clock.Add(time.Minute)
+	clock.Add(time.Minute)
+	require.Equal(t, d0.Add(15*time.Minute), requireTick(t, ticker.C))
+	requireNoTick(t, ticker.C)
+
+	ticker.Reset(time.Hour)
+	clock.Add(59 * time.Minute)
+	requireNoTick(t, ticker.C)
+	clock.Add(time.Minute)
+	requireTick(t, ticker.C)
+
+	ticker.Stop()
+	clock.Add(2 * time.Hour)
+	requireNoTick(t, ticker.C)
+
+	require.Panics(t, func() { clock.NewTicker(0) })
+	require.Panics(t, func() { ticker.Reset(-1) })
+}
+
+func TestFullClock_sleep(t *testing.T) {
+	d0 := utc.MustParse("2020-01-01T00:00:00Z")
+	clock := utc.NewWallClock(d0)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		clock.Sleep(time.Hour)
+	}()
+	require.Eventually(t, func() bool {
+		clock.Add(time.Minute)
+		select {
+		case <-done:
+			return true
+		default:
+			return false
+		}
+	}, 5*time.Second, time.Millisecond)
+	require.False(t, clock.Now().Before(d0.Add(time.Hour)))
+}
+
+func TestNewFullClock(t *testing.T) {
+	clock := utc.NewWallClock(utc.MustParse("2020-01-01T00:00:00Z"))
+	require.Equal(t, utc.FullClock(clock), utc.NewFullClock(clock))
+	d := utc.NewDomain("d")
+	require.Equal(t, utc.FullClock(d), utc.NewFullClock(d))
+
+	// the global clock
+	defer clock.MockNow().UnmockNow()
+	global := utc.NewFullClock(nil)
+	require.Equal(t, clock.Now(), global.Now())
+	ticker := global.NewTicker(time.Second)
+	defer ticker.Stop()
+	clock.Add(time.Second)
+	requireTick(t, ticker.C)
+
+	// a clock without timer support uses runtime timers
+	fc := utc.NewFullClock(utc.ClockFn(utc.WallClock))
+	start := time.Now()
+	fc.Sleep(10 * time.Millisecond)
+	require.True(t, time.Since(start) >= 10*time.Millisecond)
+	requireTick(t, fc.After(time.Millisecond))
+	timer := fc.NewTimer(time.Hour)
+	require.True(t, timer.Stop())
+}
+
+func TestFullClock_domain(t *testing.T) {
+	d0 := utc.MustParse("2020-01-01T00:00:00Z")
+	clock := utc.NewWallClock(d0)
+	d := utc.NewDomain("d")
+	defer d.Mock(clock)()
+
+	ticker := d.NewTicker(time.Second)
+	defer ticker.Stop()
+	after := d.After(2 * time.Second)
+	clock.Add(time.Second)
+	requireTick(t, ticker.C)
+	requireNoTick(t, after)
+	clock.Add(time.Second)
+	requireTick(t, ticker.C)
+	requireTick(t, after)
+}
+
+func TestFullClock_concurrent(t *testing.T) {
+	clock := utc.NewWallClock(utc.MustParse("2020-01-01T00:00:00Z"))
+	ticker := clock.NewTicker(time.Second)
+	timer := clock.NewTimer(time.Second)
+
+	wg := sync.WaitGroup{}
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				clock.Add(time.Second)
+				if i%10 == 0 {
+					ticker.Reset(time.Second)
+					timer.Reset(time.Second)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	ticker.Stop()
+	timer.Stop()
+	for len(ticker.C) > 0 {
+		<-ticker.C
+	}
+	clock.Add(time.Hour)
+	requireNoTick(t, ticker.C)
+}
+
+func requireTick(t *testing.T, c <-chan utc.UTC) utc.UTC {
+	select {
+	case u := <-c:
+		return u
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "no tick")
+	}
+	return utc.Zero
+}
+
+func requireNoTick(t *testing.T, c <-chan utc.UTC) {
+	select {
+	case u := <-c:
+		require.Fail(t, "unexpected tick", u)
+	default:
+	}
+}
@@ -0,0 +1,22 @@
+module github.com/eluv-io/utc-go/gormutc
+
+go 1.21
+
+require (
+	github.com/eluv-io/utc-go v0.0.0
+	github.com/stretchr/testify v1.8.4
+	gorm.io/gorm v1.31.2
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/eluv-io/errors-go v1.0.3 // indirect
+	github.com/eluv-io/stack v1.8.2 // indirect
+	github.com/jinzhu/inflection v1.0.0 // indirect
+	github.com/jinzhu/now v1.1.5 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/text v0.20.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/eluv-io/utc-go => ../
@@ -0,0 +1,83 @@
+// Package gormutc provides utc.UTC types for GORM models that create their columns with the dialect's timestamp type
+// and a fixed fractional second precision in migrations:
+//
+//	type Event struct {
+//		ID      uint
+//		Created gormutc.Millis // datetime(3) on MySQL, timestamptz(3) on PostgreSQL
+//		Updated gormutc.UTC    `gorm:"precision:3"`
+//	}
+//
+// The types embed utc.UTC, utc.SQLMillis and utc.SQLMicros respectively, and therefore store and load values with
+// their driver.Valuer and sql.Scanner implementations. Without this package, GORM maps utc.UTC fields through
+// utc.UTC.GormDataType to the dialect's default timestamp type, whose precision varies: e.g. datetime on MySQL
+// truncates to seconds.
+package gormutc
+
+import (
+	"strconv"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+
+	"github.com/eluv-io/utc-go"
+)
+
+// UTC is a utc.UTC whose column is created with the precision given by the "precision" tag, or microseconds by
+// default - the finest precision supported by MySQL and PostgreSQL.
+type UTC struct {
+	utc.UTC
+}
+
+// Millis is a utc.SQLMillis whose column is created with millisecond precision.
+type Millis struct {
+	utc.SQLMillis
+}
+
+// Micros is a utc.SQLMicros whose column is created with microsecond precision.
+type Micros struct {
+	utc.SQLMicros
+}
+
+// GormDBDataType implements the migrator.GormDataTypeInterface of GORM: it returns the column type for the dialect of
+// db, e.g. datetime(6) on MySQL and timestamptz(6) on PostgreSQL, or an empty string for unknown dialects, so that GORM
+// falls back to the dialect's type for utc.UTC.GormDataType.
+func (UTC) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	precision := 6
+	if field != nil && field.Precision > 0 {
+		precision = field.Precision
+	}
+	return DataType(db, precision)
+}
+
+// GormDBDataType returns the column type with millisecond precision, e.g. datetime(3) on MySQL - see
+// UTC.GormDBDataType.
+func (Millis) GormDBDataType(db *gorm.DB, _ *schema.Field) string {
+	return DataType(db, 3)
+}
+
+// GormDBDataType returns the column type with microsecond precision, e.g. datetime(6) on MySQL - see
+// UTC.GormDBDataType.
+func (Micros) GormDBDataType(db *gorm.DB, _ *schema.Field) string {
+	return DataType(db, 6)
+}
+
+// DataType returns the timestamp column type with the given fractional second precision for the dialect of db, or an
+// empty string if the dialect is unknown. Use it in GormDBDataType methods of custom types.
+func DataType(db *gorm.DB, precision int) string {
+	if db == nil || db.Config == nil || db.Dialector == nil {
+		return ""
+	}
+	p := "(" + strconv.Itoa(precision) + ")"
+	switch db.Dialector.Name() {
+	case "mysql":
+		return "datetime" + p
+	case "postgres":
+		return "timestamptz" + p
+	case "sqlserver":
+		return "datetimeoffset" + p
+	case "sqlite":
+		// SQLite stores timestamps as text with the precision of the value
+		return "datetime"
+	}
+	return ""
+}
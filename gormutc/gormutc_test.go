@@ -0,0 +1,98 @@
+package gormutc_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/migrator"
+	"gorm.io/gorm/schema"
+
+	"github.com/eluv-io/utc-go"
+	"github.com/eluv-io/utc-go/gormutc"
+)
+
+var (
+	_ migrator.GormDataTypeInterface = gormutc.UTC{}
+	_ migrator.GormDataTypeInterface = gormutc.Millis{}
+	_ migrator.GormDataTypeInterface = gormutc.Micros{}
+	_ driver.Valuer                  = gormutc.UTC{}
+	_ sql.Scanner                    = (*gormutc.Millis)(nil)
+)
+
+type dialector struct {
+	gorm.Dialector
+	name string
+}
+
+func (d dialector) Name() string { return d.name }
+
+func TestGormDBDataType(t *testing.T) {
+	db := func(name string) *gorm.DB {
+		return &gorm.DB{Config: &gorm.Config{Dialector: dialector{name: name}}}
+	}
+	ms := &schema.Field{Precision: 3}
+
+	tests := []struct {
+		dialect string
+		utc     string
+		utcMs   string
+		millis  string
+		micros  string
+	}{
+		{"mysql", "datetime(6)", "datetime(3)", "datetime(3)", "datetime(6)"},
+		{"postgres", "timestamptz(6)", "timestamptz(3)", "timestamptz(3)", "timestamptz(6)"},
+		{"sqlserver", "datetimeoffset(6)", "datetimeoffset(3)", "datetimeoffset(3)", "datetimeoffset(6)"},
+		{"sqlite", "datetime", "datetime", "datetime", "datetime"},
+		{"other", "", "", "", ""},
+	}
+	for _, test := range tests {
+		t.Run(test.dialect, func(t *testing.T) {
+			d := db(test.dialect)
+			require.Equal(t, test.utc, gormutc.UTC{}.GormDBDataType(d, &schema.Field{}))
+			require.Equal(t, test.utcMs, gormutc.UTC{}.GormDBDataType(d, ms))
+			require.Equal(t, test.millis, gormutc.Millis{}.GormDBDataType(d, ms))
+			require.Equal(t, test.micros, gormutc.Micros{}.GormDBDataType(d, ms))
+		})
+	}
+
+	require.Equal(t, "", gormutc.UTC{}.GormDBDataType(nil, nil))
+}
+
+func TestValueScan(t *testing.T) {
+	u := utc.MustParse("2020-01-02T03:04:05.123456789Z")
+
+	v, err := gormutc.Millis{SQLMillis: utc.SQLMillis{UTC: u}}.Value()
+	require.NoError(t, err)
+	require.Equal(t, u.Truncate(time.Millisecond).Time, v)
+
+	v, err = gormutc.Micros{SQLMicros: utc.SQLMicros{UTC: u}}.Value()
+	require.NoError(t, err)
+	require.Equal(t, u.Truncate(time.Microsecond).Time, v)
+
+	var res gormutc.UTC
+	require.NoError(t, res.Scan(u.Time))
+	require.True(t, u.Equal(res.UTC))
+}
+
+func TestSchema(t *testing.T) {
+	type event struct {
+		ID      uint
+		Created gormutc.Millis
+		Updated gormutc.UTC `gorm:"precision:3"`
+	}
+	s, err := schema.Parse(&event{}, &sync.Map{}, schema.NamingStrategy{})
+	require.NoError(t, err)
+
+	created := s.LookUpField("created")
+	require.NotNil(t, created)
+	require.Equal(t, schema.DataType("time"), created.DataType)
+
+	updated := s.LookUpField("updated")
+	require.NotNil(t, updated)
+	require.Equal(t, 3, updated.Precision)
+}
@@ -0,0 +1,25 @@
+module github.com/eluv-io/utc-go/grpcutc
+
+go 1.21
+
+require (
+	github.com/eluv-io/errors-go v1.0.3
+	github.com/eluv-io/utc-go v0.0.0
+	github.com/stretchr/testify v1.8.4
+	google.golang.org/grpc v1.60.1
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/eluv-io/stack v1.8.2 // indirect
+	github.com/golang/protobuf v1.5.3 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/net v0.16.0 // indirect
+	golang.org/x/sys v0.13.0 // indirect
+	golang.org/x/text v0.13.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20231002182017-d307bd883b97 // indirect
+	google.golang.org/protobuf v1.31.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/eluv-io/utc-go => ../
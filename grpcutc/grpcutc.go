@@ -0,0 +1,229 @@
+// Package grpcutc provides gRPC interceptors that propagate the clock of a request across service boundaries, enabling
+// end-to-end time mocking in integration tests.
+//
+// The client interceptors stamp outgoing requests with the current time of the clock of the request context - see
+// utc.ContextNow - in the metadata entry MetadataKey. The server interceptors read the stamp and install a clock in
+// the request context that starts at the stamped time and advances with the server's clock - see utc.ContextWithClock.
+// Handlers that take the time with utc.ContextNow(ctx) instead of utc.Now() therefore see the time of the client, and
+// since the client interceptors stamp requests with the time of the request context, the clock is propagated further
+// to downstream services:
+//
+//	conn, err := grpc.Dial(target,
+//		grpc.WithChainUnaryInterceptor(grpcutc.UnaryClientInterceptor()),
+//		grpc.WithChainStreamInterceptor(grpcutc.StreamClientInterceptor()),
+//		...)
+//
+//	srv := grpc.NewServer(
+//		grpc.ChainUnaryInterceptor(grpcutc.UnaryServerInterceptor()),
+//		grpc.ChainStreamInterceptor(grpcutc.StreamServerInterceptor()))
+//
+// The server interceptors let clients control the time seen by the handlers: install them in test and integration
+// environments only.
+//
+// The client interceptors also stamp requests with their absolute deadline. The deadline interceptors apply it on the
+// server side with a skew allowance for the difference between the clocks of client and server. Unlike the deadline
+// that gRPC propagates as remaining duration, the absolute deadline includes the time spent in transit. The deadline
+// interceptors are safe for production use:
+//
+//	srv := grpc.NewServer(
+//		grpc.ChainUnaryInterceptor(grpcutc.UnaryServerDeadlineInterceptor(100*time.Millisecond)),
+//		grpc.ChainStreamInterceptor(grpcutc.StreamServerDeadlineInterceptor(100*time.Millisecond)))
+package grpcutc
+
+import (
+	"context"
+	"time"
+
+	"github.com/eluv-io/errors-go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/eluv-io/utc-go"
+)
+
+const (
+	// MetadataKey is the key of the metadata entry holding the time of the client, formatted as ISO 8601 with
+	// nanosecond precision.
+	MetadataKey = "x-utc-now"
+
+	// DeadlineMetadataKey is the key of the metadata entry holding the absolute deadline of the request, formatted as
+	// ISO 8601 with nanosecond precision - see utc.FormatDeadline.
+	DeadlineMetadataKey = "x-utc-deadline"
+)
+
+// OutgoingContext returns a copy of ctx with the outgoing metadata stamped with the current time of the clock of ctx -
+// see utc.ContextNow - and the absolute deadline of ctx, if any - see utc.FormatDeadline. Existing stamps are replaced.
+func OutgoingContext(ctx context.Context) context.Context {
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if ok {
+		md = md.Copy()
+	} else {
+		md = metadata.MD{}
+	}
+	md.Set(MetadataKey, utc.ContextNow(ctx).Nano().String())
+	if d, ok := utc.FormatDeadline(ctx); ok {
+		md.Set(DeadlineMetadataKey, d)
+	} else {
+		md.Delete(DeadlineMetadataKey)
+	}
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+// IncomingContext returns a copy of ctx with a clock that follows the time stamped in the incoming metadata of ctx: the
+// clock is set to the stamped time on receipt and advances with the clock of ctx from then on - see utc.OffsetClock.
+// It returns ctx unchanged if the metadata holds no stamp, and an error if the stamp is invalid.
+func IncomingContext(ctx context.Context) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	vals := md.Get(MetadataKey)
+	if len(vals) == 0 {
+		return ctx, nil
+	}
+	stamp, err := utc.FromString(vals[0])
+	if err != nil {
+		return ctx, errors.E("IncomingContext", errors.K.Invalid, err, "stamp", vals[0])
+	}
+	base := utc.ContextClock(ctx)
+	offset := stamp.Sub(utc.ContextNow(ctx))
+	return utc.ContextWithClock(ctx, utc.NewOffsetClock(base, offset)), nil
+}
+
+// IncomingDeadlineContext returns a copy of ctx with the absolute deadline stamped in the incoming metadata of ctx,
+// shortened by the given skew allowance - see utc.ContextWithPropagatedDeadline. Unlike the deadline that gRPC
+// propagates as remaining duration, the absolute deadline includes the time spent in transit. If the metadata holds no
+// deadline, the returned context has no deadline other than the one of ctx. It returns an error if the deadline is
+// invalid, in which case the returned context is ctx wrapped with a cancel function.
+func IncomingDeadlineContext(ctx context.Context, skew time.Duration) (context.Context, context.CancelFunc, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	vals := md.Get(DeadlineMetadataKey)
+	if len(vals) == 0 {
+		ctx, cancel := context.WithCancel(ctx)
+		return ctx, cancel, nil
+	}
+	deadline, err := utc.ParseDeadline(vals[0])
+	if err != nil {
+		ctx, cancel := context.WithCancel(ctx)
+		return ctx, cancel, errors.E("IncomingDeadlineContext", errors.K.Invalid, err)
+	}
+	ctx, cancel := utc.ContextWithPropagatedDeadline(ctx, deadline, skew)
+	return ctx, cancel, nil
+}
+
+// UnaryClientInterceptor returns a client interceptor that stamps unary requests with the time and deadline of the
+// request context - see OutgoingContext.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		return invoker(OutgoingContext(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor returns a client interceptor that stamps streams with the time and deadline of the stream
+// context - see OutgoingContext.
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(
+		ctx context.Context,
+		desc *grpc.StreamDesc,
+		cc *grpc.ClientConn,
+		method string,
+		streamer grpc.Streamer,
+		opts ...grpc.CallOption,
+	) (grpc.ClientStream, error) {
+		return streamer(OutgoingContext(ctx), desc, cc, method, opts...)
+	}
+}
+
+// UnaryServerInterceptor returns a server interceptor that installs the clock of the client in the request context -
+// see IncomingContext. Requests with an invalid stamp fail with codes.InvalidArgument.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		ctx, err := IncomingContext(ctx)
+		if err != nil {
+			return nil, invalidStamp(MetadataKey, err)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns a server interceptor that installs the clock of the client in the stream context -
+// see IncomingContext. Streams with an invalid stamp fail with codes.InvalidArgument.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		ctx, err := IncomingContext(ss.Context())
+		if err != nil {
+			return invalidStamp(MetadataKey, err)
+		}
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// UnaryServerDeadlineInterceptor returns a server interceptor that applies the absolute deadline of the client with
+// the given skew allowance to the request context - see IncomingDeadlineContext. Requests with an invalid deadline fail
+// with codes.InvalidArgument. Chain it after UnaryServerInterceptor, if used, so that the deadline is interpreted in
+// the clock of the client.
+func UnaryServerDeadlineInterceptor(skew time.Duration) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		ctx, cancel, err := IncomingDeadlineContext(ctx, skew)
+		defer cancel()
+		if err != nil {
+			return nil, invalidStamp(DeadlineMetadataKey, err)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerDeadlineInterceptor returns a server interceptor that applies the absolute deadline of the client with
+// the given skew allowance to the stream context - see IncomingDeadlineContext. Streams with an invalid deadline fail
+// with codes.InvalidArgument. Chain it after StreamServerInterceptor, if used, so that the deadline is interpreted in
+// the clock of the client.
+func StreamServerDeadlineInterceptor(skew time.Duration) grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		ctx, cancel, err := IncomingDeadlineContext(ss.Context(), skew)
+		defer cancel()
+		if err != nil {
+			return invalidStamp(DeadlineMetadataKey, err)
+		}
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func invalidStamp(key string, err error) error {
+	return status.Errorf(codes.InvalidArgument, "invalid %s metadata: %v", key, err)
+}
+
+// serverStream is a grpc.ServerStream with a replaced context.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
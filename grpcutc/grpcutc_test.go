@@ -0,0 +1,167 @@
+package grpcutc_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/eluv-io/utc-go"
+	"github.com/eluv-io/utc-go/grpcutc"
+)
+
+var start = utc.MustParse("2020-01-01T10:00:00.123456789Z")
+
+func TestOutgoingContext(t *testing.T) {
+	ctx := utc.ContextWithClock(context.Background(), utc.NewWallClock(start))
+	ctx = metadata.AppendToOutgoingContext(ctx, "other", "value", grpcutc.MetadataKey, "stale")
+
+	md, ok := metadata.FromOutgoingContext(grpcutc.OutgoingContext(ctx))
+	require.True(t, ok)
+	require.Equal(t, []string{"2020-01-01T10:00:00.123456789Z"}, md.Get(grpcutc.MetadataKey))
+	require.Equal(t, []string{"value"}, md.Get("other"))
+
+	// the metadata of the original context is not modified
+	md, _ = metadata.FromOutgoingContext(ctx)
+	require.Equal(t, []string{"stale"}, md.Get(grpcutc.MetadataKey))
+}
+
+func TestIncomingContext(t *testing.T) {
+	ctx := context.Background()
+	res, err := grpcutc.IncomingContext(ctx)
+	require.NoError(t, err)
+	require.Equal(t, ctx, res)
+
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(grpcutc.MetadataKey, "invalid"))
+	_, err = grpcutc.IncomingContext(ctx)
+	require.Error(t, err)
+
+	// the clock starts at the stamped time and advances with the clock of the server
+	server := utc.NewWallClock(start.Add(time.Hour))
+	ctx = utc.ContextWithClock(context.Background(), server)
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(grpcutc.MetadataKey, start.Nano().String()))
+	ctx, err = grpcutc.IncomingContext(ctx)
+	require.NoError(t, err)
+	require.Equal(t, start, utc.ContextNow(ctx))
+	server.Add(time.Minute)
+	require.Equal(t, start.Add(time.Minute), utc.ContextNow(ctx))
+}
+
+func TestDeadline(t *testing.T) {
+	clock := utc.NewWallClock(start).MockNow()
+	defer clock.UnmockNow()
+
+	// no deadline
+	md, _ := metadata.FromOutgoingContext(grpcutc.OutgoingContext(context.Background()))
+	require.Empty(t, md.Get(grpcutc.DeadlineMetadataKey))
+	ctx, cancel, err := grpcutc.IncomingDeadlineContext(metadata.NewIncomingContext(context.Background(), md), 0)
+	require.NoError(t, err)
+	cancel()
+	_, ok := ctx.Deadline()
+	require.False(t, ok)
+
+	client, cancel := utc.ContextWithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	md, _ = metadata.FromOutgoingContext(grpcutc.OutgoingContext(client))
+	require.Equal(t, []string{"2020-01-01T10:01:00.123456789Z"}, md.Get(grpcutc.DeadlineMetadataKey))
+
+	// time spent in transit counts against the deadline
+	clock.Add(10 * time.Second)
+	var deadline time.Time
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		deadline, _ = ctx.Deadline()
+		return nil, nil
+	}
+	_, err = grpcutc.UnaryServerDeadlineInterceptor(time.Second)(
+		metadata.NewIncomingContext(context.Background(), md), nil, &grpc.UnaryServerInfo{}, handler)
+	require.NoError(t, err)
+	require.Equal(t, start.Add(59*time.Second).Time, deadline)
+
+	// invalid deadlines are rejected
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(grpcutc.DeadlineMetadataKey, "invalid"))
+	_, err = grpcutc.UnaryServerDeadlineInterceptor(time.Second)(ctx, nil, &grpc.UnaryServerInfo{}, handler)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestUnaryInterceptors(t *testing.T) {
+	client := utc.ContextWithClock(context.Background(), utc.NewWallClock(start))
+
+	var got utc.UTC
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		got = utc.ContextNow(ctx)
+		return nil, nil
+	}
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		_, err := grpcutc.UnaryServerInterceptor()(
+			metadata.NewIncomingContext(context.Background(), md),
+			req,
+			&grpc.UnaryServerInfo{FullMethod: method},
+			handler)
+		return err
+	}
+
+	err := grpcutc.UnaryClientInterceptor()(client, "/test/Method", nil, nil, nil, invoker)
+	require.NoError(t, err)
+	require.WithinDuration(t, start.Time, got.Time, time.Minute)
+	require.False(t, got.Before(start))
+
+	// invalid stamps are rejected
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(grpcutc.MetadataKey, "invalid"))
+	_, err = grpcutc.UnaryServerInterceptor()(ctx, nil, &grpc.UnaryServerInfo{}, handler)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestEndToEnd(t *testing.T) {
+	lis := bufconn.Listen(1 << 16)
+
+	received := make(chan utc.UTC, 1)
+	deadlines := make(chan string, 1)
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(grpcutc.UnaryServerInterceptor(), grpcutc.UnaryServerDeadlineInterceptor(0)),
+		grpc.ChainStreamInterceptor(grpcutc.StreamServerInterceptor(), grpcutc.StreamServerDeadlineInterceptor(0)),
+		grpc.UnknownServiceHandler(func(srv interface{}, stream grpc.ServerStream) error {
+			received <- utc.ContextNow(stream.Context())
+			d, _ := utc.FormatDeadline(stream.Context())
+			deadlines <- d
+			if err := stream.RecvMsg(&healthpb.HealthCheckRequest{}); err != nil {
+				return err
+			}
+			return stream.SendMsg(&healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING})
+		}))
+	go func() { _ = srv.Serve(lis) }()
+	defer srv.Stop()
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(grpcutc.UnaryClientInterceptor()),
+		grpc.WithChainStreamInterceptor(grpcutc.StreamClientInterceptor()))
+	require.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+
+	ctx := utc.ContextWithClock(context.Background(), utc.NewWallClock(start))
+	ctx, cancel := context.WithTimeout(ctx, time.Hour)
+	defer cancel()
+	res, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	require.Equal(t, healthpb.HealthCheckResponse_SERVING, res.Status)
+
+	got := <-received
+	require.WithinDuration(t, start.Time, got.Time, time.Minute)
+	require.False(t, got.Before(start))
+
+	// the deadline is propagated in the clock of the client
+	deadline, err := utc.ParseDeadline(<-deadlines)
+	require.NoError(t, err)
+	require.WithinDuration(t, start.Add(time.Hour).Time, deadline.Time, time.Minute)
+}
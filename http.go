@@ -0,0 +1,107 @@
+package utc
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eluv-io/errors-go"
+)
+
+// HTTPDateFormat is the format of dates in HTTP headers (IMF-fixdate, RFC 9110 section 5.6.7), same as
+// http.TimeFormat: Mon, 02 Jan 2006 15:04:05 GMT
+const HTTPDateFormat = "Mon, 02 Jan 2006 15:04:05 GMT"
+
+// httpDateFormats are the formats accepted by ParseHTTPDate: IMF-fixdate and the obsolete RFC 850 and ANSI C asctime
+// formats, which recipients must accept according to RFC 9110.
+var httpDateFormats = []string{
+	HTTPDateFormat,
+	"Monday, 02-Jan-06 15:04:05 GMT",
+	"Mon Jan _2 15:04:05 2006",
+}
+
+// FormatHTTPDate formats u as HTTP date: Mon, 02 Jan 2006 15:04:05 GMT
+func (u UTC) FormatHTTPDate() string {
+	return u.Time.Format(HTTPDateFormat)
+}
+
+// ParseHTTPDate parses an HTTP date in any of the formats allowed by RFC 9110 (like http.ParseTime).
+func ParseHTTPDate(s string) (UTC, error) {
+	var err error
+	for _, format := range httpDateFormats {
+		var t time.Time
+		t, err = time.Parse(format, s)
+		if err == nil {
+			return New(t), nil
+		}
+	}
+	return Zero, errors.E("ParseHTTPDate", errors.K.Invalid, WrapSentinel(ErrParse, err), "date", s)
+}
+
+// FormatRetryAfter formats the Retry-After header value for retrying at the given time, in HTTP-date form.
+func FormatRetryAfter(u UTC) string {
+	return u.FormatHTTPDate()
+}
+
+// FormatRetryAfterDelay formats the Retry-After header value for retrying after the given delay, in delta-seconds
+// form. The delay is rounded up to whole seconds, negative delays are formatted as 0.
+func FormatRetryAfterDelay(d time.Duration) string {
+	if d <= 0 {
+		return "0"
+	}
+	secs := (d + time.Second - 1) / time.Second
+	return strconv.FormatInt(int64(secs), 10)
+}
+
+// ParseRetryAfter parses the value of a Retry-After header in either delta-seconds form ("120") or HTTP-date form
+// ("Fri, 31 Dec 1999 23:59:59 GMT") and returns the time after which to retry. Delta-seconds are relative to the
+// current time of the given clock (utc.Now() if nil).
+func ParseRetryAfter(s string, clock Clock) (UTC, error) {
+	s = strings.TrimSpace(s)
+	if isDigits(s) {
+		secs, err := strconv.ParseInt(s, 10, 64)
+		if err != nil || secs > math.MaxInt64/int64(time.Second) {
+			// s consists of digits only: the number is too large
+			return Zero, errors.E("ParseRetryAfter", errors.K.Invalid, WrapSentinel(ErrOutOfRange, err), "retry_after", s)
+		}
+		return clockNow(clock).Add(time.Duration(secs) * time.Second), nil
+	}
+	res, err := ParseHTTPDate(s)
+	if err != nil {
+		return Zero, errors.E("ParseRetryAfter", errors.K.Invalid, err, "retry_after", s)
+	}
+	return res, nil
+}
+
+// FormatCookieExpires formats u as value of the Expires attribute of a Set-Cookie header: Mon, 02 Jan 2006 15:04:05
+// GMT. The value is truncated to seconds.
+func (u UTC) FormatCookieExpires() string {
+	return u.FormatHTTPDate()
+}
+
+// CookieExpiry converts the value of the Max-Age attribute of a cookie to the absolute expiry time relative to the
+// current time of the given clock (utc.Now() if nil). According to RFC 6265, a Max-Age of zero or less expires the
+// cookie immediately: the earliest representable time is returned, the unix epoch.
+func CookieExpiry(maxAge int, clock Clock) UTC {
+	if maxAge <= 0 {
+		return Unix(0, 0)
+	}
+	return clockNow(clock).Add(time.Duration(maxAge) * time.Second)
+}
+
+// CookieMaxAge converts the absolute expiry time of a cookie to a Max-Age in seconds relative to the current time of
+// the given clock (utc.Now() if nil), following the conventions of the MaxAge field of http.Cookie: the result is
+// rounded up to whole seconds and is at least 1 for expiries in the future, and -1 (delete the cookie now) for
+// expiries at or before the current time.
+func CookieMaxAge(expires UTC, clock Clock) int {
+	d := expires.Sub(clockNow(clock))
+	if d <= 0 {
+		return -1
+	}
+	secs := (d + time.Second - 1) / time.Second
+	if secs > math.MaxInt32 {
+		return math.MaxInt32
+	}
+	return int(secs)
+}
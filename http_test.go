@@ -0,0 +1,76 @@
+package utc_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+func TestHTTPDate(t *testing.T) {
+	u := utc.MustParse("1994-11-06T08:49:37Z")
+	require.Equal(t, "Sun, 06 Nov 1994 08:49:37 GMT", u.FormatHTTPDate())
+	require.Equal(t, u.Time.Format(http.TimeFormat), u.FormatHTTPDate())
+
+	for _, s := range []string{
+		"Sun, 06 Nov 1994 08:49:37 GMT",
+		"Sunday, 06-Nov-94 08:49:37 GMT",
+		"Sun Nov  6 08:49:37 1994",
+	} {
+		res, err := utc.ParseHTTPDate(s)
+		require.NoError(t, err, s)
+		require.Equal(t, u, res, s)
+	}
+
+	_, err := utc.ParseHTTPDate("1994-11-06T08:49:37Z")
+	require.Error(t, err)
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := utc.MustParse("2020-01-01T10:00:00Z")
+	clock := utc.NewWallClock(now)
+
+	require.Equal(t, "Wed, 01 Jan 2020 10:02:00 GMT", utc.FormatRetryAfter(now.Add(2*time.Minute)))
+	require.Equal(t, "120", utc.FormatRetryAfterDelay(2*time.Minute))
+	require.Equal(t, "2", utc.FormatRetryAfterDelay(1500*time.Millisecond))
+	require.Equal(t, "0", utc.FormatRetryAfterDelay(-time.Second))
+
+	res, err := utc.ParseRetryAfter("120", clock)
+	require.NoError(t, err)
+	require.Equal(t, now.Add(2*time.Minute), res)
+
+	res, err = utc.ParseRetryAfter(" Wed, 01 Jan 2020 10:02:00 GMT", clock)
+	require.NoError(t, err)
+	require.Equal(t, now.Add(2*time.Minute), res)
+
+	for _, s := range []string{"", "-1", "1.5", "99999999999999999999", "tomorrow"} {
+		_, err = utc.ParseRetryAfter(s, clock)
+		require.Error(t, err, s)
+	}
+}
+
+func TestCookieHelpers(t *testing.T) {
+	now := utc.MustParse("2020-01-01T10:00:00Z")
+	clock := utc.NewWallClock(now)
+
+	expires := now.Add(time.Hour + 500*time.Millisecond)
+	require.Equal(t, "Wed, 01 Jan 2020 11:00:00 GMT", expires.FormatCookieExpires())
+
+	// same format as net/http
+	cookie := &http.Cookie{Name: "a", Value: "b", Expires: expires.Time}
+	require.Contains(t, cookie.String(), "Expires="+expires.FormatCookieExpires())
+
+	require.Equal(t, now.Add(time.Hour), utc.CookieExpiry(3600, clock))
+	require.Equal(t, utc.Unix(0, 0), utc.CookieExpiry(0, clock))
+	require.Equal(t, utc.Unix(0, 0), utc.CookieExpiry(-1, clock))
+
+	require.Equal(t, 3601, utc.CookieMaxAge(expires, clock))
+	require.Equal(t, 3600, utc.CookieMaxAge(now.Add(time.Hour), clock))
+	require.Equal(t, 1, utc.CookieMaxAge(now.Add(time.Nanosecond), clock))
+	require.Equal(t, -1, utc.CookieMaxAge(now, clock))
+	require.Equal(t, -1, utc.CookieMaxAge(now.Add(-time.Hour), clock))
+	require.Equal(t, 3600, utc.CookieMaxAge(utc.CookieExpiry(3600, clock), clock))
+}
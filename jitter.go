@@ -0,0 +1,57 @@
+package utc
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Jitter returns a random duration in the interval [d - fraction*d, d + fraction*d], e.g. between 9s and 11s for d=10s
+// and fraction=0.1. The fraction is capped at 1. The random values are taken from the given source, or from the
+// global source of math/rand if nil. Use a seeded source for reproducible tests.
+func Jitter(d time.Duration, fraction float64, rng *rand.Rand) time.Duration {
+	if d <= 0 || fraction <= 0 {
+		return d
+	}
+	fraction = math.Min(fraction, 1)
+	delta := time.Duration(float64(d) * fraction)
+	lo := d - delta
+	hi := d + min(delta, math.MaxInt64-d)
+
+	var r float64
+	if rng == nil {
+		r = rand.Float64()
+	} else {
+		r = rng.Float64()
+	}
+	return lo + time.Duration(r*float64(hi-lo))
+}
+
+// JitteredTicker is like a time.Ticker with randomized intervals, for avoiding thundering herds of periodic tasks. It
+// is driven by the global clock: if a TestClock is the global clock when the ticker is created, the ticks are
+// delivered when the TestClock is advanced - see AfterFunc.
+type JitteredTicker struct {
+	C <-chan UTC // the channel on which the ticks are delivered
+
+	ticker *Ticker
+}
+
+// NewJitteredTicker returns a new JitteredTicker with intervals of Jitter(d, fraction, rng). The random values are
+// taken from the given source, or from the global source of math/rand if nil: use a seeded source together with a
+// TestClock for reproducible ticks in tests. The source is only used by the ticker while it runs. Like time.Ticker,
+// it drops ticks for slow receivers. If the clock jumps past several ticks, a single tick is delivered and the next
+// interval starts at the current time. Stop the ticker to release its resources. It panics if d is not positive.
+func NewJitteredTicker(d time.Duration, fraction float64, rng *rand.Rand) *JitteredTicker {
+	if d <= 0 {
+		panic("utc.NewJitteredTicker: non-positive interval")
+	}
+	c := make(chan UTC, 1)
+	t := &Ticker{C: c, c: c, d: d, jitter: fraction, rng: rng}
+	t.schedule(Now(), 0)
+	return &JitteredTicker{C: c, ticker: t}
+}
+
+// Stop turns off the ticker. No more ticks are sent after Stop returns. Stop does not close the channel.
+func (t *JitteredTicker) Stop() {
+	t.ticker.Stop()
+}
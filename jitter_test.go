@@ -0,0 +1,139 @@
+package utc_test
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+func TestJitter(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		j := utc.Jitter(10*time.Second, 0.1, rng)
+		require.GreaterOrEqual(t, j, 9*time.Second)
+		require.LessOrEqual(t, j, 11*time.Second)
+	}
+
+	// reproducible with the same seed
+	r1 := rand.New(rand.NewSource(42))
+	r2 := rand.New(rand.NewSource(42))
+	require.Equal(t, utc.Jitter(time.Minute, 0.5, r1), utc.Jitter(time.Minute, 0.5, r2))
+
+	// no jitter
+	require.Equal(t, time.Second, utc.Jitter(time.Second, 0, rng))
+	require.Equal(t, time.Second, utc.Jitter(time.Second, -1, rng))
+	require.Equal(t, -time.Second, utc.Jitter(-time.Second, 0.5, rng))
+
+	// fraction capped at 1, no overflow
+	j := utc.Jitter(time.Second, 5, nil)
+	require.GreaterOrEqual(t, j, time.Duration(0))
+	require.LessOrEqual(t, j, 2*time.Second)
+	j = utc.Jitter(time.Duration(1<<62), 1, nil)
+	require.GreaterOrEqual(t, j, time.Duration(0))
+}
+
+func TestJitteredTicker(t *testing.T) {
+	now := utc.MustParse("2020-01-01T10:00:00Z")
+	clock := utc.NewWallClock(now).MockNow()
+	defer clock.UnmockNow()
+
+	ticker := utc.NewJitteredTicker(10*time.Second, 0.2, nil)
+	defer ticker.Stop()
+
+	clock.Add(7 * time.Second)
+	select {
+	case <-ticker.C:
+		require.Fail(t, "tick too early")
+	default:
+	}
+
+	var last utc.UTC
+	for i := 0; i < 10; i++ {
+		clock.Add(6 * time.Second)
+		select {
+		case tick := <-ticker.C:
+			require.Equal(t, clock.Now(), tick)
+			require.True(t, tick.After(last))
+			last = tick
+		default:
+			// an interval of up to 12s may span two steps of 6s
+			clock.Add(6 * time.Second)
+			last = <-ticker.C
+		}
+	}
+
+	// a large jump results in a single tick
+	clock.Add(time.Hour)
+	<-ticker.C
+	select {
+	case <-ticker.C:
+		require.Fail(t, "unexpected tick")
+	default:
+	}
+
+	ticker.Stop()
+	clock.Add(time.Hour)
+	select {
+	case <-ticker.C:
+		require.Fail(t, "tick after stop")
+	default:
+	}
+}
+
+func TestJitteredTicker_seeded(t *testing.T) {
+	ticks := func() []utc.UTC {
+		clock := utc.NewWallClock(utc.MustParse("2020-01-01T10:00:00Z")).MockNow()
+		defer clock.UnmockNow()
+
+		ticker := utc.NewJitteredTicker(10*time.Second, 0.5, rand.New(rand.NewSource(42)))
+		defer ticker.Stop()
+
+		var res []utc.UTC
+		for len(res) < 5 {
+			clock.Add(time.Second)
+			select {
+			case tick := <-ticker.C:
+				res = append(res, tick)
+			default:
+			}
+		}
+		return res
+	}
+
+	first := ticks()
+	require.Equal(t, first, ticks())
+
+	// the intervals are jittered
+	intervals := map[time.Duration]bool{}
+	for i := 1; i < len(first); i++ {
+		intervals[first[i].Sub(first[i-1])] = true
+	}
+	require.Greater(t, len(intervals), 1)
+}
+
+func TestJitteredTicker_wallClock(t *testing.T) {
+	// a TestClock following the wall clock fires due timers synchronously when they are armed
+	clock := utc.NewTestClock().MockNow()
+	defer clock.UnmockNow()
+
+	ticker := utc.NewJitteredTicker(time.Microsecond, 0.5, nil)
+	<-ticker.C
+	<-ticker.C
+	ticker.Stop()
+
+	// drain a tick sent before Stop
+	select {
+	case <-ticker.C:
+	default:
+	}
+	time.Sleep(time.Millisecond)
+	select {
+	case <-ticker.C:
+		require.Fail(t, "tick after stop")
+	default:
+	}
+}
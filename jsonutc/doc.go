@@ -0,0 +1,16 @@
+// Package jsonutc provides codecs for utc.UTC for the JSON libraries json-iterator and easyjson, so that UTC values
+// keep the exact format of encoding/json and are encoded and decoded without intermediate allocations.
+//
+// For json-iterator, register the codec globally or for a specific configuration:
+//
+//	jsonutc.RegisterJsoniter()
+//	// or
+//	api := jsoniter.ConfigCompatibleWithStandardLibrary
+//	api.RegisterExtension(jsonutc.Extension())
+//
+// For easyjson, use jsonutc.UTC for fields in the structs for which code is generated, or call WriteEasyJSON and
+// ReadEasyJSON in hand-written marshalers.
+//
+// Like encoding/json, both codecs encode the zero value of UTC as "" also in fields tagged with omitempty, so that the
+// output is identical to that of encoding/json.
+package jsonutc
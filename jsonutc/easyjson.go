@@ -0,0 +1,58 @@
+package jsonutc
+
+import (
+	"bytes"
+
+	"github.com/mailru/easyjson/jlexer"
+	"github.com/mailru/easyjson/jwriter"
+
+	"github.com/eluv-io/utc-go"
+)
+
+// UTC is a utc.UTC that implements the easyjson.Marshaler and easyjson.Unmarshaler interfaces, so that code generated
+// by easyjson encodes and decodes it directly instead of through MarshalJSON and UnmarshalJSON. Like with encoding/json,
+// the zero value is encoded as "" also for fields tagged with omitempty.
+type UTC struct {
+	utc.UTC
+}
+
+// MarshalEasyJSON implements the easyjson.Marshaler interface.
+func (u UTC) MarshalEasyJSON(w *jwriter.Writer) {
+	WriteEasyJSON(w, u.UTC)
+}
+
+// UnmarshalEasyJSON implements the easyjson.Unmarshaler interface.
+func (u *UTC) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	ReadEasyJSON(l, &u.UTC)
+}
+
+// WriteEasyJSON writes u to w in the JSON format of utc.UTC, without intermediate allocations. Use it in hand-written
+// MarshalEasyJSON methods.
+func WriteEasyJSON(w *jwriter.Writer, u utc.UTC) {
+	if w.Error != nil {
+		return
+	}
+	w.Buffer.EnsureSpace(len(utc.ISO8601) + 2)
+	b, err := u.AppendText(append(w.Buffer.Buf, '"'))
+	if err != nil {
+		w.Error = err
+		return
+	}
+	w.Buffer.Buf = append(b, '"')
+}
+
+// ReadEasyJSON reads a UTC in the JSON format of utc.UTC from l into u. Like utc.UTC.UnmarshalJSON, null is a no-op. Use
+// it in hand-written UnmarshalEasyJSON methods.
+func ReadEasyJSON(l *jlexer.Lexer, u *utc.UTC) {
+	if l.IsNull() {
+		l.Skip()
+		return
+	}
+	data := l.UnsafeBytes()
+	if !l.Ok() {
+		return
+	}
+	if err := u.UnmarshalText(bytes.TrimSpace(data)); err != nil {
+		l.AddError(err)
+	}
+}
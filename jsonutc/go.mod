@@ -0,0 +1,23 @@
+module github.com/eluv-io/utc-go/jsonutc
+
+go 1.21
+
+require (
+	github.com/eluv-io/utc-go v0.0.0
+	github.com/json-iterator/go v1.1.12
+	github.com/mailru/easyjson v0.7.7
+	github.com/modern-go/reflect2 v1.0.2
+	github.com/stretchr/testify v1.8.4
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/eluv-io/errors-go v1.0.3 // indirect
+	github.com/eluv-io/stack v1.8.2 // indirect
+	github.com/josharian/intern v1.0.0 // indirect
+	github.com/modern-go/concurrent v0.0.0-20180228061459-e0a39a4cb421 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/eluv-io/utc-go => ../
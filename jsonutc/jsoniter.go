@@ -0,0 +1,86 @@
+package jsonutc
+
+import (
+	"reflect"
+	"strings"
+	"unsafe"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/modern-go/reflect2"
+
+	"github.com/eluv-io/utc-go"
+)
+
+var typeOfUTC = reflect.TypeOf(utc.UTC{})
+
+// RegisterJsoniter registers the UTC codec globally for all json-iterator configurations. Use Extension instead to
+// register it for a specific configuration only.
+func RegisterJsoniter() {
+	jsoniter.RegisterTypeEncoder(typeOfUTC.String(), codec{})
+	jsoniter.RegisterTypeDecoder(typeOfUTC.String(), codec{})
+}
+
+// Extension returns a json-iterator extension that encodes and decodes UTC values with the codec of this package:
+//
+//	api := jsoniter.ConfigCompatibleWithStandardLibrary
+//	api.RegisterExtension(jsonutc.Extension())
+func Extension() jsoniter.Extension {
+	return &extension{}
+}
+
+type extension struct {
+	jsoniter.DummyExtension
+}
+
+func (*extension) CreateEncoder(typ reflect2.Type) jsoniter.ValEncoder {
+	if typ.Type1() == typeOfUTC {
+		return codec{}
+	}
+	return nil
+}
+
+func (*extension) CreateDecoder(typ reflect2.Type) jsoniter.ValDecoder {
+	if typ.Type1() == typeOfUTC {
+		return codec{}
+	}
+	return nil
+}
+
+// codec is the json-iterator ValEncoder and ValDecoder for UTC.
+type codec struct{}
+
+// IsEmpty always returns false: like with encoding/json, where omitempty does not apply to structs, the zero value is
+// encoded as "" for fields tagged with omitempty.
+func (codec) IsEmpty(unsafe.Pointer) bool {
+	return false
+}
+
+func (codec) Encode(ptr unsafe.Pointer, stream *jsoniter.Stream) {
+	u := (*utc.UTC)(ptr)
+	b := append(stream.Buffer(), '"')
+	b, err := u.AppendText(b)
+	if err != nil {
+		stream.Error = err
+		return
+	}
+	stream.SetBuffer(append(b, '"'))
+}
+
+func (codec) Decode(ptr unsafe.Pointer, iter *jsoniter.Iterator) {
+	switch iter.WhatIsNext() {
+	case jsoniter.NilValue:
+		// like UTC.UnmarshalJSON: null is a no-op
+		iter.ReadNil()
+	case jsoniter.StringValue:
+		s := iter.ReadString()
+		if iter.Error != nil {
+			return
+		}
+		if err := (*utc.UTC)(ptr).UnmarshalText([]byte(strings.TrimSpace(s))); err != nil {
+			iter.ReportError("decode utc.UTC", err.Error())
+		}
+	default:
+		iter.ReportError("decode utc.UTC", "not a JSON string")
+		iter.Skip()
+	}
+}
@@ -0,0 +1,134 @@
+package jsonutc_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/mailru/easyjson"
+	"github.com/mailru/easyjson/jlexer"
+	"github.com/mailru/easyjson/jwriter"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+	"github.com/eluv-io/utc-go/jsonutc"
+)
+
+type doc struct {
+	At    utc.UTC  `json:"at"`
+	Ptr   *utc.UTC `json:"ptr"`
+	Empty utc.UTC  `json:"empty"`
+	Omit  utc.UTC  `json:"omit,omitempty"`
+}
+
+func TestJsoniter(t *testing.T) {
+	api := jsoniter.Config{EscapeHTML: true, SortMapKeys: true, ValidateJsonRawMessage: true}.Froze()
+	api.RegisterExtension(jsonutc.Extension())
+	testJsoniter(t, api)
+}
+
+func TestRegisterJsoniter(t *testing.T) {
+	jsonutc.RegisterJsoniter()
+	testJsoniter(t, jsoniter.ConfigDefault)
+}
+
+func testJsoniter(t *testing.T, api jsoniter.API) {
+	u := utc.MustParse("2020-01-02T03:04:05.678Z")
+	d := doc{At: u, Ptr: &u}
+
+	b, err := api.Marshal(d)
+	require.NoError(t, err)
+	require.Equal(t, `{"at":"2020-01-02T03:04:05.678Z","ptr":"2020-01-02T03:04:05.678Z","empty":"","omit":""}`, string(b))
+
+	// same as encoding/json, including omitempty
+	std, err := json.Marshal(d)
+	require.NoError(t, err)
+	require.Equal(t, string(std), string(b))
+
+	var res doc
+	require.NoError(t, api.Unmarshal(b, &res))
+	require.True(t, u.Equal(res.At))
+	require.True(t, u.Equal(*res.Ptr))
+	require.True(t, res.Empty.IsZero())
+
+	require.NoError(t, api.Unmarshal([]byte(`{"at":null,"ptr":null,"omit":" 2020-01-02T04:04:05.678+01:00 "}`), &res))
+	require.True(t, u.Equal(res.At))
+	require.Nil(t, res.Ptr)
+	require.True(t, u.Equal(res.Omit))
+
+	require.Error(t, api.Unmarshal([]byte(`{"at":"invalid"}`), &res))
+	require.Error(t, api.Unmarshal([]byte(`{"at":1}`), &res))
+
+	_, err = api.Marshal(utc.Max.Add(1))
+	require.Error(t, err)
+}
+
+type easyDoc struct {
+	At  utc.UTC
+	Opt jsonutc.UTC
+}
+
+func (d easyDoc) MarshalEasyJSON(w *jwriter.Writer) {
+	w.RawString(`{"at":`)
+	jsonutc.WriteEasyJSON(w, d.At)
+	if !d.Opt.IsZero() {
+		w.RawString(`,"opt":`)
+		d.Opt.MarshalEasyJSON(w)
+	}
+	w.RawByte('}')
+}
+
+func (d *easyDoc) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	l.Delim('{')
+	for !l.IsDelim('}') {
+		key := l.UnsafeFieldName(false)
+		l.WantColon()
+		switch key {
+		case "at":
+			jsonutc.ReadEasyJSON(l, &d.At)
+		case "opt":
+			d.Opt.UnmarshalEasyJSON(l)
+		default:
+			l.SkipRecursive()
+		}
+		l.WantComma()
+	}
+	l.Delim('}')
+}
+
+func TestEasyJSON(t *testing.T) {
+	u := utc.MustParse("2020-01-02T03:04:05.678Z")
+
+	b, err := easyjson.Marshal(easyDoc{At: u})
+	require.NoError(t, err)
+	require.Equal(t, `{"at":"2020-01-02T03:04:05.678Z"}`, string(b))
+
+	b, err = easyjson.Marshal(easyDoc{Opt: jsonutc.UTC{UTC: u}})
+	require.NoError(t, err)
+	require.Equal(t, `{"at":"","opt":"2020-01-02T03:04:05.678Z"}`, string(b))
+
+	var res easyDoc
+	require.NoError(t, easyjson.Unmarshal(b, &res))
+	require.True(t, res.At.IsZero())
+	require.True(t, u.Equal(res.Opt.UTC))
+
+	res = easyDoc{}
+	require.NoError(t, easyjson.Unmarshal([]byte(`{"at":"2020-01-02T03:04:05.678Z","opt":null}`), &res))
+	require.True(t, u.Equal(res.At))
+	require.True(t, res.Opt.IsZero())
+
+	require.Error(t, easyjson.Unmarshal([]byte(`{"at":"invalid"}`), &res))
+	require.Error(t, easyjson.Unmarshal([]byte(`{"at":1}`), &res))
+
+	_, err = easyjson.Marshal(easyDoc{At: utc.Max.Add(1)})
+	require.Error(t, err)
+
+	// not optional: the zero value is not omitted by generated code, like with encoding/json
+	_, optional := interface{}(jsonutc.UTC{}).(easyjson.Optional)
+	require.False(t, optional)
+
+	// jsonutc.UTC still marshals with encoding/json
+	b, err = json.Marshal(jsonutc.UTC{UTC: u})
+	require.NoError(t, err)
+	require.Equal(t, `"2020-01-02T03:04:05.678Z"`, string(b))
+}
@@ -0,0 +1,97 @@
+package utc
+
+import (
+	"sync"
+	"time"
+)
+
+// ClockJump describes a step of the system's wall clock detected by a JumpMonitor.
+type ClockJump struct {
+	At     UTC           // the wall clock time after the jump
+	Offset time.Duration // the size of the jump: positive if the wall clock stepped forward, negative if backward
+}
+
+// JumpMonitor detects steps of the system's wall clock, e.g. caused by NTP steps, manual clock changes or the resume
+// of a suspended VM. It periodically compares the progression of the wall clock with the progression of the monotonic
+// clock and calls a callback when the two differ by more than a threshold.
+//
+// The monitor works on the system clock and is not affected by mocked clocks.
+type JumpMonitor struct {
+	interval  time.Duration
+	threshold time.Duration
+	fn        func(ClockJump)
+	sample    func() clockSample
+
+	prev     clockSample
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewJumpMonitor creates and starts a JumpMonitor that samples the clocks at the given interval and calls fn in the
+// monitor's goroutine for every jump whose absolute size exceeds the threshold. The threshold should allow for the
+// gradual adjustments of a clock discipline like NTP slewing, which typically amount to less than a millisecond per
+// second. Stop the monitor to release its resources.
+func NewJumpMonitor(interval, threshold time.Duration, fn func(ClockJump)) *JumpMonitor {
+	m := newJumpMonitor(interval, threshold, fn, sampleClock)
+	go m.run()
+	return m
+}
+
+func newJumpMonitor(interval, threshold time.Duration, fn func(ClockJump), sample func() clockSample) *JumpMonitor {
+	return &JumpMonitor{
+		interval:  interval,
+		threshold: threshold,
+		fn:        fn,
+		sample:    sample,
+		prev:      sample(),
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+}
+
+// Stop stops the monitor and waits until its goroutine has terminated. No more callbacks are invoked after Stop
+// returns. Stop must not be called from within the callback.
+func (m *JumpMonitor) Stop() {
+	m.stopOnce.Do(func() { close(m.stop) })
+	<-m.done
+}
+
+func (m *JumpMonitor) run() {
+	defer close(m.done)
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-m.stop:
+			return
+		case <-ticker.C:
+			m.check()
+		}
+	}
+}
+
+// check takes a sample and calls the callback if the wall clock jumped since the previous sample.
+func (m *JumpMonitor) check() {
+	cur := m.sample()
+	offset := cur.wall.Sub(m.prev.wall) - (cur.mono - m.prev.mono)
+	m.prev = cur
+	if offset > m.threshold || offset < -m.threshold {
+		m.fn(ClockJump{At: New(cur.wall), Offset: offset})
+	}
+}
+
+// clockSample is a simultaneous reading of the wall clock and the monotonic clock.
+type clockSample struct {
+	wall time.Time     // the wall clock reading, without monotonic clock reading
+	mono time.Duration // the monotonic clock reading, relative to monoBase
+}
+
+// monoBase is the reference for monotonic clock readings.
+var monoBase = time.Now()
+
+// sampleClock returns a reading of the system's wall and monotonic clocks.
+func sampleClock() clockSample {
+	t := time.Now()
+	return clockSample{wall: t.Round(0), mono: t.Sub(monoBase)}
+}
@@ -0,0 +1,78 @@
+package utc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClocks provides clock samples with independently controlled wall and monotonic clocks.
+type fakeClocks struct {
+	wall time.Time
+	mono time.Duration
+}
+
+func (f *fakeClocks) sample() clockSample {
+	return clockSample{wall: f.wall, mono: f.mono}
+}
+
+// advance advances both clocks by d.
+func (f *fakeClocks) advance(d time.Duration) {
+	f.wall = f.wall.Add(d)
+	f.mono += d
+}
+
+func TestJumpMonitor_check(t *testing.T) {
+	clocks := &fakeClocks{wall: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	var jumps []ClockJump
+	m := newJumpMonitor(time.Second, 100*time.Millisecond, func(j ClockJump) {
+		jumps = append(jumps, j)
+	}, clocks.sample)
+
+	clocks.advance(time.Second)
+	m.check()
+	require.Empty(t, jumps)
+
+	// slewing below the threshold
+	clocks.advance(time.Second)
+	clocks.wall = clocks.wall.Add(50 * time.Millisecond)
+	m.check()
+	require.Empty(t, jumps)
+
+	// step forward
+	clocks.advance(time.Second)
+	clocks.wall = clocks.wall.Add(time.Hour)
+	m.check()
+	require.Len(t, jumps, 1)
+	require.Equal(t, time.Hour, jumps[0].Offset)
+	require.True(t, New(clocks.wall).Equal(jumps[0].At))
+
+	// no repeated report
+	clocks.advance(time.Second)
+	m.check()
+	require.Len(t, jumps, 1)
+
+	// step backward
+	clocks.advance(time.Second)
+	clocks.wall = clocks.wall.Add(-5 * time.Second)
+	m.check()
+	require.Len(t, jumps, 2)
+	require.Equal(t, -5*time.Second, jumps[1].Offset)
+
+	// VM resume: the monotonic clock did not advance during the suspension
+	clocks.advance(time.Second)
+	clocks.wall = clocks.wall.Add(10 * time.Minute)
+	m.check()
+	require.Len(t, jumps, 3)
+	require.Equal(t, 10*time.Minute, jumps[2].Offset)
+}
+
+func TestJumpMonitor(t *testing.T) {
+	m := NewJumpMonitor(time.Millisecond, time.Hour, func(j ClockJump) {
+		require.Fail(t, "unexpected jump", j)
+	})
+	time.Sleep(20 * time.Millisecond)
+	m.Stop()
+	m.Stop()
+}
@@ -0,0 +1,39 @@
+package utc
+
+import (
+	"time"
+)
+
+// Key is a small comparable representation of a UTC instant that is safe to use with Go's == operator and as map key.
+// Unlike UTC, it does not carry a monotonic clock reading or location, hence two keys are equal if and only if they
+// represent the same instant.
+type Key struct {
+	Sec  int64 // seconds since 1970-01-01T00:00:00Z
+	Nsec int32 // nanoseconds within the second, in the range [0, 999999999]
+}
+
+// Key returns the comparable Key of this instant.
+func (u UTC) Key() Key {
+	return Key{Sec: u.Unix(), Nsec: int32(u.Nanosecond())}
+}
+
+// UTC converts the key back to a UTC instant (without monotonic clock reading).
+func (k Key) UTC() UTC {
+	return New(time.Unix(k.Sec, int64(k.Nsec)))
+}
+
+// String returns the key's instant formatted like UTC.String().
+func (k Key) String() string {
+	return k.UTC().String()
+}
+
+// Compare compares the instants of the two keys and returns -1, 0 or +1 if k is before, equal to or after other.
+func (k Key) Compare(other Key) int {
+	switch {
+	case k.Sec < other.Sec || k.Sec == other.Sec && k.Nsec < other.Nsec:
+		return -1
+	case k == other:
+		return 0
+	}
+	return 1
+}
@@ -0,0 +1,42 @@
+package utc_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+func TestKey(t *testing.T) {
+	now := utc.Now()
+	wall := now.StripMono()
+	zoned := utc.New(now.Time.In(time.FixedZone("X", 3600)))
+
+	// the UTC instances are not == but their keys are
+	require.NotEqual(t, now, wall)
+	require.Equal(t, now.Key(), wall.Key())
+	require.Equal(t, now.Key(), zoned.Key())
+
+	set := map[utc.Key]int{}
+	for _, u := range []utc.UTC{now, wall, zoned, now.Add(time.Nanosecond)} {
+		set[u.Key()]++
+	}
+	require.Len(t, set, 2)
+	require.Equal(t, 3, set[now.Key()])
+
+	testFnOneDate(t, func(t *testing.T, date utc.UTC) {
+		require.True(t, date.Equal(date.Key().UTC()))
+		require.Equal(t, date.String(), date.Key().String())
+	})
+}
+
+func TestKey_Compare(t *testing.T) {
+	testFnTwoDates(t, func(t *testing.T, date1, date2 utc.UTC) {
+		require.Equal(t, date1.Compare(date2.Time), date1.Key().Compare(date2.Key()))
+		require.Equal(t, 0, date1.Key().Compare(date1.Key()))
+	})
+	neg := utc.MustParse("1969-12-31T23:59:59.5Z")
+	require.Equal(t, -1, neg.Key().Compare(neg.Add(time.Millisecond).Key()))
+}
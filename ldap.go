@@ -0,0 +1,137 @@
+package utc
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/eluv-io/errors-go"
+)
+
+// ParseGeneralizedTime parses an LDAP GeneralizedTime value as defined in RFC 4517, section 3.3.13, e.g.
+// "20210909014640Z", "20210909014640.0Z" (Active Directory), "202109090146Z" or "2021090901.5-0700". Minutes and
+// seconds are optional and the fraction (with '.' or ',' as decimal mark) applies to the last given unit: hours,
+// minutes or seconds. The timezone is either Z or an offset ±hh[mm]. Values without timezone (local time) are
+// interpreted as UTC. Leap seconds (seconds set to 60) are handled according to the current LeapSecondPolicy like in
+// FromString.
+func ParseGeneralizedTime(s string) (UTC, error) {
+	e := errors.Template("ParseGeneralizedTime", errors.K.Invalid, ErrParse, "value", s)
+
+	pos := 0
+	digits := func(n int) (int, bool) {
+		if pos+n > len(s) {
+			return 0, false
+		}
+		v := 0
+		for i := pos; i < pos+n; i++ {
+			c := s[i]
+			if c < '0' || c > '9' {
+				return 0, false
+			}
+			v = v*10 + int(c-'0')
+		}
+		pos += n
+		return v, true
+	}
+
+	var year, month, day, hour, min, sec int
+	var ok bool
+	if year, ok = digits(4); !ok {
+		return Zero, e("reason", "invalid year")
+	}
+	if month, ok = digits(2); !ok || month < 1 || month > 12 {
+		return Zero, e("reason", "invalid month")
+	}
+	if day, ok = digits(2); !ok || day < 1 || day > DaysInMonth(year, time.Month(month)) {
+		return Zero, e("reason", "invalid day")
+	}
+	if hour, ok = digits(2); !ok || hour > 23 {
+		return Zero, e("reason", "invalid hour")
+	}
+
+	unit := time.Hour // the unit the fraction applies to
+	if min, ok = digits(2); ok {
+		if min > 59 {
+			return Zero, e("reason", "invalid minute")
+		}
+		unit = time.Minute
+		if sec, ok = digits(2); ok {
+			if sec > 60 {
+				return Zero, e("reason", "invalid second")
+			}
+			unit = time.Second
+		}
+	}
+
+	var frac time.Duration
+	if pos < len(s) && (s[pos] == '.' || s[pos] == ',') {
+		pos++
+		start := pos
+		for pos < len(s) && s[pos] >= '0' && s[pos] <= '9' {
+			pos++
+		}
+		if pos == start || pos-start > 18 {
+			return Zero, e("reason", "invalid fraction")
+		}
+		f, err := strconv.ParseFloat("0."+s[start:pos], 64)
+		if err != nil {
+			return Zero, e(WrapSentinel(ErrParse, err), "reason", "invalid fraction")
+		}
+		frac = time.Duration(f * float64(unit))
+		if unit == time.Second {
+			// avoid floating point rounding for the common case
+			n, _ := strconv.ParseInt((s[start:pos] + "000000000")[:9], 10, 64)
+			frac = time.Duration(n)
+		}
+	}
+
+	loc := time.UTC
+	switch {
+	case pos == len(s):
+		// local time without timezone: interpreted as UTC
+	case s[pos] == 'Z' && pos+1 == len(s):
+	case s[pos] == '+' || s[pos] == '-':
+		sign := 1
+		if s[pos] == '-' {
+			sign = -1
+		}
+		pos++
+		offH, okH := digits(2)
+		offM := 0
+		if okH && pos < len(s) {
+			offM, okH = digits(2)
+		}
+		if !okH || pos != len(s) || offH > 23 || offM > 59 {
+			return Zero, e("reason", "invalid timezone")
+		}
+		loc = time.FixedZone("", sign*(offH*3600+offM*60))
+	default:
+		return Zero, e("reason", "invalid timezone")
+	}
+
+	leap := sec == 60
+	if leap {
+		sec = 59
+	}
+	u := New(time.Date(year, time.Month(month), day, hour, min, sec, 0, loc).Add(frac))
+	if leap {
+		// like FromString: apply the current leap second policy
+		var err error
+		if u, err = applyLeapSecondPolicy(u); err != nil {
+			return Zero, e(err)
+		}
+		return u, nil
+	}
+	return smearPrecedingSecond(u), nil
+}
+
+// FormatGeneralizedTime formats this UTC as LDAP GeneralizedTime "20060102150405Z", with a fraction of seconds
+// without trailing zeros if the time is not on a full second: "20060102150405.123Z".
+func (u UTC) FormatGeneralizedTime() string {
+	return u.Time.Format("20060102150405.999999999Z")
+}
+
+// FormatGeneralizedTimeAD formats this UTC as GeneralizedTime in the format used by Active Directory:
+// "20060102150405.0Z". Fractions of seconds are truncated.
+func (u UTC) FormatGeneralizedTimeAD() string {
+	return u.Time.Format("20060102150405") + ".0Z"
+}
@@ -0,0 +1,103 @@
+package utc_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+func TestParseGeneralizedTime(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{"20210909014640Z", "2021-09-09T01:46:40Z"},
+		{"20210909014640.0Z", "2021-09-09T01:46:40Z"},
+		{"20210909014640,25Z", "2021-09-09T01:46:40.25Z"},
+		{"20210909014640.123456789Z", "2021-09-09T01:46:40.123456789Z"},
+		{"202109090146Z", "2021-09-09T01:46:00Z"},
+		{"202109090146.5Z", "2021-09-09T01:46:30Z"},
+		{"2021090901Z", "2021-09-09T01:00:00Z"},
+		{"2021090901.25Z", "2021-09-09T01:15:00Z"},
+		{"20210909014640+0200", "2021-09-08T23:46:40Z"},
+		{"20210909014640.5-07", "2021-09-09T08:46:40.5Z"},
+		{"20210909014640-0730", "2021-09-09T09:16:40Z"},
+		{"20210909014640", "2021-09-09T01:46:40Z"},
+		{"20161231235960Z", "2016-12-31T23:59:59.999999999Z"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			u, err := utc.ParseGeneralizedTime(tt.s)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, u.Format(time.RFC3339Nano))
+		})
+	}
+
+	for _, s := range []string{
+		"",
+		"2021",
+		"2021090",
+		"20211309014640Z",
+		"20210931014640Z",
+		"20210909244640Z",
+		"20210909016040Z",
+		"20210909014661Z",
+		"20210909014640.Z",
+		"20210909014640ZZ",
+		"20210909014640+2",
+		"20210909014640+02:00",
+		"2021-09-09T01:46:40Z",
+	} {
+		_, err := utc.ParseGeneralizedTime(s)
+		require.Error(t, err, s)
+	}
+}
+
+func TestFormatGeneralizedTime(t *testing.T) {
+	u := utc.MustParse("2021-09-09T01:46:40Z")
+	require.Equal(t, "20210909014640Z", u.FormatGeneralizedTime())
+	require.Equal(t, "20210909014640.0Z", u.FormatGeneralizedTimeAD())
+
+	u = utc.MustParse("2021-09-09T01:46:40.120Z")
+	require.Equal(t, "20210909014640.12Z", u.FormatGeneralizedTime())
+	require.Equal(t, "20210909014640.0Z", u.FormatGeneralizedTimeAD())
+
+	for _, s := range []string{u.FormatGeneralizedTime(), u.FormatGeneralizedTimeAD()} {
+		res, err := utc.ParseGeneralizedTime(s)
+		require.NoError(t, err)
+		require.Equal(t, u.Truncate(time.Second), res.Truncate(time.Second))
+	}
+}
+
+func TestParseGeneralizedTime_leapSecond(t *testing.T) {
+	defer utc.SetLeapSecondPolicy(utc.GetLeapSecondPolicy())
+
+	tests := []struct {
+		s      string
+		policy utc.LeapSecondPolicy
+		want   string
+	}{
+		{"20161231235960Z", utc.LeapSecondClamp, "2016-12-31T23:59:59.999999999Z"},
+		{"20170101005960.5+0100", utc.LeapSecondClamp, "2016-12-31T23:59:59.999999999Z"},
+		{"20161231235959.5Z", utc.LeapSecondSmear, "2016-12-31T23:59:59.25Z"},
+		{"20161231235960.5Z", utc.LeapSecondSmear, "2016-12-31T23:59:59.75Z"},
+		{"20161231235960.25Z", utc.LeapSecondRollover, "2017-01-01T00:00:00.25Z"},
+	}
+	for _, tt := range tests {
+		utc.SetLeapSecondPolicy(tt.policy)
+		u, err := utc.ParseGeneralizedTime(tt.s)
+		require.NoError(t, err, tt.s)
+		require.Equal(t, tt.want, u.Format(time.RFC3339Nano), tt.s)
+	}
+
+	utc.SetLeapSecondPolicy(utc.LeapSecondClamp)
+	_, err := utc.ParseGeneralizedTime("20210909014660Z")
+	require.ErrorIs(t, err, utc.ErrParse)
+
+	utc.SetLeapSecondPolicy(utc.LeapSecondReject)
+	_, err = utc.ParseGeneralizedTime("20161231235960Z")
+	require.ErrorIs(t, err, utc.ErrParse)
+}
@@ -0,0 +1,104 @@
+package utc
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/eluv-io/errors-go"
+)
+
+// LeapSecondPolicy defines how FromString handles timestamps with a leap second, i.e. with the seconds field set to 60
+// like in "2016-12-31T23:59:60.500Z". Since UTC (like time.Time) cannot represent the leap second, such timestamps are
+// mapped to an instant close to the leap second.
+type LeapSecondPolicy int32
+
+const (
+	// LeapSecondClamp maps the leap second to the last nanosecond before the leap second: 23:59:59.999999999
+	LeapSecondClamp LeapSecondPolicy = iota
+	// LeapSecondSmear smears the leap second and the preceding second linearly over the preceding second, so that
+	// parsed timestamps keep their order: 23:59:59.000 stays 23:59:59.000, 23:59:59.500 becomes 23:59:59.250,
+	// 23:59:60.000 becomes 23:59:59.500 and 23:59:60.500 becomes 23:59:59.750. Timestamps of the preceding second are
+	// only smeared on days that end with a leap second according to the current LeapSecondTable - see LeapSeconds.
+	LeapSecondSmear
+	// LeapSecondRollover maps the leap second to the first second of the following day: 23:59:60.500 becomes
+	// 00:00:00.500
+	LeapSecondRollover
+	// LeapSecondReject rejects timestamps with a leap second with a parse error.
+	LeapSecondReject
+)
+
+var leapSecondPolicy atomic.Int32
+
+// SetLeapSecondPolicy sets the policy used by FromString (and therefore MustParse, UnmarshalText and UnmarshalJSON),
+// ParseZoned and ParseGeneralizedTime for timestamps with a leap second. The default is LeapSecondClamp.
+func SetLeapSecondPolicy(p LeapSecondPolicy) {
+	leapSecondPolicy.Store(int32(p))
+}
+
+// GetLeapSecondPolicy returns the current leap second policy.
+func GetLeapSecondPolicy() LeapSecondPolicy {
+	return LeapSecondPolicy(leapSecondPolicy.Load())
+}
+
+// parseLeapSecond parses the given string if it has the seconds field set to 60 and applies the current leap second
+// policy. It returns the resulting instant and the timezone offset of the string in seconds east of UTC, or false if
+// the string does not contain a leap second.
+func parseLeapSecond(s string) (UTC, int, bool, error) {
+	// 2006-01-02T15:04:60...
+	if len(s) < 19 || s[10] != 'T' || s[16] != ':' || s[17:19] != "60" {
+		return Zero, 0, false, nil
+	}
+	e := errors.Template("parse", errors.K.Invalid, ErrParse, "utc", s)
+
+	if GetLeapSecondPolicy() == LeapSecondReject {
+		return Zero, 0, true, e("reason", "leap second rejected")
+	}
+	t, err := parseTime(s[:17] + "59" + s[19:])
+	if err != nil {
+		return Zero, 0, true, e(WrapSentinel(ErrParse, err))
+	}
+	_, offset := t.Zone()
+	u, err := applyLeapSecondPolicy(New(t))
+	if err != nil {
+		return Zero, 0, true, e(err)
+	}
+	return u, offset, true, nil
+}
+
+// smearPrecedingSecond applies the LeapSecondSmear policy to the given instant, parsed from a timestamp without leap
+// second: if the current policy is LeapSecondSmear and the instant lies in the second before a leap second of the
+// current LeapSecondTable, it is mapped linearly into the first half of that second. Otherwise, it is returned as is.
+func smearPrecedingSecond(u UTC) UTC {
+	if GetLeapSecondPolicy() != LeapSecondSmear || u.Second() != 59 || u.Minute() != 59 || u.Hour() != 23 {
+		return u
+	}
+	sec := u.Truncate(time.Second)
+	if !LeapSeconds().insertsLeapSecond(sec.Add(time.Second)) {
+		return u
+	}
+	return sec.Add(time.Duration(u.Nanosecond()) / 2)
+}
+
+// applyLeapSecondPolicy applies the current leap second policy to the given instant of a leap second, parsed with the
+// seconds field set to 59 instead of 60.
+func applyLeapSecondPolicy(u UTC) (UTC, error) {
+	e := errors.Template("applyLeapSecondPolicy", errors.K.Invalid, ErrParse)
+
+	policy := GetLeapSecondPolicy()
+	if policy == LeapSecondReject {
+		return Zero, e("reason", "leap second rejected")
+	}
+	if u.Hour() != 23 || u.Minute() != 59 {
+		return Zero, e("reason", "leap second not at end of UTC day")
+	}
+
+	sec := u.Truncate(time.Second)
+	switch policy {
+	case LeapSecondSmear:
+		return sec.Add(time.Second/2 + time.Duration(u.Nanosecond())/2), nil
+	case LeapSecondRollover:
+		return u.Add(time.Second), nil
+	default:
+		return sec.Add(time.Second - 1), nil
+	}
+}
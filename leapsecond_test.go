@@ -0,0 +1,99 @@
+package utc_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+func TestFromString_leapSecond(t *testing.T) {
+	defer utc.SetLeapSecondPolicy(utc.GetLeapSecondPolicy())
+
+	tests := []struct {
+		s      string
+		policy utc.LeapSecondPolicy
+		want   string
+	}{
+		{"2016-12-31T23:59:60Z", utc.LeapSecondClamp, "2016-12-31T23:59:59.999999999Z"},
+		{"2016-12-31T23:59:60.500Z", utc.LeapSecondClamp, "2016-12-31T23:59:59.999999999Z"},
+		{"2017-01-01T00:59:60.500+01:00", utc.LeapSecondClamp, "2016-12-31T23:59:59.999999999Z"},
+		{"2016-12-31T23:59:60Z", utc.LeapSecondSmear, "2016-12-31T23:59:59.5Z"},
+		{"2016-12-31T23:59:60.500Z", utc.LeapSecondSmear, "2016-12-31T23:59:59.75Z"},
+		{"2016-12-31T23:59:60", utc.LeapSecondRollover, "2017-01-01T00:00:00Z"},
+		{"2016-12-31T23:59:60.250Z", utc.LeapSecondRollover, "2017-01-01T00:00:00.25Z"},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprint(test.s, "_", test.policy), func(t *testing.T) {
+			utc.SetLeapSecondPolicy(test.policy)
+			u, err := utc.FromString(test.s)
+			require.NoError(t, err)
+			require.Equal(t, test.want, u.Format(time.RFC3339Nano))
+		})
+	}
+
+	utc.SetLeapSecondPolicy(utc.LeapSecondClamp)
+	for _, s := range []string{
+		"2016-12-31T23:58:60Z",
+		"2016-12-31T22:59:60Z",
+		"2016-12-31T23:59:60+01:00",
+		"2016-12-31T23:59:61Z",
+		"2016-12-31T23:59:60x",
+	} {
+		_, err := utc.FromString(s)
+		require.Error(t, err, s)
+	}
+
+	utc.SetLeapSecondPolicy(utc.LeapSecondReject)
+	_, err := utc.FromString("2016-12-31T23:59:60Z")
+	require.Error(t, err)
+}
+
+func TestFromString_leapSecondSmearOrder(t *testing.T) {
+	defer utc.SetLeapSecondPolicy(utc.GetLeapSecondPolicy())
+	utc.SetLeapSecondPolicy(utc.LeapSecondSmear)
+
+	// both seconds are smeared over the second before the leap second
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{"2016-12-31T23:59:58.900Z", "2016-12-31T23:59:58.9Z"},
+		{"2016-12-31T23:59:59Z", "2016-12-31T23:59:59Z"},
+		{"2016-12-31T23:59:59.500Z", "2016-12-31T23:59:59.25Z"},
+		{"2017-01-01T00:59:59.800+01:00", "2016-12-31T23:59:59.4Z"},
+		{"2016-12-31T23:59:60Z", "2016-12-31T23:59:59.5Z"},
+		{"2016-12-31T23:59:60.500Z", "2016-12-31T23:59:59.75Z"},
+		{"2017-01-01T00:00:00Z", "2017-01-01T00:00:00Z"},
+		// no leap second at the end of this day
+		{"2017-12-31T23:59:59.500Z", "2017-12-31T23:59:59.5Z"},
+	}
+	for _, test := range tests {
+		u, err := utc.FromString(test.s)
+		require.NoError(t, err, test.s)
+		require.Equal(t, test.want, u.Format(time.RFC3339Nano), test.s)
+	}
+
+	// the order of timestamps is preserved across both seconds
+	var prev utc.UTC
+	for _, s := range []string{
+		"2016-12-31T23:59:59.000Z",
+		"2016-12-31T23:59:59.800Z",
+		"2016-12-31T23:59:59.999999999Z",
+		"2016-12-31T23:59:60.000Z",
+		"2016-12-31T23:59:60.100Z",
+		"2016-12-31T23:59:60.999999999Z",
+		"2017-01-01T00:00:00.000Z",
+	} {
+		u := utc.MustParse(s)
+		require.True(t, prev.Before(u), s)
+		prev = u
+	}
+
+	// the preceding second is not smeared with other policies
+	utc.SetLeapSecondPolicy(utc.LeapSecondClamp)
+	require.Equal(t, "2016-12-31T23:59:59.800Z", utc.MustParse("2016-12-31T23:59:59.800Z").String())
+}
@@ -0,0 +1,81 @@
+package utc
+
+import (
+	"time"
+
+	"github.com/eluv-io/errors-go"
+)
+
+// Lease is a time-limited claim, e.g. of a distributed lock, that expires unless it is renewed within its TTL. All
+// computations take the current time from a Clock, or from utc.Now() if the clock is nil. A Lease marshals to JSON:
+//
+//	{"acquired":"2020-01-01T00:00:00.000Z","renewed":"2020-01-01T00:00:10.000Z","ttl":"30s"}
+//
+// and can therefore be persisted, e.g. in the record representing the lock. The zero value is an expired lease.
+type Lease struct {
+	Acquired UTC           `json:"acquired"` // the time the lease was acquired
+	Renewed  UTC           `json:"renewed"`  // the time the lease was last renewed or acquired
+	TTL      DurationValue `json:"ttl"`      // the time-to-live after acquisition or renewal
+}
+
+// AcquireLease returns a new lease acquired at the current time of the given clock with the given TTL.
+func AcquireLease(ttl time.Duration, clock Clock) Lease {
+	now := clockNow(clock)
+	return Lease{Acquired: now, Renewed: now, TTL: NewDurationValue(ttl)}
+}
+
+// Renew renews the lease at the current time of the given clock. It returns an error and leaves the lease unchanged
+// if the lease has already expired - an expired lease has to be acquired again.
+func (l *Lease) Renew(clock Clock) error {
+	now := clockNow(clock)
+	if l.expiredAt(now) {
+		return errors.E("Lease.Renew", errors.K.Invalid,
+			"reason", "lease expired",
+			"expires", l.Expires(),
+			"now", now)
+	}
+	l.Renewed = now
+	return nil
+}
+
+// Expires returns the time the lease expires unless renewed.
+func (l Lease) Expires() UTC {
+	return l.Renewed.Add(l.TTL.Duration)
+}
+
+// Expired returns true if the lease has expired at the current time of the given clock.
+func (l Lease) Expired(clock Clock) bool {
+	return l.expiredAt(clockNow(clock))
+}
+
+func (l Lease) expiredAt(now UTC) bool {
+	return l.Renewed.IsZero() || !now.Before(l.Expires())
+}
+
+// Remaining returns the time until the lease expires at the current time of the given clock, or 0 if it has expired.
+func (l Lease) Remaining(clock Clock) time.Duration {
+	now := clockNow(clock)
+	if l.expiredAt(now) {
+		return 0
+	}
+	return l.Expires().Sub(now)
+}
+
+// RemainingFraction returns the remaining fraction of the TTL at the current time of the given clock: 1 right after
+// acquisition or renewal, 0 when expired. It is useful for renewing at a fixed fraction of the TTL, e.g. when the
+// remaining fraction drops below 0.5.
+func (l Lease) RemainingFraction(clock Clock) float64 {
+	if l.TTL.Duration <= 0 {
+		return 0
+	}
+	f := float64(l.Remaining(clock)) / float64(l.TTL.Duration)
+	if f > 1 {
+		return 1
+	}
+	return f
+}
+
+// Held returns the duration the lease has been held since its acquisition at the current time of the given clock.
+func (l Lease) Held(clock Clock) time.Duration {
+	return clockNow(clock).Sub(l.Acquired)
+}
@@ -0,0 +1,68 @@
+package utc_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+func TestLease(t *testing.T) {
+	now := utc.MustParse("2020-01-01T00:00:00Z")
+	clock := utc.NewWallClock(now)
+
+	l := utc.AcquireLease(30*time.Second, clock)
+	require.False(t, l.Expired(clock))
+	require.Equal(t, now.Add(30*time.Second), l.Expires())
+	require.Equal(t, 30*time.Second, l.Remaining(clock))
+	require.Equal(t, 1.0, l.RemainingFraction(clock))
+
+	clock.Add(20 * time.Second)
+	require.InDelta(t, 1.0/3, l.RemainingFraction(clock), 1e-9)
+	require.Equal(t, 10*time.Second, l.Remaining(clock))
+
+	require.NoError(t, l.Renew(clock))
+	require.Equal(t, now.Add(50*time.Second), l.Expires())
+	require.Equal(t, now, l.Acquired)
+	require.Equal(t, 20*time.Second, l.Held(clock))
+
+	clock.Add(30 * time.Second)
+	require.True(t, l.Expired(clock))
+	require.Equal(t, time.Duration(0), l.Remaining(clock))
+	require.Equal(t, 0.0, l.RemainingFraction(clock))
+	require.Error(t, l.Renew(clock))
+	require.Equal(t, now.Add(20*time.Second), l.Renewed)
+
+	require.True(t, utc.Lease{}.Expired(clock))
+	require.Error(t, (&utc.Lease{}).Renew(clock))
+}
+
+func TestLease_JSON(t *testing.T) {
+	now := utc.MustParse("2020-01-01T00:00:00Z")
+	clock := utc.NewWallClock(now)
+	l := utc.AcquireLease(30*time.Second, clock)
+	clock.Add(10 * time.Second)
+	require.NoError(t, l.Renew(clock))
+
+	jsn, err := json.Marshal(l)
+	require.NoError(t, err)
+	require.Equal(t, `{"acquired":"2020-01-01T00:00:00.000Z","renewed":"2020-01-01T00:00:10.000Z","ttl":"30s"}`, string(jsn))
+
+	var res utc.Lease
+	require.NoError(t, json.Unmarshal(jsn, &res))
+	require.Equal(t, l.Expires(), res.Expires())
+	clock.Add(15 * time.Second)
+	require.Equal(t, 0.5, res.RemainingFraction(clock))
+}
+
+func TestLease_globalClock(t *testing.T) {
+	clock := utc.NewWallClock(utc.MustParse("2020-01-01T00:00:00Z")).MockNow()
+	defer clock.UnmockNow()
+
+	l := utc.AcquireLease(time.Minute, nil)
+	clock.Add(time.Minute)
+	require.True(t, l.Expired(nil))
+}
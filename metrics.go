@@ -0,0 +1,53 @@
+package utc
+
+import (
+	"sync/atomic"
+)
+
+// Metrics are usage counters of the clock functions of this package. The counters are cumulative since the start of
+// the process or the last call to ResetMetrics.
+type Metrics struct {
+	NowCalls uint64 `json:"now_calls"` // calls to Now() and Domain.Now() - only counted while metrics are enabled
+	Timers   uint64 `json:"timers"`    // timers created with AfterFunc, TestClock.AfterFunc and Domain.AfterFunc
+	Mocks    uint64 `json:"mocks"`     // clocks installed as global clock, see OnMock
+	Unmocks  uint64 `json:"unmocks"`   // clocks removed as global clock, see OnUnmock
+}
+
+var (
+	metricsEnabled atomic.Bool
+	metrics        struct {
+		now, timers, mocks, unmocks atomic.Uint64
+	}
+)
+
+// EnableMetrics enables or disables counting of Now() calls, which are not counted by default in order to keep Now()
+// as cheap as possible. All other counters are always maintained.
+func EnableMetrics(enable bool) {
+	metricsEnabled.Store(enable)
+}
+
+// GetMetrics returns a snapshot of the current usage counters. Use it to find hot paths that hammer the clock or to
+// verify that production code never installs a mock clock, e.g. by exporting the values as Prometheus counters.
+func GetMetrics() Metrics {
+	return Metrics{
+		NowCalls: metrics.now.Load(),
+		Timers:   metrics.timers.Load(),
+		Mocks:    metrics.mocks.Load(),
+		Unmocks:  metrics.unmocks.Load(),
+	}
+}
+
+// ResetMetrics resets all usage counters to zero.
+func ResetMetrics() {
+	metrics.now.Store(0)
+	metrics.timers.Store(0)
+	metrics.mocks.Store(0)
+	metrics.unmocks.Store(0)
+}
+
+// countNow counts a call to Now if metrics are enabled.
+func countNow() {
+	if metricsEnabled.Load() {
+		metrics.now.Add(1)
+	}
+}
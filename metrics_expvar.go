@@ -0,0 +1,18 @@
+//go:build !tinygo && !utc_noexpvar
+
+package utc
+
+import (
+	"expvar"
+)
+
+// MetricsVar returns an expvar.Var that reports the current usage counters as JSON object. Publish it with
+// expvar.Publish("utc", utc.MetricsVar()).
+//
+// MetricsVar is not available in TinyGo builds or with the build tag "utc_noexpvar", since the expvar package pulls in
+// net/http - e.g. for WebAssembly targets.
+func MetricsVar() expvar.Var {
+	return expvar.Func(func() interface{} {
+		return GetMetrics()
+	})
+}
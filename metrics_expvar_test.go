@@ -0,0 +1,26 @@
+//go:build !tinygo && !utc_noexpvar
+
+package utc_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+func TestMetricsVar(t *testing.T) {
+	utc.ResetMetrics()
+	defer utc.ResetMetrics()
+
+	utc.AfterFunc(utc.Now().Add(time.Hour), func() {})()
+	m := utc.GetMetrics()
+
+	var res utc.Metrics
+	require.NoError(t, json.Unmarshal([]byte(utc.MetricsVar().String()), &res))
+	require.Equal(t, uint64(1), res.Timers)
+	require.Equal(t, m, res)
+}
@@ -0,0 +1,40 @@
+package utc_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+func TestMetrics(t *testing.T) {
+	utc.ResetMetrics()
+	require.Equal(t, utc.Metrics{}, utc.GetMetrics())
+
+	// Now() is not counted by default
+	utc.Now()
+	require.Equal(t, uint64(0), utc.GetMetrics().NowCalls)
+
+	utc.EnableMetrics(true)
+	defer utc.EnableMetrics(false)
+	utc.Now()
+	utc.Now()
+	utc.NewDomain("d").Now()
+	require.Equal(t, uint64(3), utc.GetMetrics().NowCalls)
+
+	clock := utc.NewWallClock(utc.MustParse("2020-01-01T00:00:00Z")).MockNow()
+	clock.AfterFunc(clock.Now().Add(time.Hour), func() {})
+	utc.AfterFunc(clock.Now().Add(time.Hour), func() {})
+	clock.UnmockNow()
+	utc.AfterFunc(utc.Now().Add(time.Hour), func() {})()
+
+	m := utc.GetMetrics()
+	require.Equal(t, uint64(3), m.Timers)
+	require.Equal(t, uint64(1), m.Mocks)
+	require.Equal(t, uint64(1), m.Unmocks)
+
+	utc.ResetMetrics()
+	require.Equal(t, utc.Metrics{}, utc.GetMetrics())
+}
@@ -1,5 +1,11 @@
 package utc
 
+import (
+	"fmt"
+	"os"
+	"sync/atomic"
+)
+
 // MockNowFn allows to replace the Now func variable with a mock function and returns a function to restore the default
 // Now() implementation.
 //
@@ -42,3 +48,39 @@ func MockNow(time UTC) (restore func()) {
 func MockNowClock(clock TestClock) {
 	clock.MockNow()
 }
+
+// suiteClock is the clock installed by RunWithClock for the duration of a test binary.
+var suiteClock atomic.Pointer[TestClock]
+
+// RunWithClock installs the given clock as global clock for an entire test binary, runs the tests and returns the
+// exit code. It is meant to be called from TestMain:
+//
+//	func TestMain(m *testing.M) {
+//		os.Exit(utc.RunWithClock(m, utc.NewWallClock(utc.MustParse("2020-01-01"))))
+//	}
+//
+// While the tests are running, ResetNow() and TestClock.UnmockNow() restore the suite clock instead of the default
+// implementation of Now(). If a different clock is still installed after the tests have finished, i.e. a test mocked
+// Now() without resetting it, the leaked mock is reported on stderr and a non-zero exit code is returned.
+func RunWithClock(m interface{ Run() int }, clock TestClock) int {
+	suiteClock.Store(&clock)
+	clock.MockNow()
+
+	code := m.Run()
+
+	current := atomicClock.Load()
+	suiteClock.Store(nil)
+	ResetNow()
+
+	if current == nil || !clock.is(current.c) {
+		var leaked Clock
+		if current != nil {
+			leaked = current.c
+		}
+		_, _ = fmt.Fprintf(os.Stderr, "utc: leaked mock clock detected after running tests: %T\n", leaked)
+		if code == 0 {
+			code = 1
+		}
+	}
+	return code
+}
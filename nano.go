@@ -0,0 +1,70 @@
+package utc
+
+// iso8601NanoTemplate is the template for the fixed-size ISO 8601 format produced by appendISO8601Nano.
+const iso8601NanoTemplate = "0000-00-00T00:00:00.000000000Z"
+
+// UTCNano is a UTC that marshals to and from text and JSON with fixed nanoseconds instead of milliseconds:
+// 2006-01-02T15:04:05.000000000Z - see ISO8601Nano. It allows to round-trip values without losing sub-millisecond
+// precision, e.g. trace timestamps that are passed through. The zero value marshals to the empty representation like
+// UTC. Unmarshaling is the same as for UTC, which accepts any number of fractional digits.
+type UTCNano struct {
+	UTC
+}
+
+// Nano returns this UTC as UTCNano.
+func (u UTC) Nano() UTCNano {
+	return UTCNano{UTC: u}
+}
+
+// String returns the time formatted in ISO 8601 format with nanoseconds: 2006-01-02T15:04:05.000000000Z
+func (u UTCNano) String() string {
+	var buf [len(iso8601NanoTemplate)]byte
+	return string(u.appendISO8601Nano(buf[:0]))
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (u UTCNano) MarshalJSON() ([]byte, error) {
+	if u.IsZero() {
+		return []byte(`""`), nil
+	}
+	b, err := u.AppendText(append(make([]byte, 0, len(iso8601NanoTemplate)+2), '"'))
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '"'), nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (u UTCNano) MarshalText() ([]byte, error) {
+	if u.IsZero() {
+		return nil, nil
+	}
+	return u.AppendText(make([]byte, 0, len(iso8601NanoTemplate)))
+}
+
+// AppendText implements the encoding.TextAppender interface.
+func (u UTCNano) AppendText(b []byte) ([]byte, error) {
+	if u.IsZero() {
+		return b, nil
+	}
+	if err := u.ValidateISO8601(); err != nil {
+		return nil, err
+	}
+	return u.appendISO8601Nano(b), nil
+}
+
+// appendISO8601Nano appends the time formatted in ISO 8601 format 2006-01-02T15:04:05.000000000Z to b. Years outside
+// of [0000, 9999] are clamped.
+func (u UTC) appendISO8601Nano(b []byte) []byte {
+	b = u.appendISO8601(b)
+	b = b[:len(b)-1] // strip the 'Z'
+
+	var s [7]byte
+	s[6] = 'Z'
+	nanos := u.Nanosecond() % 1000000
+	for i := 5; i >= 0; i-- {
+		s[i] = byte('0' + nanos%10)
+		nanos /= 10
+	}
+	return append(b, s[:]...)
+}
@@ -0,0 +1,64 @@
+package utc_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+func TestUTCNano(t *testing.T) {
+	u := utc.MustParse("2020-05-17T10:11:12.123456789Z")
+	n := u.Nano()
+
+	require.Equal(t, "2020-05-17T10:11:12.123456789Z", n.String())
+	require.Equal(t, u.Time.Format(utc.ISO8601Nano), n.String())
+	require.Equal(t, "2020-05-17T10:11:12.123Z", u.String())
+
+	jsn, err := json.Marshal(n)
+	require.NoError(t, err)
+	require.Equal(t, `"2020-05-17T10:11:12.123456789Z"`, string(jsn))
+
+	var res utc.UTCNano
+	require.NoError(t, json.Unmarshal(jsn, &res))
+	require.True(t, u.Equal(res.UTC))
+
+	txt, err := n.MarshalText()
+	require.NoError(t, err)
+	require.Equal(t, "2020-05-17T10:11:12.123456789Z", string(txt))
+	require.NoError(t, res.UnmarshalText(txt))
+	require.True(t, u.Equal(res.UTC))
+
+	b, err := n.AppendText([]byte("t="))
+	require.NoError(t, err)
+	require.Equal(t, "t=2020-05-17T10:11:12.123456789Z", string(b))
+
+	// leading zeros
+	require.Equal(t, "2020-05-17T10:11:12.000000001Z", u.Truncate(time.Second).Add(1).Nano().String())
+	require.Equal(t, "2020-05-17T10:11:12.000000000Z", u.Truncate(time.Second).Nano().String())
+
+	// zero value
+	jsn, err = json.Marshal(utc.UTCNano{})
+	require.NoError(t, err)
+	require.Equal(t, `""`, string(jsn))
+	txt, err = utc.UTCNano{}.MarshalText()
+	require.NoError(t, err)
+	require.Empty(t, txt)
+
+	// out of range
+	_, err = json.Marshal(utc.Max.Add(time.Hour).Nano())
+	require.Error(t, err)
+
+	// struct field round trip
+	type trace struct {
+		Start utc.UTCNano `json:"start"`
+	}
+	jsn, err = json.Marshal(trace{Start: n})
+	require.NoError(t, err)
+	var tr trace
+	require.NoError(t, json.Unmarshal(jsn, &tr))
+	require.True(t, u.Equal(tr.Start.UTC))
+}
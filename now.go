@@ -2,15 +2,9 @@ package utc
 
 import (
 	"time"
-)
-
-// nowFn is the function used to get the current time and can be mocked with
-// MockNow, MockNowFn, MockNowClock as described in comments of setClock and allowClock.
-var nowFn func() UTC
 
-func init() {
-	nowFn = now
-}
+	"github.com/eluv-io/errors-go"
+)
 
 // New creates a new UTC instance from the given time. Use utc.Now() to get the
 // current time.
@@ -18,9 +12,42 @@ func New(t time.Time) UTC {
 	return UTC{Time: t.UTC(), mono: t}
 }
 
+// NewWall creates a new UTC instance from the given time like New, but without retaining the original time instance:
+// any monotonic clock reading is stripped, and the result does not reference the time's location. Use it when
+// constructing large numbers of UTC values from parsed or decoded data, where time measurements are not needed, e.g.
+// the time zone offsets of parsed times are otherwise retained as separately allocated locations. The result is
+// identical to New(t).StripMono(), hence comparable with ==.
+func NewWall(t time.Time) UTC {
+	t = t.UTC()
+	return UTC{Time: t, mono: t}
+}
+
+// NewChecked creates a new UTC instance from the given time like New, but returns an error if the year is outside of
+// [0000, 9999] and the result could therefore not be marshaled. The zero time is accepted and results in Zero - use
+// NewCheckedNonZero to reject it.
+func NewChecked(t time.Time) (UTC, error) {
+	u := New(t)
+	if err := u.ValidateISO8601(); err != nil {
+		return Zero, errors.E("NewChecked", errors.K.Invalid, err)
+	}
+	return u, nil
+}
+
+// NewCheckedNonZero is like NewChecked, but also returns an error for the zero time.
+func NewCheckedNonZero(t time.Time) (UTC, error) {
+	if t.IsZero() {
+		return Zero, errors.E("NewCheckedNonZero", errors.K.Invalid, ErrOutOfRange, "reason", "zero time")
+	}
+	return NewChecked(t)
+}
+
 // Now returns the current time as UTC instance. Now can be mocked for tests: see MockNow() function.
 func Now() UTC {
-	return nowFn()
+	countNow()
+	if m := atomicClock.Load(); m != nil {
+		return m.c.Now()
+	}
+	return now()
 }
 
 // WallNow returns Now as a wall clock, i.e. with the monotonic clock reading stripped.
@@ -36,14 +63,54 @@ func WallNowMs() UTC {
 	return WallNow().Round(time.Millisecond)
 }
 
+// WallNowUs returns Now as a wall clock rounded to the microsecond.
+// WallNowUs is equivalent to calling WallNow().Round(time.Microsecond) and useful where UTC instances are serialized
+// with microsecond precision, e.g. in tracing data or databases.
+func WallNowUs() UTC {
+	return WallNow().Round(time.Microsecond)
+}
+
+// WallNowSec returns Now as a wall clock rounded to the second.
+// WallNowSec is equivalent to calling WallNow().Round(time.Second) and useful where UTC instances are stored with
+// second precision, e.g. in MySQL DATETIME columns, so that they compare equal after a round trip.
+func WallNowSec() UTC {
+	return WallNow().Round(time.Second)
+}
+
+// startTime is the time the process started, or more precisely the time this package was initialized.
+var startTime = now()
+
+// StartTime returns the time the process started (more precisely, the time this package was initialized), including
+// the monotonic clock reading. If Now is mocked with a TestClock, StartTime returns the time of the TestClock at the
+// time it was installed instead, so that Uptime behaves consistently with the mocked Now in tests. For other mocks,
+// e.g. with MockNowFn, StartTime returns the time of the mock at the first call of StartTime, so that installing the
+// mock does not consume any of the times it returns.
+func StartTime() UTC {
+	if m := atomicClock.Load(); m != nil {
+		return m.startTime()
+	}
+	return startTime
+}
+
+// Uptime returns the duration since StartTime, computed with the monotonic clock if available. It respects mocks of
+// Now: advancing a mocked TestClock advances the uptime accordingly.
+func Uptime() time.Duration {
+	return Now().Sub(StartTime())
+}
+
 // now is the default, non-mocked value of Now.
 func now() UTC {
 	return New(time.Now())
 }
 
-// ResetNow resets the Now func to the default implementation.
+// ResetNow resets the Now func to the default implementation, or to the suite clock if running within
+// RunWithClock.
 func ResetNow() {
-	setNowFn(now)
+	if c := suiteClock.Load(); c != nil {
+		c.MockNow()
+		return
+	}
+	setClock(nil)
 }
 
 // setNowFn sets the given function as the Clock to use for tests.
@@ -1,62 +1,46 @@
 package utc
 
 import (
-	"reflect"
-	"runtime"
 	"sync"
 	"sync/atomic"
 )
 
 // functions in this file implement use of a Clock to return the current UTC.
 // This is intended for tests and won't happen when running production code.
-// See comments of allowClock.
 
 // clocker wraps a Clock
 type clocker struct {
-	c Clock
+	c         Clock
+	startOnce sync.Once
+	start     UTC // the time of the clock when it was installed, see StartTime
 }
 
-var (
-	// nowFnMu protects access to nowFn when nowFn is set to nowFnClock. This is
-	// intended to be used during tests only.
-	nowFnMu sync.Mutex
-	// atomicClock stores the current Clock
-	atomicClock atomic.Pointer[clocker]
-)
-
-// nowFnClock is the function used to get the current time via a Clock during tests.
-func nowFnClock() UTC {
-	return getClock().Now()
+// newClocker wraps the given clock. The start time of a TestClock is recorded immediately, since its Now method has no
+// side effects. For all other clocks, e.g. functions installed with MockNowFn that return a sequence of times, the
+// start time is recorded lazily on the first call of startTime in order not to consume any of their values.
+func newClocker(c Clock) *clocker {
+	m := &clocker{c: c}
+	if _, ok := c.(TestClock); ok {
+		m.startTime()
+	}
+	return m
 }
 
-// allowClock allows using a custom Clock and is called during test whenever setClock is called.
-//   - in production code nowFn is initialised to function now, is never updated and
-//     therefore does not need any protection against races.
-//   - in test code nowFn is replaced with nowFnClock which will incur a lookup into atomicClock
-//
-// The function minimizes changes to nowFn, hence - although the mutex does not
-// protect read access to nowFn - the probability of race is reduced. An example of
-// such a race will happen whenever a test that does not mock UTC has a goroutine
-// that does not terminate (and keep calling utc.Now) while another test starts and
-// installs a clock to mock UTC (see TestRace in test_clock_test.go). This kind of
-// case clearly results from a faulty test and the -race flag helps in detecting it.
-// On the other hand, a package that would like to prevent any such race could implement
-// an initializer using:
-// //go:build race
-//
-//	func init(){
-//	 utc.ResetNow()
-//	}
-func allowClock() {
-	nowFnMu.Lock()
-	defer nowFnMu.Unlock()
-	if runtime.FuncForPC(reflect.ValueOf(nowFn).Pointer()) == runtime.FuncForPC(reflect.ValueOf(nowFnClock).Pointer()) {
-		//nowFnClock already set
-		return
-	}
-	nowFn = nowFnClock
+// startTime returns the time of the clock when it was installed, or when startTime was first called for clocks other
+// than TestClock - see newClocker.
+func (m *clocker) startTime() UTC {
+	m.startOnce.Do(func() {
+		m.start = m.c.Now()
+	})
+	return m.start
 }
 
+// atomicClock stores the current Clock. It is nil unless a clock was installed with one of the MockNowXyz functions,
+// in which case Now() is delegated to that clock. The same atomic access is used in production code, tests and race
+// builds: there is a single synchronization path, and an atomic load is negligible compared to reading the system
+// clock.
+var atomicClock atomic.Pointer[clocker]
+
 // getClock returns the current Clock stored in atomicClock or the default 'now'
 // function if no clock was stored.
 func getClock() Clock {
@@ -69,21 +53,101 @@ func getClock() Clock {
 }
 
 // setClock sets c to be the current clock. This function is intended to be used
-// in tests only through one of the MockNowXyz functions. It first calls allowClock,
-// then replaces the current clock with c.
+// in tests only through one of the MockNowXyz functions. A nil clock restores the
+// default 'now' function.
 func setClock(c Clock) {
-	allowClock()
-
-	old := getClock()
-	n := &clocker{c: c}
-	atomicClock.Store(n)
+	var n *clocker
+	if c != nil {
+		n = newClocker(c)
+	}
+	old := atomicClock.Swap(n)
 
 	type um interface {
 		unMocked()
 	}
-	if unm, ok := old.(um); ok {
-		// notify the previous clock that it is no more the current 'mock'
-		// unMocked is currently implemented only by TestClock
-		unm.unMocked()
+	if old != nil {
+		if unm, ok := old.c.(um); ok {
+			// notify the previous clock that it is no more the current 'mock'
+			// unMocked is currently implemented only by TestClock
+			unm.unMocked()
+		}
+		metrics.unmocks.Add(1)
+		mockHooks.fire(false, old.c)
+	}
+	if c != nil {
+		metrics.mocks.Add(1)
+		mockHooks.fire(true, c)
+	}
+}
+
+// OnMock registers a function that is called with the new clock whenever a clock becomes the global clock through one
+// of the MockNowXyz functions or TestClock.MockNow(). The function is called synchronously in the goroutine installing
+// the clock. The returned function removes the hook.
+func OnMock(fn func(c Clock)) (remove func()) {
+	return mockHooks.add(true, fn)
+}
+
+// OnUnmock registers a function that is called with the old clock whenever a clock stops being the global clock,
+// either because it was replaced by another clock or because Now was reset with ResetNow() or
+// TestClock.UnmockNow(). The function is called synchronously in the goroutine removing the clock. The returned
+// function removes the hook.
+func OnUnmock(fn func(c Clock)) (remove func()) {
+	return mockHooks.add(false, fn)
+}
+
+var mockHooks = &hooks{}
+
+// hooks manages the OnMock and OnUnmock hooks.
+type hooks struct {
+	mu       sync.Mutex
+	seq      int
+	onMock   []hook
+	onUnmock []hook
+}
+
+type hook struct {
+	id int
+	fn func(c Clock)
+}
+
+func (h *hooks) list(mock bool) *[]hook {
+	if mock {
+		return &h.onMock
+	}
+	return &h.onUnmock
+}
+
+func (h *hooks) add(mock bool, fn func(c Clock)) (remove func()) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.seq++
+	id := h.seq
+	l := h.list(mock)
+	*l = append(*l, hook{id: id, fn: fn})
+
+	return func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		l := h.list(mock)
+		for i, hk := range *l {
+			if hk.id == id {
+				*l = append((*l)[:i:i], (*l)[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// fire calls the registered hooks in registration order. The hooks are called without holding the lock, so they may
+// register or remove hooks and install clocks themselves.
+func (h *hooks) fire(mock bool, c Clock) {
+	h.mu.Lock()
+	l := *h.list(mock)
+	h.mu.Unlock()
+
+	for _, hk := range l {
+		hk.fn(c)
 	}
 }
@@ -0,0 +1,45 @@
+// Package openapiutc describes the types of the utc package correctly in OpenAPI schemas generated with the
+// openapi3gen package of kin-openapi. Without it, utc.UTC is described as object with the members of the embedded
+// time.Time instead of as string in date-time format:
+//
+//	schemaRef, err := openapi3gen.NewSchemaRefForValue(&MyStruct{}, nil, openapiutc.Option())
+package openapiutc
+
+import (
+	"reflect"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3gen"
+
+	"github.com/eluv-io/utc-go"
+)
+
+// dateTimeTypes are the types that marshal to an ISO 8601 / RFC 3339 date-time string in JSON.
+var dateTimeTypes = map[reflect.Type]bool{
+	reflect.TypeOf(utc.UTC{}):          true,
+	reflect.TypeOf(utc.ExplicitZero{}): true,
+	reflect.TypeOf(utc.Zoned{}):        true,
+	reflect.TypeOf(utc.SQLMillis{}):    true,
+	reflect.TypeOf(utc.SQLMicros{}):    true,
+}
+
+// Option returns the openapi3gen option that installs the schema customizer of this package.
+func Option() openapi3gen.Option {
+	return openapi3gen.SchemaCustomizer(SchemaCustomizer(nil))
+}
+
+// SchemaCustomizer returns a schema customizer that describes the date-time types of the utc package as string in
+// date-time format, and invokes the given customizer (if not nil) for all other types. Use it in order to combine it
+// with an existing customizer, since openapi3gen supports a single customizer only.
+func SchemaCustomizer(next openapi3gen.SchemaCustomizerFn) openapi3gen.SchemaCustomizerFn {
+	return func(name string, t reflect.Type, tag reflect.StructTag, schema *openapi3.Schema) error {
+		if dateTimeTypes[t] {
+			*schema = *openapi3.NewDateTimeSchema()
+			return nil
+		}
+		if next != nil {
+			return next(name, t, tag, schema)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,50 @@
+package openapiutc_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3gen"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+	"github.com/eluv-io/utc-go/openapiutc"
+)
+
+type testStruct struct {
+	Created  utc.UTC   `json:"created"`
+	Modified *utc.UTC  `json:"modified,omitempty"`
+	Local    utc.Zoned `json:"local"`
+	Name     string    `json:"name"`
+}
+
+func TestOption(t *testing.T) {
+	ref, err := openapi3gen.NewSchemaRefForValue(&testStruct{}, nil, openapiutc.Option())
+	require.NoError(t, err)
+
+	jsn, err := json.Marshal(ref.Value)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"type": "object",
+		"properties": {
+			"created": {"type": "string", "format": "date-time"},
+			"modified": {"type": "string", "format": "date-time"},
+			"local": {"type": "string", "format": "date-time"},
+			"name": {"type": "string"}
+		}
+	}`, string(jsn))
+}
+
+func TestSchemaCustomizer_next(t *testing.T) {
+	var names []string
+	next := func(name string, t reflect.Type, tag reflect.StructTag, schema *openapi3.Schema) error {
+		names = append(names, name)
+		return nil
+	}
+	_, err := openapi3gen.NewSchemaRefForValue(&testStruct{}, nil,
+		openapi3gen.SchemaCustomizer(openapiutc.SchemaCustomizer(next)))
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"name", "_root"}, names)
+}
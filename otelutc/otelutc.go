@@ -0,0 +1,46 @@
+// Package otelutc provides OpenTelemetry tracing options with timestamps driven by a utc.Clock.
+//
+// By default, the OpenTelemetry SDK takes span start, end and event timestamps from time.Now(). With the options of
+// this package, the timestamps are taken from utc.Now() or a given clock instead, so that spans recorded in tests under
+// a TestClock have deterministic timestamps:
+//
+//	clock := utc.NewWallClock(utc.MustParse("2020-01-01T00:00:00Z")).MockNow()
+//	defer clock.UnmockNow()
+//
+//	ctx, span := tracer.Start(ctx, "op", otelutc.Now(nil))
+//	clock.Add(time.Second)
+//	span.End(otelutc.Now(nil)) // duration of exactly one second
+//
+// Use utc.UTC.EpochNanos and utc.FromEpochNanos to convert to and from the epoch nanoseconds of exported spans.
+package otelutc
+
+import (
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/eluv-io/utc-go"
+)
+
+// Now returns an option that sets the timestamp of a span start, span end or span event to the current time of the
+// given clock, or of utc.Now() if the clock is nil.
+func Now(clock utc.Clock) trace.SpanEventOption {
+	if clock == nil {
+		return Timestamp(utc.Now())
+	}
+	return Timestamp(clock.Now())
+}
+
+// Timestamp returns an option that sets the timestamp of a span start, span end or span event to the given instant.
+func Timestamp(u utc.UTC) trace.SpanEventOption {
+	return trace.WithTimestamp(u.Time)
+}
+
+// FromTime converts a timestamp of the OpenTelemetry API, e.g. the start time of a ReadOnlySpan, to UTC. The zero
+// time, which denotes an unset timestamp, converts to utc.Zero.
+func FromTime(t time.Time) utc.UTC {
+	if t.IsZero() {
+		return utc.Zero
+	}
+	return utc.New(t)
+}
@@ -0,0 +1,56 @@
+package otelutc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/eluv-io/utc-go"
+	"github.com/eluv-io/utc-go/otelutc"
+)
+
+func TestNow(t *testing.T) {
+	start := utc.MustParse("2020-01-01T00:00:00.123456789Z")
+	clock := utc.NewWallClock(start).MockNow()
+	defer clock.UnmockNow()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	tracer := provider.Tracer("test")
+
+	_, span := tracer.Start(context.Background(), "op", otelutc.Now(nil))
+	clock.Add(time.Second)
+	span.AddEvent("event", otelutc.Now(nil))
+	clock.Add(time.Second)
+	span.End(otelutc.Now(nil))
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	s := spans[0]
+	require.True(t, start.Equal(otelutc.FromTime(s.StartTime())))
+	require.True(t, start.Add(2*time.Second).Equal(otelutc.FromTime(s.EndTime())))
+	require.Equal(t, 2*time.Second, s.EndTime().Sub(s.StartTime()))
+	require.Len(t, s.Events(), 1)
+	require.True(t, start.Add(time.Second).Equal(otelutc.FromTime(s.Events()[0].Time)))
+	require.Equal(t, uint64(1577836800123456789), otelutc.FromTime(s.StartTime()).EpochNanos())
+}
+
+func TestNow_clock(t *testing.T) {
+	start := utc.MustParse("2020-01-01T00:00:00Z")
+	clock := utc.NewWallClock(start)
+
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+
+	_, span := tracer.Start(context.Background(), "op", otelutc.Now(clock))
+	clock.Add(time.Minute)
+	span.End(otelutc.Timestamp(clock.Now()))
+
+	s := recorder.Ended()[0]
+	require.Equal(t, time.Minute, s.EndTime().Sub(s.StartTime()))
+	require.True(t, otelutc.FromTime(time.Time{}).IsZero())
+}
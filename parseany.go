@@ -0,0 +1,150 @@
+package utc
+
+import (
+	"strings"
+	"time"
+
+	"github.com/eluv-io/errors-go"
+)
+
+// ParseAnyOption is an option for ParseAny.
+type ParseAnyOption func(*parseAnyConfig)
+
+type parseAnyConfig struct {
+	dayFirst bool
+	loc      *time.Location
+}
+
+// WithDayFirst configures ParseAny to interpret ambiguous numeric dates like 2/1/2006 as day/month/year (1 February,
+// common in Europe) rather than month/day/year (2 January, common in the US).
+func WithDayFirst() ParseAnyOption {
+	return func(c *parseAnyConfig) {
+		c.dayFirst = true
+	}
+}
+
+// WithLocation configures ParseAny to interpret dates and times without timezone in the given location rather than in
+// UTC.
+func WithLocation(loc *time.Location) ParseAnyOption {
+	return func(c *parseAnyConfig) {
+		c.loc = loc
+	}
+}
+
+// ParseAny parses a date or time entered by a human in any of a number of common formats, for admin tools and CSV
+// imports where strictness is counterproductive. Unlike FromString, which accepts ISO 8601 only, it is an explicit
+// opt-in to heuristics that may guess wrong. Recognized are:
+//
+//   - everything accepted by FromString: 2006-01-02T15:04:05.000Z
+//   - ISO 8601 dates with a space instead of T, optional seconds and optional timezone, including the output of
+//     time.Time.String(): 2006-01-02 15:04:05 +0000 UTC, 2006/01/02 15:04
+//   - numeric dates in month/day/year order, or day/month/year order with WithDayFirst, separated by slashes, dashes or
+//     dots, with four- or two-digit years: 1/2/2006, 01-02-06
+//   - dates with month names: Jan 2, 2006, January 2 2006, 2 Jan 2006, 02-Jan-2006
+//   - the formats of RFC 1123, RFC 822, RFC 850, ANSI C, Unix date and Ruby date, optionally with a leading weekday
+//
+// Dates may be followed by a time in 24-hour format (15:04, 15:04:05 with optional fraction) or 12-hour format (3 PM,
+// 3:04 PM, 3:04:05pm) and a timezone (MST, -0700, -07:00, Z). Month names, weekdays and AM/PM are case-insensitive.
+// Dates and times without timezone are interpreted in UTC - see WithLocation.
+//
+// ParseAny tries many layouts in turn and is therefore much slower than FromString. Don't use it in hot paths.
+func ParseAny(s string, opts ...ParseAnyOption) (UTC, error) {
+	cfg := parseAnyConfig{loc: time.UTC}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	if u, err := FromString(s); err == nil && !u.IsZero() {
+		return u, nil
+	}
+
+	value := normalizeAny(s)
+	if value != "" {
+		layouts := anyLayoutsMonthFirst
+		if cfg.dayFirst {
+			layouts = anyLayoutsDayFirst
+		}
+		for _, layout := range layouts {
+			if t, err := time.ParseInLocation(layout, value, cfg.loc); err == nil {
+				return New(t.UTC()), nil
+			}
+		}
+	}
+	return Zero, errors.E("ParseAny", errors.K.Invalid, ErrParse, "reason", "unrecognized date format", "date", s)
+}
+
+// normalizeAny prepares a human-entered date for matching against the layouts of ParseAny: it collapses whitespace,
+// upper-cases the string (Go matches month and day names case-insensitively, but not am/pm), drops a leading weekday
+// and the monotonic clock reading of time.Time.String() and removes the space before AM/PM.
+func normalizeAny(s string) string {
+	fields := strings.Fields(strings.ToUpper(s))
+	if n := len(fields); n > 0 && strings.HasPrefix(fields[n-1], "M=") {
+		fields = fields[:n-1]
+	}
+	if len(fields) > 0 {
+		if _, ok := anyWeekdays[strings.TrimSuffix(fields[0], ",")]; ok {
+			fields = fields[1:]
+		}
+	}
+	for i := 1; i < len(fields); i++ {
+		if fields[i] == "AM" || fields[i] == "PM" {
+			fields[i-1] += fields[i]
+			fields = append(fields[:i], fields[i+1:]...)
+		}
+	}
+	return strings.Join(fields, " ")
+}
+
+var anyWeekdays = func() map[string]struct{} {
+	res := make(map[string]struct{})
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		res[strings.ToUpper(d.String())] = struct{}{}
+		res[strings.ToUpper(d.String()[:3])] = struct{}{}
+	}
+	return res
+}()
+
+var (
+	anyLayoutsMonthFirst = anyLayouts(false)
+	anyLayoutsDayFirst   = anyLayouts(true)
+)
+
+// anyLayouts returns the layouts tried by ParseAny, as combinations of date, time and timezone layouts.
+func anyLayouts(dayFirst bool) []string {
+	numeric := "1/2/"
+	if dayFirst {
+		numeric = "2/1/"
+	}
+	var dates []string
+	for _, sep := range []string{"-", "/", "."} {
+		dates = append(dates, "2006"+sep+"1"+sep+"2")
+	}
+	for _, sep := range []string{"/", "-", "."} {
+		n := strings.ReplaceAll(numeric, "/", sep)
+		dates = append(dates, n+"2006", n+"06")
+	}
+	dates = append(dates,
+		"Jan 2, 2006", "Jan 2 2006", "January 2, 2006", "January 2 2006",
+		"2 Jan 2006", "2 January 2006", "2-Jan-2006", "2-Jan-06", "2 Jan 06")
+
+	times := []string{"15:04", "15:04:05", "3PM", "3:04PM", "3:04:05PM"}
+	zones := []string{"", " MST", " -0700", " -0700 MST", " -07:00", "Z07:00", " Z07:00"}
+
+	var res []string
+	for _, d := range dates {
+		res = append(res, d)
+		for _, t := range times {
+			for _, z := range zones {
+				res = append(res, d+" "+t+z)
+				if strings.HasPrefix(d, "2006") {
+					res = append(res, d+"T"+t+z)
+				}
+			}
+		}
+	}
+	// formats with the year after the time: ANSI C, Unix date and Ruby date without weekday
+	return append(res,
+		"Jan 2 15:04:05 2006",
+		"Jan 2 15:04:05 MST 2006",
+		"Jan 2 15:04:05 -0700 2006")
+}
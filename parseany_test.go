@@ -0,0 +1,93 @@
+package utc_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+func TestParseAny(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{"2006-01-02T15:04:05.123Z", "2006-01-02T15:04:05.123Z"},
+		{"2006-01-02", "2006-01-02T00:00:00Z"},
+		{"2006-01-02 15:04", "2006-01-02T15:04:00Z"},
+		{"2006-01-02 15:04:05", "2006-01-02T15:04:05Z"},
+		{"2006-01-02 15:04:05.5", "2006-01-02T15:04:05.500Z"},
+		{"2006-01-02 15:04:05 +0000 UTC", "2006-01-02T15:04:05Z"},
+		{"2006-01-02 15:04:05 -0700 MST", "2006-01-02T22:04:05Z"},
+		{"2006-01-02 15:04:05.999 +0100 CET m=+0.000123", "2006-01-02T14:04:05.999Z"},
+		{"2006-01-02 15:04:05+02:00", "2006-01-02T13:04:05Z"},
+		{"2006/1/2 3:04 pm", "2006-01-02T15:04:00Z"},
+		{"2/1/2006", "2006-02-01T00:00:00Z"},
+		{"02/01/2006 12:30 AM", "2006-02-01T00:30:00Z"},
+		{"2-1-06 18:00", "2006-02-01T18:00:00Z"},
+		{"Jan 2, 2006 3:04 PM", "2006-01-02T15:04:00Z"},
+		{"jan 2 2006", "2006-01-02T00:00:00Z"},
+		{"January 2, 2006 3PM", "2006-01-02T15:00:00Z"},
+		{"  2  Jan   2006 ", "2006-01-02T00:00:00Z"},
+		{"02-Jan-2006 15:04:05", "2006-01-02T15:04:05Z"},
+		{"Mon, 02 Jan 2006 15:04:05 GMT", "2006-01-02T15:04:05Z"},
+		{"Mon, 02 Jan 2006 15:04:05 -0700", "2006-01-02T22:04:05Z"},
+		{"02 Jan 06 15:04 UTC", "2006-01-02T15:04:00Z"},
+		{"Monday, 02-Jan-06 15:04:05 UTC", "2006-01-02T15:04:05Z"},
+		{"Mon Jan  2 15:04:05 2006", "2006-01-02T15:04:05Z"},
+		{"Mon Jan 2 15:04:05 UTC 2006", "2006-01-02T15:04:05Z"},
+		{"Mon Jan 02 15:04:05 -0700 2006", "2006-01-02T22:04:05Z"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			u, err := utc.ParseAny(tt.s)
+			require.NoError(t, err)
+			require.Equal(t, utc.MustParse(tt.want), u)
+		})
+	}
+}
+
+func TestParseAny_dayFirst(t *testing.T) {
+	u, err := utc.ParseAny("2/1/2006", utc.WithDayFirst())
+	require.NoError(t, err)
+	require.Equal(t, utc.MustParse("2006-01-02"), u)
+
+	u, err = utc.ParseAny("31.12.2006 23:59", utc.WithDayFirst())
+	require.NoError(t, err)
+	require.Equal(t, utc.MustParse("2006-12-31T23:59:00Z"), u)
+
+	// unambiguous formats are not affected
+	u, err = utc.ParseAny("2006-02-01", utc.WithDayFirst())
+	require.NoError(t, err)
+	require.Equal(t, utc.MustParse("2006-02-01"), u)
+}
+
+func TestParseAny_location(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*3600)
+
+	u, err := utc.ParseAny("2006-01-02 15:04", utc.WithLocation(loc))
+	require.NoError(t, err)
+	require.Equal(t, utc.MustParse("2006-01-02T13:04:00Z"), u)
+
+	// explicit timezones take precedence
+	u, err = utc.ParseAny("2006-01-02 15:04 +0000", utc.WithLocation(loc))
+	require.NoError(t, err)
+	require.Equal(t, utc.MustParse("2006-01-02T15:04:00Z"), u)
+}
+
+func TestParseAny_invalid(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"   ",
+		"yesterday",
+		"13/13/2006",
+		"2006-02-30",
+		"Jan 32, 2006",
+		"2006-01-02 25:00",
+	} {
+		_, err := utc.ParseAny(s)
+		require.Error(t, err, s)
+	}
+}
@@ -0,0 +1,103 @@
+package utc
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+)
+
+// ParseCacheStats are the statistics of the parse cache - see SetParseCacheSize.
+type ParseCacheStats struct {
+	Size   int    // the maximum number of entries, 0 if the cache is disabled
+	Len    int    // the current number of entries
+	Hits   uint64 // the number of lookups that found a cached result
+	Misses uint64 // the number of lookups that did not
+}
+
+// parseCache is the current parse cache, nil if disabled.
+var parseCache atomic.Pointer[lruCache]
+
+// SetParseCacheSize enables a least-recently-used cache of the given size for the results of FromString (and therefore
+// MustParse, UnmarshalText and UnmarshalJSON), keyed by the input string. It is useful for workloads that decode the
+// same timestamp strings over and over, e.g. when polling manifests. The cache is disabled by default, and a size <= 0
+// disables it again. Changing the size discards all cached entries.
+//
+// The cache is safe for concurrent use. Only successfully parsed strings are cached, and timestamps with a leap second
+// are never cached, since their result depends on the LeapSecondPolicy. Note that UnmarshalText and UnmarshalJSON parse
+// the most common format (2006-01-02T15:04:05.000Z) without allocations and without consulting the cache, hence the
+// cache is beneficial mainly for other formats, e.g. timestamps with timezone offsets.
+func SetParseCacheSize(size int) {
+	if size <= 0 {
+		parseCache.Store(nil)
+		return
+	}
+	parseCache.Store(newLRUCache(size))
+}
+
+// GetParseCacheStats returns the statistics of the parse cache.
+func GetParseCacheStats() ParseCacheStats {
+	c := parseCache.Load()
+	if c == nil {
+		return ParseCacheStats{}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return ParseCacheStats{
+		Size:   c.size,
+		Len:    c.order.Len(),
+		Hits:   c.hits,
+		Misses: c.misses,
+	}
+}
+
+// lruCache is a least-recently-used cache of parsed strings.
+type lruCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List // of *lruEntry, most recently used first
+	entries map[string]*list.Element
+	hits    uint64
+	misses  uint64
+}
+
+type lruEntry struct {
+	key string
+	val UTC
+}
+
+func newLRUCache(size int) *lruCache {
+	return &lruCache{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[string]*list.Element, size),
+	}
+}
+
+func (c *lruCache) get(key string) (UTC, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		c.misses++
+		return Zero, false
+	}
+	c.hits++
+	c.order.MoveToFront(e)
+	return e.Value.(*lruEntry).val, true
+}
+
+func (c *lruCache) add(key string, val UTC) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[key]; ok {
+		e.Value.(*lruEntry).val = val
+		c.order.MoveToFront(e)
+		return
+	}
+	if c.order.Len() >= c.size {
+		last := c.order.Back()
+		delete(c.entries, last.Value.(*lruEntry).key)
+		c.order.Remove(last)
+	}
+	c.entries[key] = c.order.PushFront(&lruEntry{key: key, val: val})
+}
@@ -0,0 +1,90 @@
+package utc_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+func TestParseCache(t *testing.T) {
+	defer utc.SetParseCacheSize(0)
+	require.Equal(t, utc.ParseCacheStats{}, utc.GetParseCacheStats())
+
+	utc.SetParseCacheSize(2)
+	s1, s2, s3 := "2020-01-01T01:00:00+01:00", "2020-01-02T01:00:00+01:00", "2020-01-03T01:00:00+01:00"
+
+	u1, err := utc.FromString(s1)
+	require.NoError(t, err)
+	require.Equal(t, "2020-01-01T00:00:00.000Z", u1.String())
+	require.Equal(t, utc.ParseCacheStats{Size: 2, Len: 1, Misses: 1}, utc.GetParseCacheStats())
+
+	res, err := utc.FromString(s1)
+	require.NoError(t, err)
+	require.Equal(t, u1, res)
+	require.Equal(t, utc.ParseCacheStats{Size: 2, Len: 1, Hits: 1, Misses: 1}, utc.GetParseCacheStats())
+
+	_, err = utc.FromString(s2)
+	require.NoError(t, err)
+	_, err = utc.FromString(s1) // s1 is now the most recently used entry
+	require.NoError(t, err)
+	_, err = utc.FromString(s3) // evicts s2
+	require.NoError(t, err)
+	require.Equal(t, utc.ParseCacheStats{Size: 2, Len: 2, Hits: 2, Misses: 3}, utc.GetParseCacheStats())
+
+	_, err = utc.FromString(s1)
+	require.NoError(t, err)
+	_, err = utc.FromString(s2)
+	require.NoError(t, err)
+	require.Equal(t, utc.ParseCacheStats{Size: 2, Len: 2, Hits: 3, Misses: 4}, utc.GetParseCacheStats())
+
+	// errors and leap seconds are not cached
+	_, err = utc.FromString("invalid")
+	require.Error(t, err)
+	_, err = utc.FromString("2016-12-31T23:59:60.500Z")
+	require.NoError(t, err)
+	stats := utc.GetParseCacheStats()
+	require.Equal(t, 2, stats.Len)
+	require.Equal(t, uint64(6), stats.Misses)
+
+	// unmarshaling uses the cache for formats other than the fast path
+	var u utc.UTC
+	require.NoError(t, u.UnmarshalText([]byte(s1)))
+	require.Equal(t, uint64(4), utc.GetParseCacheStats().Hits)
+
+	// resizing discards all entries
+	utc.SetParseCacheSize(10)
+	require.Equal(t, utc.ParseCacheStats{Size: 10}, utc.GetParseCacheStats())
+
+	utc.SetParseCacheSize(0)
+	_, err = utc.FromString(s1)
+	require.NoError(t, err)
+	require.Equal(t, utc.ParseCacheStats{}, utc.GetParseCacheStats())
+}
+
+func TestParseCache_concurrent(t *testing.T) {
+	defer utc.SetParseCacheSize(0)
+	utc.SetParseCacheSize(8)
+
+	wg := sync.WaitGroup{}
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				s := fmt.Sprintf("2020-01-%02dT00:00:00+02:00", i%16+1)
+				u, err := utc.FromString(s)
+				require.NoError(t, err)
+				require.Equal(t, i%16+1, u.Add(2*3600e9).Day())
+			}
+		}()
+	}
+	wg.Wait()
+
+	stats := utc.GetParseCacheStats()
+	require.Equal(t, 8, stats.Len)
+	require.Equal(t, uint64(8000), stats.Hits+stats.Misses)
+}
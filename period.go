@@ -0,0 +1,121 @@
+package utc
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+)
+
+// Period is a recurring schedule of instants "every d anchored at origin": the occurrences are origin + i*Every for
+// all integers i, including negative ones. It is meant for closed-form computations of rotation schedules (segments,
+// keys, logs...). All computations are performed without iteration, even for origins far in the past.
+type Period struct {
+	Origin UTC           // the anchor of the period, the occurrence with index 0
+	Every  time.Duration // the interval between occurrences, must be positive
+}
+
+// NewPeriod creates a Period with the given origin and interval. It panics if every is not positive.
+func NewPeriod(origin UTC, every time.Duration) Period {
+	if every <= 0 {
+		panic("utc.NewPeriod: non-positive interval")
+	}
+	return Period{Origin: origin, Every: every}
+}
+
+// String returns the period formatted as "every 6h0m0s from 2006-01-02T15:04:05.000Z".
+func (p Period) String() string {
+	return fmt.Sprintf("every %s from %s", p.Every, p.Origin)
+}
+
+// OccurrenceIndex returns the index of the last occurrence at or before the given instant, i.e.
+// floor((u - origin) / every). The index is negative for instants before the origin, and saturated at math.MinInt64
+// and math.MaxInt64 for instants that are too far from the origin for the interval.
+func (p Period) OccurrenceIndex(u UTC) int64 {
+	p.check()
+	span := u.Time.Sub(p.Origin.Time)
+	if span > math.MinInt64 && span < math.MaxInt64 {
+		return floorDiv(int64(span), int64(p.Every))
+	}
+
+	// span in nanoseconds exceeds int64: compute with big integers
+	bigSpan := big.NewInt(u.Unix() - p.Origin.Unix())
+	bigSpan.Mul(bigSpan, big.NewInt(int64(time.Second)))
+	bigSpan.Add(bigSpan, big.NewInt(int64(u.Nanosecond()-p.Origin.Nanosecond())))
+	// Euclidean division with a positive divisor is floor division
+	q, _ := new(big.Int).DivMod(bigSpan, big.NewInt(int64(p.Every)), new(big.Int))
+	return saturateInt64(q)
+}
+
+// At returns the occurrence with the given index: origin + i*every.
+func (p Period) At(i int64) UTC {
+	p.check()
+	if i >= -math.MaxInt64/int64(p.Every) && i <= math.MaxInt64/int64(p.Every) {
+		return New(p.Origin.Time.Add(time.Duration(i) * p.Every))
+	}
+
+	// offset in nanoseconds exceeds int64: compute with big integers
+	offset := new(big.Int).Mul(big.NewInt(i), big.NewInt(int64(p.Every)))
+	sec, nsec := new(big.Int).DivMod(offset, big.NewInt(int64(time.Second)), new(big.Int))
+	return Unix(p.Origin.Unix()+sec.Int64(), int64(p.Origin.Nanosecond())+nsec.Int64())
+}
+
+// Next returns the first occurrence strictly after the given instant.
+func (p Period) Next(after UTC) UTC {
+	return p.At(p.OccurrenceIndex(after) + 1)
+}
+
+// Prev returns the last occurrence strictly before the given instant.
+func (p Period) Prev(before UTC) UTC {
+	i := p.OccurrenceIndex(before)
+	if p.At(i).Equal(before) {
+		i--
+	}
+	return p.At(i)
+}
+
+// Contains returns true if the given instant is an occurrence of the period.
+func (p Period) Contains(u UTC) bool {
+	return p.At(p.OccurrenceIndex(u)).Equal(u)
+}
+
+// RangeAt returns the range [At(i), At(i+1)) of the interval starting at the occurrence with the given index.
+func (p Period) RangeAt(i int64) Range {
+	return Range{Start: p.At(i), End: p.At(i + 1)}
+}
+
+func (p Period) check() {
+	if p.Every <= 0 {
+		panic("utc.Period: non-positive interval")
+	}
+}
+
+// floorDiv returns floor(a / b) for b > 0.
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if a%b != 0 && a < 0 {
+		q--
+	}
+	return q
+}
+
+// NextAligned returns the first instant at or after this instant that is a multiple of d since the unix epoch
+// 1970-01-01T00:00:00Z, e.g. the next full 6 hours for d = 6h. Unlike the Truncate/Add idiom, it is correct for
+// instants before the epoch. It panics if d is not positive.
+func (u UTC) NextAligned(d time.Duration) UTC {
+	p := NewPeriod(epoch, d)
+	if p.Contains(u) {
+		return u
+	}
+	return p.Next(u)
+}
+
+// PrevAligned returns the last instant at or before this instant that is a multiple of d since the unix epoch
+// 1970-01-01T00:00:00Z. It panics if d is not positive.
+func (u UTC) PrevAligned(d time.Duration) UTC {
+	p := NewPeriod(epoch, d)
+	return p.At(p.OccurrenceIndex(u))
+}
+
+// epoch is the unix epoch 1970-01-01T00:00:00Z.
+var epoch = Unix(0, 0)
@@ -0,0 +1,125 @@
+package utc_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+func TestPeriod(t *testing.T) {
+	origin := utc.MustParse("2020-01-01T00:00:00Z")
+	p := utc.NewPeriod(origin, 6*time.Hour)
+	require.Equal(t, "every 6h0m0s from 2020-01-01T00:00:00.000Z", p.String())
+
+	tests := []struct {
+		u     string
+		index int64
+		next  string
+		prev  string
+	}{
+		{"2020-01-01T00:00:00Z", 0, "2020-01-01T06:00:00Z", "2019-12-31T18:00:00Z"},
+		{"2020-01-01T00:00:00.001Z", 0, "2020-01-01T06:00:00Z", "2020-01-01T00:00:00Z"},
+		{"2020-01-01T05:59:59.999Z", 0, "2020-01-01T06:00:00Z", "2020-01-01T00:00:00Z"},
+		{"2020-01-01T06:00:00Z", 1, "2020-01-01T12:00:00Z", "2020-01-01T00:00:00Z"},
+		{"2020-01-02T13:00:00Z", 6, "2020-01-02T18:00:00Z", "2020-01-02T12:00:00Z"},
+		{"2019-12-31T23:59:59.999Z", -1, "2020-01-01T00:00:00Z", "2019-12-31T18:00:00Z"},
+		{"2019-12-31T18:00:00Z", -1, "2020-01-01T00:00:00Z", "2019-12-31T12:00:00Z"},
+		{"2019-12-31T17:59:59Z", -2, "2019-12-31T18:00:00Z", "2019-12-31T12:00:00Z"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.u, func(t *testing.T) {
+			u := utc.MustParse(tt.u)
+			require.Equal(t, tt.index, p.OccurrenceIndex(u))
+			require.Equal(t, tt.next, p.Next(u).Format(time.RFC3339))
+			require.Equal(t, tt.prev, p.Prev(u).Format(time.RFC3339))
+			require.False(t, p.At(tt.index).After(u))
+			require.True(t, p.At(tt.index+1).After(u))
+		})
+	}
+
+	require.True(t, p.Contains(origin.Add(18*time.Hour)))
+	require.False(t, p.Contains(origin.Add(18*time.Hour+1)))
+
+	r := p.RangeAt(-1)
+	require.True(t, origin.Add(-6*time.Hour).Equal(r.Start))
+	require.True(t, origin.Equal(r.End))
+
+	require.Panics(t, func() { utc.NewPeriod(origin, 0) })
+	require.Panics(t, func() { utc.Period{}.Next(origin) })
+}
+
+func TestPeriod_farOrigin(t *testing.T) {
+	// the span between origin and instant exceeds the range of time.Duration
+	p := utc.NewPeriod(utc.MustParse("0001-01-01T00:00:00.5Z"), time.Hour)
+	u := utc.MustParse("2020-06-15T10:20:30Z")
+	next := p.Next(u)
+	require.Equal(t, "2020-06-15T11:00:00.500Z", next.String())
+	require.Equal(t, "2020-06-15T10:00:00.500Z", p.Prev(u).String())
+	require.True(t, p.Contains(next))
+	require.Equal(t, next, p.At(p.OccurrenceIndex(next)))
+
+	// negative direction
+	p = utc.NewPeriod(utc.MustParse("9999-01-01T00:00:00Z"), 7*24*time.Hour)
+	u = utc.MustParse("2020-06-15T10:20:30Z")
+	prev := p.Prev(u)
+	require.True(t, prev.Before(u))
+	require.Equal(t, prev.Weekday(), p.Origin.Weekday())
+	require.True(t, p.Next(u).Sub(prev) == 7*24*time.Hour)
+	require.Less(t, p.OccurrenceIndex(u), int64(0))
+
+	// indexes beyond the range of int64 are saturated
+	p = utc.NewPeriod(utc.Min, time.Nanosecond)
+	require.Equal(t, int64(math.MaxInt64), p.OccurrenceIndex(utc.Max))
+	p = utc.NewPeriod(utc.Max, time.Nanosecond)
+	require.Equal(t, int64(math.MinInt64), p.OccurrenceIndex(utc.Min))
+
+	// random cross check with small periods
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		origin := utc.RandBetween(rnd, utc.Min, utc.Max)
+		every := utc.RandDuration(rnd, 1, 1000*time.Hour)
+		p := utc.NewPeriod(origin, every)
+		u := utc.RandBetween(rnd, utc.Min, utc.Max)
+		prev, next := p.Prev(u), p.Next(u)
+		require.True(t, prev.Before(u), "%s %s", p, u)
+		require.True(t, next.After(u), "%s %s", p, u)
+		span := every
+		if p.Contains(u) {
+			span = 2 * every
+		}
+		require.Equal(t, span, next.Time.Sub(prev.Time), "%s %s", p, u)
+	}
+}
+
+func TestUTC_NextPrevAligned(t *testing.T) {
+	tests := []struct {
+		u    string
+		d    time.Duration
+		next string
+		prev string
+	}{
+		{"2020-01-01T07:30:00Z", 6 * time.Hour, "2020-01-01T12:00:00Z", "2020-01-01T06:00:00Z"},
+		{"2020-01-01T06:00:00Z", 6 * time.Hour, "2020-01-01T06:00:00Z", "2020-01-01T06:00:00Z"},
+		{"1969-12-31T23:00:00Z", 6 * time.Hour, "1970-01-01T00:00:00Z", "1969-12-31T18:00:00Z"},
+		{"1969-12-31T18:00:00Z", 6 * time.Hour, "1969-12-31T18:00:00Z", "1969-12-31T18:00:00Z"},
+		{"1960-05-05T05:05:05Z", time.Minute, "1960-05-05T05:06:00Z", "1960-05-05T05:05:00Z"},
+		// weeks since the epoch start on Thursdays
+		{"2020-01-01T00:00:00Z", 7 * 24 * time.Hour, "2020-01-02T00:00:00Z", "2019-12-26T00:00:00Z"},
+		// far from the epoch
+		{"0005-03-03T03:03:03Z", time.Hour, "0005-03-03T04:00:00Z", "0005-03-03T03:00:00Z"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.u+"/"+tt.d.String(), func(t *testing.T) {
+			u := utc.MustParse(tt.u)
+			require.Equal(t, tt.next, u.NextAligned(tt.d).Format(time.RFC3339))
+			require.Equal(t, tt.prev, u.PrevAligned(tt.d).Format(time.RFC3339))
+		})
+	}
+
+	require.Panics(t, func() { utc.Now().NextAligned(0) })
+}
@@ -0,0 +1,178 @@
+// Package pgxutc registers a pgx v5 codec that binds utc.UTC values natively to the PostgreSQL timestamptz type.
+//
+// Without the codec, pgx falls back to the driver.Valuer and sql.Scanner implementations of utc.UTC, which go through
+// an intermediate time.Time or text representation. With the codec, UTC values are encoded and decoded directly in
+// the binary protocol:
+//
+//	conn, err := pgx.Connect(ctx, dsn)
+//	...
+//	pgxutc.Register(conn.TypeMap())
+//
+// or for a connection pool:
+//
+//	config.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
+//		pgxutc.Register(conn.TypeMap())
+//		return nil
+//	}
+//
+// The values are mapped as follows:
+//
+//   - utc.Zero <-> NULL
+//   - utc.Max  <-> infinity
+//   - utc.Min  <-> -infinity
+//
+// Values are scanned into *utc.UTC, *utc.SQLMillis and *utc.SQLMicros as well as into **utc.UTC, where NULL is scanned
+// as nil.
+//
+// Note that PostgreSQL stores timestamps with microsecond precision.
+package pgxutc
+
+import (
+	"database/sql/driver"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/eluv-io/utc-go"
+)
+
+// Register registers the Codec for the timestamptz type in the given type map.
+func Register(m *pgtype.Map) {
+	m.RegisterType(&pgtype.Type{
+		Name:  "timestamptz",
+		OID:   pgtype.TimestamptzOID,
+		Codec: &Codec{},
+	})
+}
+
+// Codec is a pgtype.Codec for timestamptz that handles utc.UTC, *utc.UTC as well as utc.SQLMillis and utc.SQLMicros
+// values and scan targets of these types (including **utc.UTC), and delegates all other values to the embedded
+// pgtype.TimestamptzCodec.
+type Codec struct {
+	pgtype.TimestamptzCodec
+}
+
+// PlanEncode implements the pgtype.Codec interface.
+func (c *Codec) PlanEncode(m *pgtype.Map, oid uint32, format int16, value any) pgtype.EncodePlan {
+	if _, ok := toUTC(value); !ok {
+		return c.TimestamptzCodec.PlanEncode(m, oid, format, value)
+	}
+	next := c.TimestamptzCodec.PlanEncode(m, oid, format, pgtype.Timestamptz{})
+	if next == nil {
+		return nil
+	}
+	return &encodePlan{next: next}
+}
+
+// PlanScan implements the pgtype.Codec interface.
+func (c *Codec) PlanScan(m *pgtype.Map, oid uint32, format int16, target any) pgtype.ScanPlan {
+	if !isScanTarget(target) {
+		return c.TimestamptzCodec.PlanScan(m, oid, format, target)
+	}
+	next := c.TimestamptzCodec.PlanScan(m, oid, format, &pgtype.Timestamptz{})
+	if next == nil {
+		return nil
+	}
+	return &scanPlan{next: next}
+}
+
+// DecodeDatabaseSQLValue implements the pgtype.Codec interface.
+func (c *Codec) DecodeDatabaseSQLValue(m *pgtype.Map, oid uint32, format int16, src []byte) (driver.Value, error) {
+	return c.TimestamptzCodec.DecodeDatabaseSQLValue(m, oid, format, src)
+}
+
+// DecodeValue implements the pgtype.Codec interface.
+func (c *Codec) DecodeValue(m *pgtype.Map, oid uint32, format int16, src []byte) (any, error) {
+	return c.TimestamptzCodec.DecodeValue(m, oid, format, src)
+}
+
+type encodePlan struct {
+	next pgtype.EncodePlan
+}
+
+func (p *encodePlan) Encode(value any, buf []byte) ([]byte, error) {
+	u, _ := toUTC(value)
+	return p.next.Encode(ToTimestamptz(u), buf)
+}
+
+type scanPlan struct {
+	next pgtype.ScanPlan
+}
+
+func (p *scanPlan) Scan(src []byte, target any) error {
+	var ts pgtype.Timestamptz
+	if err := p.next.Scan(src, &ts); err != nil {
+		return err
+	}
+	u := FromTimestamptz(ts)
+	switch t := target.(type) {
+	case *utc.UTC:
+		*t = u
+	case *utc.SQLMillis:
+		t.UTC = u
+	case *utc.SQLMicros:
+		t.UTC = u
+	case **utc.UTC:
+		if !ts.Valid {
+			*t = nil
+		} else {
+			*t = &u
+		}
+	}
+	return nil
+}
+
+// isScanTarget returns true if the given target is supported by scanPlan.
+func isScanTarget(target any) bool {
+	switch target.(type) {
+	case *utc.UTC, *utc.SQLMillis, *utc.SQLMicros, **utc.UTC:
+		return true
+	}
+	return false
+}
+
+// toUTC converts the supported values to UTC.
+func toUTC(value any) (utc.UTC, bool) {
+	switch v := value.(type) {
+	case utc.UTC:
+		return v, true
+	case *utc.UTC:
+		if v == nil {
+			return utc.Zero, true
+		}
+		return *v, true
+	case utc.SQLMillis:
+		return v.Truncate(time.Millisecond), true
+	case utc.SQLMicros:
+		return v.Truncate(time.Microsecond), true
+	}
+	return utc.Zero, false
+}
+
+// ToTimestamptz converts the given UTC to a pgtype.Timestamptz. The zero value is converted to NULL, utc.Max to
+// infinity and utc.Min to -infinity.
+func ToTimestamptz(u utc.UTC) pgtype.Timestamptz {
+	switch {
+	case u.IsZero():
+		return pgtype.Timestamptz{}
+	case u.Equal(utc.Max):
+		return pgtype.Timestamptz{InfinityModifier: pgtype.Infinity, Valid: true}
+	case u.Equal(utc.Min):
+		return pgtype.Timestamptz{InfinityModifier: pgtype.NegativeInfinity, Valid: true}
+	}
+	return pgtype.Timestamptz{Time: u.Time, Valid: true}
+}
+
+// FromTimestamptz converts the given pgtype.Timestamptz to UTC. NULL is converted to the zero value, infinity to
+// utc.Max and -infinity to utc.Min.
+func FromTimestamptz(ts pgtype.Timestamptz) utc.UTC {
+	switch {
+	case !ts.Valid:
+		return utc.Zero
+	case ts.InfinityModifier == pgtype.Infinity:
+		return utc.Max
+	case ts.InfinityModifier == pgtype.NegativeInfinity:
+		return utc.Min
+	}
+	return utc.New(ts.Time)
+}
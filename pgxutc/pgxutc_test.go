@@ -0,0 +1,122 @@
+package pgxutc_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+	"github.com/eluv-io/utc-go/pgxutc"
+)
+
+func TestCodec(t *testing.T) {
+	m := pgtype.NewMap()
+	pgxutc.Register(m)
+
+	tests := []struct {
+		name string
+		val  utc.UTC
+		want utc.UTC
+	}{
+		{"regular", utc.MustParse("2022-03-04T05:06:07.123456Z"), utc.MustParse("2022-03-04T05:06:07.123456Z")},
+		{"truncated to micros", utc.MustParse("2022-03-04T05:06:07.123456789Z"), utc.MustParse("2022-03-04T05:06:07.123456Z")},
+		{"zero", utc.Zero, utc.Zero},
+		{"max", utc.Max, utc.Max},
+		{"min", utc.Min, utc.Min},
+	}
+	for _, test := range tests {
+		for _, format := range []int16{pgtype.BinaryFormatCode, pgtype.TextFormatCode} {
+			t.Run(test.name, func(t *testing.T) {
+				buf, err := m.Encode(pgtype.TimestamptzOID, format, test.val, nil)
+				require.NoError(t, err)
+				require.Equal(t, test.val.IsZero(), buf == nil)
+
+				bufPtr, err := m.Encode(pgtype.TimestamptzOID, format, &test.val, nil)
+				require.NoError(t, err)
+				require.Equal(t, buf, bufPtr)
+
+				var res utc.UTC
+				err = m.Scan(pgtype.TimestamptzOID, format, buf, &res)
+				require.NoError(t, err)
+				require.Equal(t, test.want.String(), res.String())
+			})
+		}
+	}
+}
+
+func TestCodecInfinity(t *testing.T) {
+	m := pgtype.NewMap()
+	pgxutc.Register(m)
+
+	for _, format := range []int16{pgtype.BinaryFormatCode, pgtype.TextFormatCode} {
+		buf, err := m.Encode(pgtype.TimestamptzOID, format, pgtype.Timestamptz{InfinityModifier: pgtype.Infinity, Valid: true}, nil)
+		require.NoError(t, err)
+		bufMax, err := m.Encode(pgtype.TimestamptzOID, format, utc.Max, nil)
+		require.NoError(t, err)
+		require.Equal(t, buf, bufMax)
+
+		var ts pgtype.Timestamptz
+		require.NoError(t, m.Scan(pgtype.TimestamptzOID, format, bufMax, &ts))
+		require.Equal(t, pgtype.Infinity, ts.InfinityModifier)
+	}
+}
+
+func TestCodecPrecisionTypes(t *testing.T) {
+	m := pgtype.NewMap()
+	pgxutc.Register(m)
+
+	u := utc.MustParse("2022-03-04T05:06:07.123456789Z")
+	buf, err := m.Encode(pgtype.TimestamptzOID, pgtype.BinaryFormatCode, utc.SQLMillis{UTC: u}, nil)
+	require.NoError(t, err)
+
+	var res utc.UTC
+	require.NoError(t, m.Scan(pgtype.TimestamptzOID, pgtype.BinaryFormatCode, buf, &res))
+	require.Equal(t, "2022-03-04T05:06:07.123Z", res.String())
+}
+
+func TestCodecScanTargets(t *testing.T) {
+	m := pgtype.NewMap()
+	pgxutc.Register(m)
+
+	u := utc.MustParse("2022-03-04T05:06:07.123456Z")
+	for _, format := range []int16{pgtype.BinaryFormatCode, pgtype.TextFormatCode} {
+		buf, err := m.Encode(pgtype.TimestamptzOID, format, u, nil)
+		require.NoError(t, err)
+		bufMax, err := m.Encode(pgtype.TimestamptzOID, format, utc.Max, nil)
+		require.NoError(t, err)
+
+		var millis utc.SQLMillis
+		require.NoError(t, m.Scan(pgtype.TimestamptzOID, format, buf, &millis))
+		require.Equal(t, u.String(), millis.String())
+		require.NoError(t, m.Scan(pgtype.TimestamptzOID, format, nil, &millis))
+		require.True(t, millis.IsZero())
+
+		var micros utc.SQLMicros
+		require.NoError(t, m.Scan(pgtype.TimestamptzOID, format, bufMax, &micros))
+		require.True(t, micros.IsMax())
+
+		ptr := &utc.UTC{}
+		require.NoError(t, m.Scan(pgtype.TimestamptzOID, format, nil, &ptr))
+		require.Nil(t, ptr)
+		require.NoError(t, m.Scan(pgtype.TimestamptzOID, format, buf, &ptr))
+		require.NotNil(t, ptr)
+		require.Equal(t, u.String(), ptr.String())
+		require.NoError(t, m.Scan(pgtype.TimestamptzOID, format, bufMax, &ptr))
+		require.True(t, ptr.IsMax())
+	}
+}
+
+func TestCodecOtherTypes(t *testing.T) {
+	m := pgtype.NewMap()
+	pgxutc.Register(m)
+
+	now := time.Date(2022, 3, 4, 5, 6, 7, 0, time.UTC)
+	buf, err := m.Encode(pgtype.TimestamptzOID, pgtype.BinaryFormatCode, now, nil)
+	require.NoError(t, err)
+
+	var res time.Time
+	require.NoError(t, m.Scan(pgtype.TimestamptzOID, pgtype.BinaryFormatCode, buf, &res))
+	require.True(t, now.Equal(res))
+}
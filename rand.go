@@ -0,0 +1,48 @@
+package utc
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"time"
+)
+
+// RandBetween returns a pseudo-random UTC in the closed interval [lo, hi], drawn uniformly with nanosecond precision
+// from the given source. Use a rand.Rand with a fixed seed for reproducible test fixtures. RandBetween supports the
+// full range from Min to Max, which exceeds the range of time.Duration. It panics if hi is before lo.
+func RandBetween(r *rand.Rand, lo, hi UTC) UTC {
+	if hi.Time.Before(lo.Time) {
+		panic("utc.RandBetween: hi is before lo")
+	}
+	span := hi.Time.Sub(lo.Time)
+	if span < math.MaxInt64 {
+		return New(lo.Time.Add(time.Duration(r.Int63n(int64(span) + 1))))
+	}
+
+	// span in nanoseconds exceeds int64: compute with big integers
+	bigSpan := big.NewInt(hi.Unix() - lo.Unix())
+	bigSpan.Mul(bigSpan, big.NewInt(int64(time.Second)))
+	bigSpan.Add(bigSpan, big.NewInt(int64(hi.Nanosecond()-lo.Nanosecond()+1)))
+	offset := new(big.Int).Rand(r, bigSpan)
+
+	sec, nsec := new(big.Int).QuoRem(offset, big.NewInt(int64(time.Second)), new(big.Int))
+	return Unix(lo.Unix()+sec.Int64(), int64(lo.Nanosecond())+nsec.Int64())
+}
+
+// RandDuration returns a pseudo-random duration in the closed interval [lo, hi], drawn uniformly from the given
+// source. It panics if hi is smaller than lo.
+func RandDuration(r *rand.Rand, lo, hi time.Duration) time.Duration {
+	if hi < lo {
+		panic("utc.RandDuration: hi is smaller than lo")
+	}
+	span := uint64(hi) - uint64(lo)
+	if span < math.MaxInt64 {
+		return lo + time.Duration(r.Int63n(int64(span)+1))
+	}
+	for {
+		// rejection sampling: span >= 2^63, hence at most half of the values are rejected
+		if v := r.Uint64(); v <= span {
+			return time.Duration(uint64(lo) + v)
+		}
+	}
+}
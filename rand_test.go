@@ -0,0 +1,71 @@
+package utc_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+func TestRandBetween(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	tests := []struct {
+		lo, hi utc.UTC
+	}{
+		{utc.MustParse("2020-01-01"), utc.MustParse("2020-01-02")},
+		{utc.MustParse("2020-01-01"), utc.MustParse("2020-01-01")},
+		{utc.Min, utc.Max},
+		{utc.Min, utc.MustParse("0000-01-01T00:00:00.000000001Z")},
+	}
+	for _, test := range tests {
+		for i := 0; i < 1000; i++ {
+			u := utc.RandBetween(r, test.lo, test.hi)
+			require.False(t, u.Before(test.lo), u)
+			require.False(t, u.After(test.hi), u)
+		}
+	}
+
+	// reproducible
+	a := utc.RandBetween(rand.New(rand.NewSource(42)), utc.Min, utc.Max)
+	b := utc.RandBetween(rand.New(rand.NewSource(42)), utc.Min, utc.Max)
+	require.Equal(t, a, b)
+
+	// full range is covered: with 1000 samples, both the first and the last 10% of the range are hit
+	var early, late bool
+	for i := 0; i < 1000; i++ {
+		y := utc.RandBetween(r, utc.Min, utc.Max).Year()
+		early = early || y < 1000
+		late = late || y > 9000
+	}
+	require.True(t, early)
+	require.True(t, late)
+
+	require.Panics(t, func() { utc.RandBetween(r, utc.Max, utc.Min) })
+}
+
+func TestRandDuration(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	tests := []struct {
+		lo, hi time.Duration
+	}{
+		{0, time.Second},
+		{-time.Hour, time.Hour},
+		{time.Minute, time.Minute},
+		{math.MinInt64, math.MaxInt64},
+		{math.MinInt64 + 1, 1},
+	}
+	for _, test := range tests {
+		for i := 0; i < 1000; i++ {
+			d := utc.RandDuration(r, test.lo, test.hi)
+			require.GreaterOrEqual(t, d, test.lo)
+			require.LessOrEqual(t, d, test.hi)
+		}
+	}
+	require.Panics(t, func() { utc.RandDuration(r, time.Second, 0) })
+}
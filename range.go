@@ -0,0 +1,108 @@
+package utc
+
+import (
+	"math"
+	"time"
+)
+
+// Range is the half-open time interval [Start, End): Start is included, End is excluded.
+type Range struct {
+	Start UTC `json:"start"`
+	End   UTC `json:"end"`
+}
+
+// NewRange creates a new Range [start, end).
+func NewRange(start, end UTC) Range {
+	return Range{Start: start, End: end}
+}
+
+// String returns the range formatted as ISO 8601 time interval: 2006-01-02T15:04:05.000Z/2006-01-02T16:04:05.000Z
+func (r Range) String() string {
+	return r.Start.String() + "/" + r.End.String()
+}
+
+// Duration returns the duration of the range: End - Start.
+func (r Range) Duration() time.Duration {
+	return r.End.Sub(r.Start)
+}
+
+// IsEmpty returns true if the range does not contain any instant, i.e. if End is not after Start.
+func (r Range) IsEmpty() bool {
+	return !r.End.After(r.Start)
+}
+
+// Contains returns true if the given instant is within the range: Start <= u < End
+func (r Range) Contains(u UTC) bool {
+	return !u.Before(r.Start) && u.Before(r.End)
+}
+
+// Lerp returns the instant at the given fraction of the range by linear interpolation: Start for 0, End for 1 and the
+// middle of the range for 0.5. Fractions outside of [0, 1] extrapolate beyond Start and End.
+func (r Range) Lerp(f float64) UTC {
+	switch f {
+	case 0:
+		return r.Start
+	case 1:
+		return r.End
+	}
+	return addNanos(r.Start, f*r.nanos())
+}
+
+// Fraction returns the fraction of the range that has elapsed at the given instant, the inverse of Lerp: 0 at Start, 1
+// at End, less than 0 before Start and greater than 1 after End. Use it for progress computations. If the range is
+// empty, Fraction returns 0 for instants before End and 1 otherwise.
+func (r Range) Fraction(u UTC) float64 {
+	span := r.nanos()
+	if span <= 0 {
+		if u.Before(r.End) {
+			return 0
+		}
+		return 1
+	}
+	return NewRange(r.Start, u).nanos() / span
+}
+
+// Split splits the range into n contiguous ranges of equal duration, e.g. for processing a time window in parallel
+// shards. The first range starts at Start, the last ends at End, and the durations of the ranges differ by at most a
+// nanosecond - some ranges are therefore empty if the range is shorter than n nanoseconds. Split returns nil if n is
+// less than 1 or the range is empty.
+func (r Range) Split(n int) []Range {
+	if n < 1 || r.IsEmpty() {
+		return nil
+	}
+	res := make([]Range, n)
+	d := r.End.Sub(r.Start)
+	exact := d < math.MaxInt64 // Sub saturates
+	start := r.Start
+	for i := 0; i < n; i++ {
+		end := r.End
+		if i < n-1 {
+			if exact {
+				// d*(i+1)/n without overflow
+				q, m := d/time.Duration(n), d%time.Duration(n)
+				end = r.Start.Add(q*time.Duration(i+1) + m*time.Duration(i+1)/time.Duration(n))
+			} else {
+				end = r.Lerp(float64(i+1) / float64(n))
+			}
+		}
+		res[i] = Range{Start: start, End: end}
+		start = end
+	}
+	return res
+}
+
+// nanos returns the duration of the range in nanoseconds as float64, without the saturation of Duration.
+func (r Range) nanos() float64 {
+	sec := r.End.Unix() - r.Start.Unix()
+	nsec := r.End.Nanosecond() - r.Start.Nanosecond()
+	return float64(sec)*1e9 + float64(nsec)
+}
+
+// addNanos returns u plus the given number of nanoseconds, which may exceed the range of Duration.
+func addNanos(u UTC, nanos float64) UTC {
+	if math.Abs(nanos) < 1<<62 {
+		return u.Add(time.Duration(nanos))
+	}
+	sec := math.Floor(nanos / 1e9)
+	return New(time.Unix(u.Unix()+int64(sec), int64(u.Nanosecond())+int64(nanos-sec*1e9)))
+}
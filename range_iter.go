@@ -0,0 +1,84 @@
+//go:build go1.23
+
+package utc
+
+import (
+	"iter"
+	"time"
+)
+
+// All returns an iterator over the instants Start, Start+step, Start+2*step... within the range, i.e. before End. The
+// iterator yields nothing if the range is empty or step is not positive.
+//
+//	for u := range r.All(15 * time.Minute) {
+//		...
+//	}
+func (r Range) All(step time.Duration) iter.Seq[UTC] {
+	return func(yield func(UTC) bool) {
+		if step <= 0 {
+			return
+		}
+		for u := r.Start; u.Before(r.End); u = u.Add(step) {
+			if !yield(u) {
+				return
+			}
+		}
+	}
+}
+
+// Days returns an iterator over the days within the range, starting at Start and advancing by one calendar day.
+// Start is usually the start of a day (see StartOfDay) in order to iterate over day partitions.
+func (r Range) Days() iter.Seq[UTC] {
+	return func(yield func(UTC) bool) {
+		for i := 0; ; i++ {
+			u := New(r.Start.Time.AddDate(0, 0, i))
+			if !u.Before(r.End) || !yield(u) {
+				return
+			}
+		}
+	}
+}
+
+// Hours returns an iterator over the hours within the range, starting at Start and advancing by one hour.
+func (r Range) Hours() iter.Seq[UTC] {
+	return r.All(time.Hour)
+}
+
+// MonthsBetween returns an iterator over the months that intersect the range [a, b), in chronological order. Along with
+// each month, it yields the part of [a, b) within the month: the full range of the month except for a partial first
+// month if a is not the start of a month, and a partial last month if b is not. Compare the yielded range with the
+// range of the month to handle partial periods explicitly:
+//
+//	for m, r := range utc.MonthsBetween(a, b) {
+//		if r.Duration() < m.Range().Duration() {
+//			// partial month
+//		}
+//		...
+//	}
+//
+// The iterator yields nothing if b is not after a.
+func MonthsBetween(a, b UTC) iter.Seq2[YearMonth, Range] {
+	return func(yield func(YearMonth, Range) bool) {
+		for m := YearMonthOf(a); a.Before(b); m = m.Next() {
+			end := Earliest(m.Next().Start(), b)
+			if !yield(m, Range{Start: a, End: end}) {
+				return
+			}
+			a = end
+		}
+	}
+}
+
+// YearsBetween returns an iterator over the calendar years that intersect the range [a, b), in chronological order,
+// along with the part of [a, b) within each year - see MonthsBetween.
+func YearsBetween(a, b UTC) iter.Seq2[int, Range] {
+	return func(yield func(int, Range) bool) {
+		for year := a.Year(); a.Before(b); year++ {
+			end := Earliest(YearRange(year).End, b)
+			if !yield(year, Range{Start: a, End: end}) {
+				return
+			}
+			a = end
+		}
+	}
+}
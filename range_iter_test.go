@@ -0,0 +1,130 @@
+//go:build go1.23
+
+package utc_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+func collect(seq func(yield func(utc.UTC) bool)) []string {
+	var res []string
+	for u := range seq {
+		res = append(res, u.String())
+	}
+	return res
+}
+
+func TestRange_All(t *testing.T) {
+	r := utc.NewRange(utc.MustParse("2020-01-01T10:00:00Z"), utc.MustParse("2020-01-01T11:00:00Z"))
+	require.Equal(t, []string{
+		"2020-01-01T10:00:00.000Z",
+		"2020-01-01T10:20:00.000Z",
+		"2020-01-01T10:40:00.000Z",
+	}, collect(r.All(20*time.Minute)))
+
+	// End is excluded
+	require.Len(t, collect(r.All(time.Minute)), 60)
+	require.Len(t, collect(r.All(7*time.Minute)), 9)
+
+	require.Empty(t, collect(r.All(0)))
+	require.Empty(t, collect(r.All(-time.Minute)))
+	require.Empty(t, collect(utc.NewRange(r.End, r.Start).All(time.Minute)))
+
+	// early break
+	count := 0
+	for range r.All(time.Minute) {
+		count++
+		if count == 5 {
+			break
+		}
+	}
+	require.Equal(t, 5, count)
+}
+
+func TestRange_Days(t *testing.T) {
+	r := utc.NewRange(utc.MustParse("2020-02-27"), utc.MustParse("2020-03-02"))
+	require.Equal(t, []string{
+		"2020-02-27T00:00:00.000Z",
+		"2020-02-28T00:00:00.000Z",
+		"2020-02-29T00:00:00.000Z",
+		"2020-03-01T00:00:00.000Z",
+	}, collect(r.Days()))
+
+	r = utc.NewRange(utc.MustParse("2020-02-27T12:00:00Z"), utc.MustParse("2020-02-29T12:00:01Z"))
+	require.Len(t, collect(r.Days()), 3)
+	require.Empty(t, collect(utc.NewRange(r.End, r.Start).Days()))
+}
+
+func TestRange_Hours(t *testing.T) {
+	r := utc.NewRange(utc.MustParse("2020-01-01T22:30:00Z"), utc.MustParse("2020-01-02T01:00:00Z"))
+	require.Equal(t, []string{
+		"2020-01-01T22:30:00.000Z",
+		"2020-01-01T23:30:00.000Z",
+		"2020-01-02T00:30:00.000Z",
+	}, collect(r.Hours()))
+}
+
+func TestMonthsBetween(t *testing.T) {
+	a := utc.MustParse("2020-11-15T12:00:00Z")
+	b := utc.MustParse("2021-02-10T00:00:00Z")
+	var months, ranges []string
+	var partial []bool
+	for m, r := range utc.MonthsBetween(a, b) {
+		months = append(months, m.String())
+		ranges = append(ranges, r.String())
+		partial = append(partial, r.Duration() < m.Range().Duration())
+	}
+	require.Equal(t, []string{"2020-11", "2020-12", "2021-01", "2021-02"}, months)
+	require.Equal(t, []string{
+		"2020-11-15T12:00:00.000Z/2020-12-01T00:00:00.000Z",
+		"2020-12-01T00:00:00.000Z/2021-01-01T00:00:00.000Z",
+		"2021-01-01T00:00:00.000Z/2021-02-01T00:00:00.000Z",
+		"2021-02-01T00:00:00.000Z/2021-02-10T00:00:00.000Z",
+	}, ranges)
+	require.Equal(t, []bool{true, false, false, true}, partial)
+
+	// full months only
+	count := 0
+	for m, r := range utc.MonthsBetween(utc.MustParse("2020-01-01"), utc.MustParse("2020-03-01")) {
+		require.Equal(t, m.Range().Duration(), r.Duration())
+		count++
+	}
+	require.Equal(t, 2, count)
+
+	for range utc.MonthsBetween(b, a) {
+		require.Fail(t, "unexpected month")
+	}
+
+	// early break
+	count = 0
+	for range utc.MonthsBetween(a, b) {
+		count++
+		break
+	}
+	require.Equal(t, 1, count)
+}
+
+func TestYearsBetween(t *testing.T) {
+	a := utc.MustParse("2019-07-01")
+	b := utc.MustParse("2021-01-01")
+	var years []int
+	var ranges []string
+	for y, r := range utc.YearsBetween(a, b) {
+		years = append(years, y)
+		ranges = append(ranges, r.String())
+	}
+	require.Equal(t, []int{2019, 2020}, years)
+	require.Equal(t, []string{
+		"2019-07-01T00:00:00.000Z/2020-01-01T00:00:00.000Z",
+		"2020-01-01T00:00:00.000Z/2021-01-01T00:00:00.000Z",
+	}, ranges)
+
+	for range utc.YearsBetween(a, a) {
+		require.Fail(t, "unexpected year")
+	}
+}
@@ -0,0 +1,96 @@
+package utc_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+func TestRange(t *testing.T) {
+	start := utc.MustParse(oneBillionString)
+	r := utc.NewRange(start, start.Add(time.Hour))
+
+	require.Equal(t, time.Hour, r.Duration())
+	require.False(t, r.IsEmpty())
+	require.True(t, r.Contains(start))
+	require.True(t, r.Contains(start.Add(time.Hour-1)))
+	require.False(t, r.Contains(start.Add(time.Hour)))
+	require.False(t, r.Contains(start.Add(-1)))
+	require.Equal(t, "2001-09-09T01:46:40.000Z/2001-09-09T02:46:40.000Z", r.String())
+
+	require.True(t, utc.NewRange(start, start).IsEmpty())
+	require.True(t, utc.NewRange(start, start.Add(-1)).IsEmpty())
+
+	bts, err := json.Marshal(r)
+	require.NoError(t, err)
+	require.Equal(t, `{"start":"2001-09-09T01:46:40.000Z","end":"2001-09-09T02:46:40.000Z"}`, string(bts))
+	var r2 utc.Range
+	require.NoError(t, json.Unmarshal(bts, &r2))
+	require.Equal(t, r.String(), r2.String())
+}
+
+func TestRange_Lerp(t *testing.T) {
+	start := utc.MustParse("2020-01-01T00:00:00Z")
+	r := utc.NewRange(start, start.Add(time.Hour))
+
+	require.Equal(t, start, r.Lerp(0))
+	require.Equal(t, r.End, r.Lerp(1))
+	require.Equal(t, start.Add(30*time.Minute), r.Lerp(0.5))
+	require.Equal(t, start.Add(-time.Hour), r.Lerp(-1))
+	require.Equal(t, start.Add(2*time.Hour), r.Lerp(2))
+
+	require.Equal(t, 0.0, r.Fraction(start))
+	require.Equal(t, 1.0, r.Fraction(r.End))
+	require.Equal(t, 0.25, r.Fraction(start.Add(15*time.Minute)))
+	require.Equal(t, -1.0, r.Fraction(start.Add(-time.Hour)))
+	require.Equal(t, 2.0, r.Fraction(start.Add(2*time.Hour)))
+
+	// empty range
+	empty := utc.NewRange(start, start)
+	require.Equal(t, 0.0, empty.Fraction(start.Add(-time.Second)))
+	require.Equal(t, 1.0, empty.Fraction(start))
+
+	// ranges beyond the range of time.Duration
+	all := utc.NewRange(utc.Min, utc.Max)
+	mid := all.Lerp(0.5)
+	require.WithinDuration(t, utc.MustParse("5000-01-01").Time, mid.Time, 48*time.Hour)
+	require.InDelta(t, 0.5, all.Fraction(mid), 1e-9)
+	require.InDelta(t, 0.5, all.Fraction(utc.MustParse("5000-01-01")), 1e-3)
+}
+
+func TestRange_Split(t *testing.T) {
+	start := utc.MustParse("2020-01-01T00:00:00Z")
+	r := utc.NewRange(start, start.Add(time.Hour))
+
+	require.Nil(t, r.Split(0))
+	require.Nil(t, utc.NewRange(start, start).Split(3))
+	require.Equal(t, []utc.Range{r}, r.Split(1))
+
+	parts := r.Split(4)
+	require.Len(t, parts, 4)
+	for i, p := range parts {
+		require.Equal(t, 15*time.Minute, p.Duration())
+		require.Equal(t, start.Add(time.Duration(i)*15*time.Minute), p.Start)
+	}
+	require.Equal(t, r.End, parts[3].End)
+
+	// durations differ by at most a nanosecond
+	r = utc.NewRange(start, start.Add(10))
+	parts = r.Split(3)
+	require.Equal(t, []time.Duration{3, 3, 4}, []time.Duration{parts[0].Duration(), parts[1].Duration(), parts[2].Duration()})
+	require.Equal(t, r.End, parts[2].End)
+
+	// contiguous over ranges beyond the range of time.Duration
+	all := utc.NewRange(utc.Min, utc.Max)
+	parts = all.Split(7)
+	require.Equal(t, utc.Min, parts[0].Start)
+	require.Equal(t, utc.Max, parts[6].End)
+	for i := 1; i < len(parts); i++ {
+		require.Equal(t, parts[i-1].End, parts[i].Start)
+		require.False(t, parts[i].IsEmpty())
+	}
+}
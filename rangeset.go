@@ -0,0 +1,79 @@
+package utc
+
+import (
+	"sort"
+)
+
+// RangeSet is a set of instants represented as sorted, non-overlapping and non-adjacent ranges: overlapping and
+// adjacent ranges are merged when added. Use it to track the periods covered by data, e.g. the segments of a
+// recording or the windows of monitoring data, and to find the uncovered periods with Gaps.
+//
+// The zero value is an empty set. A RangeSet is not safe for concurrent use.
+type RangeSet struct {
+	ranges []Range
+}
+
+// NewRangeSet creates a RangeSet with the given ranges.
+func NewRangeSet(ranges ...Range) *RangeSet {
+	s := &RangeSet{}
+	s.Add(ranges...)
+	return s
+}
+
+// Add adds the given ranges to the set. Empty ranges are ignored.
+func (s *RangeSet) Add(ranges ...Range) {
+	for _, r := range ranges {
+		if r.IsEmpty() {
+			continue
+		}
+		// the first range that ends at or after the start of r, i.e. the first that may overlap or touch r
+		i := sort.Search(len(s.ranges), func(i int) bool { return !s.ranges[i].End.Before(r.Start) })
+		// the first range that starts after the end of r
+		j := sort.Search(len(s.ranges), func(i int) bool { return s.ranges[i].Start.After(r.End) })
+		if i < j {
+			// merge with the ranges [i, j)
+			r.Start = Earliest(r.Start, s.ranges[i].Start)
+			r.End = Latest(r.End, s.ranges[j-1].End)
+		}
+		s.ranges = append(s.ranges[:i], append([]Range{r}, s.ranges[j:]...)...)
+	}
+}
+
+// Ranges returns the ranges of the set in chronological order.
+func (s *RangeSet) Ranges() []Range {
+	return append([]Range(nil), s.ranges...)
+}
+
+// Len returns the number of ranges of the set.
+func (s *RangeSet) Len() int {
+	return len(s.ranges)
+}
+
+// Contains returns true if the given instant is in one of the ranges of the set.
+func (s *RangeSet) Contains(u UTC) bool {
+	i := sort.Search(len(s.ranges), func(i int) bool { return s.ranges[i].End.After(u) })
+	return i < len(s.ranges) && s.ranges[i].Contains(u)
+}
+
+// Gaps returns the periods within the given range that are not covered by the set, in chronological order, e.g. the
+// missing segments or outage windows in monitoring data. It returns nil if the range is empty or fully covered, and the
+// range itself if the set does not intersect it.
+func (s *RangeSet) Gaps(within Range) []Range {
+	if within.IsEmpty() {
+		return nil
+	}
+	var res []Range
+	start := within.Start
+	i := sort.Search(len(s.ranges), func(i int) bool { return s.ranges[i].End.After(within.Start) })
+	for ; i < len(s.ranges) && s.ranges[i].Start.Before(within.End); i++ {
+		r := s.ranges[i]
+		if r.Start.After(start) {
+			res = append(res, Range{Start: start, End: r.Start})
+		}
+		start = Latest(start, r.End)
+	}
+	if within.End.After(start) {
+		res = append(res, Range{Start: start, End: within.End})
+	}
+	return res
+}
@@ -0,0 +1,74 @@
+package utc_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+func TestRangeSet(t *testing.T) {
+	t0 := utc.MustParse("2020-01-01T00:00:00Z")
+	at := func(min int) utc.UTC { return t0.Add(time.Duration(min) * time.Minute) }
+	rng := func(from, to int) utc.Range { return utc.NewRange(at(from), at(to)) }
+
+	s := &utc.RangeSet{}
+	require.Equal(t, 0, s.Len())
+	require.Empty(t, s.Ranges())
+	require.False(t, s.Contains(t0))
+
+	s.Add(rng(10, 20), rng(30, 40), rng(5, 5))
+	require.Equal(t, []utc.Range{rng(10, 20), rng(30, 40)}, s.Ranges())
+
+	// overlapping and adjacent ranges are merged
+	s.Add(rng(15, 25))
+	require.Equal(t, []utc.Range{rng(10, 25), rng(30, 40)}, s.Ranges())
+	s.Add(rng(40, 45))
+	require.Equal(t, []utc.Range{rng(10, 25), rng(30, 45)}, s.Ranges())
+	s.Add(rng(0, 5))
+	require.Equal(t, []utc.Range{rng(0, 5), rng(10, 25), rng(30, 45)}, s.Ranges())
+	s.Add(rng(20, 35))
+	require.Equal(t, []utc.Range{rng(0, 5), rng(10, 45)}, s.Ranges())
+	s.Add(rng(-10, 50))
+	require.Equal(t, []utc.Range{rng(-10, 50)}, s.Ranges())
+
+	s = utc.NewRangeSet(rng(30, 40), rng(10, 20))
+	require.Equal(t, 2, s.Len())
+	require.True(t, s.Contains(at(10)))
+	require.True(t, s.Contains(at(35)))
+	require.False(t, s.Contains(at(20)))
+	require.False(t, s.Contains(at(25)))
+	require.False(t, s.Contains(at(40)))
+
+	// the returned ranges are a copy
+	s.Ranges()[0] = rng(0, 1)
+	require.Equal(t, rng(10, 20), s.Ranges()[0])
+}
+
+func TestRangeSet_Gaps(t *testing.T) {
+	t0 := utc.MustParse("2020-01-01T00:00:00Z")
+	at := func(min int) utc.UTC { return t0.Add(time.Duration(min) * time.Minute) }
+	rng := func(from, to int) utc.Range { return utc.NewRange(at(from), at(to)) }
+
+	s := utc.NewRangeSet(rng(10, 20), rng(30, 40), rng(50, 60))
+	for _, test := range []struct {
+		within utc.Range
+		want   []utc.Range
+	}{
+		{rng(0, 70), []utc.Range{rng(0, 10), rng(20, 30), rng(40, 50), rng(60, 70)}},
+		{rng(10, 60), []utc.Range{rng(20, 30), rng(40, 50)}},
+		{rng(15, 35), []utc.Range{rng(20, 30)}},
+		{rng(12, 18), nil},
+		{rng(20, 30), []utc.Range{rng(20, 30)}},
+		{rng(22, 28), []utc.Range{rng(22, 28)}},
+		{rng(65, 70), []utc.Range{rng(65, 70)}},
+		{rng(30, 30), nil},
+		{rng(40, 30), nil},
+	} {
+		require.Equal(t, test.want, s.Gaps(test.within), test.within.String())
+	}
+
+	require.Equal(t, []utc.Range{rng(0, 10)}, (&utc.RangeSet{}).Gaps(rng(0, 10)))
+}
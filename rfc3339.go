@@ -0,0 +1,146 @@
+package utc
+
+import (
+	"time"
+
+	"github.com/eluv-io/errors-go"
+)
+
+// ValidateRFC3339 validates that s is a date-time according to the grammar of RFC 3339 section 5.6, without any of the
+// lenience of FromString. Use it to check the conformance of timestamps produced by other systems, e.g. in protocol
+// tests and fuzzing.
+//
+// In particular, it requires
+//   - the separator "T" (or "t") between date and time, not a space,
+//   - at least one digit in the fraction of seconds, if present,
+//   - a time offset "Z" (or "z") or a numeric offset with hours in [00, 23] and minutes in [00, 59],
+//   - the day to exist in the month and year,
+//
+// and accepts the seconds value 60 only for a leap second, i.e. at 23:59:60 UTC on the last day of a month, taking the
+// offset into account - see RFC 3339 section 5.7. It does not check that a leap second was actually inserted at that
+// instant.
+func ValidateRFC3339(s string) error {
+	if reason, pos := validateRFC3339(s); reason != "" {
+		return errors.E("ValidateRFC3339", errors.K.Invalid, ErrParse, "reason", reason, "position", pos, "value", s)
+	}
+	return nil
+}
+
+// validateRFC3339 returns the reason and position of the first violation of RFC 3339 in s, or an empty reason if s is
+// valid.
+func validateRFC3339(s string) (string, int) {
+	// date-fullyear "-" date-month "-" date-mday
+	year, ok := rfc3339Digits(s, 0, 4)
+	if !ok {
+		return "invalid year", 0
+	}
+	if !rfc3339Char(s, 4, '-') {
+		return "expected '-'", 4
+	}
+	month, ok := rfc3339Digits(s, 5, 2)
+	if !ok || month < 1 || month > 12 {
+		return "invalid month", 5
+	}
+	if !rfc3339Char(s, 7, '-') {
+		return "expected '-'", 7
+	}
+	day, ok := rfc3339Digits(s, 8, 2)
+	if !ok || day < 1 || day > DaysInMonth(year, time.Month(month)) {
+		return "invalid day", 8
+	}
+
+	if !rfc3339Char(s, 10, 'T') && !rfc3339Char(s, 10, 't') {
+		return "expected 'T'", 10
+	}
+
+	// time-hour ":" time-minute ":" time-second [time-secfrac]
+	hour, ok := rfc3339Digits(s, 11, 2)
+	if !ok || hour > 23 {
+		return "invalid hour", 11
+	}
+	if !rfc3339Char(s, 13, ':') {
+		return "expected ':'", 13
+	}
+	minute, ok := rfc3339Digits(s, 14, 2)
+	if !ok || minute > 59 {
+		return "invalid minute", 14
+	}
+	if !rfc3339Char(s, 16, ':') {
+		return "expected ':'", 16
+	}
+	second, ok := rfc3339Digits(s, 17, 2)
+	if !ok || second > 60 {
+		return "invalid second", 17
+	}
+	pos := 19
+	if rfc3339Char(s, pos, '.') {
+		pos++
+		start := pos
+		for pos < len(s) && s[pos] >= '0' && s[pos] <= '9' {
+			pos++
+		}
+		if pos == start {
+			return "missing fraction digits", start
+		}
+	}
+
+	// time-offset
+	offset := 0
+	switch {
+	case rfc3339Char(s, pos, 'Z') || rfc3339Char(s, pos, 'z'):
+		pos++
+	case rfc3339Char(s, pos, '+') || rfc3339Char(s, pos, '-'):
+		sign := 1
+		if s[pos] == '-' {
+			sign = -1
+		}
+		oh, ok := rfc3339Digits(s, pos+1, 2)
+		if !ok || oh > 23 {
+			return "invalid offset hour", pos + 1
+		}
+		if !rfc3339Char(s, pos+3, ':') {
+			return "expected ':'", pos + 3
+		}
+		om, ok := rfc3339Digits(s, pos+4, 2)
+		if !ok || om > 59 {
+			return "invalid offset minute", pos + 4
+		}
+		offset = sign * (oh*60 + om)
+		pos += 6
+	default:
+		return "missing time offset", pos
+	}
+	if pos != len(s) {
+		return "trailing characters", pos
+	}
+
+	if second == 60 {
+		// the leap second is 23:59:60 UTC on the last day of a month
+		t := time.Date(year, time.Month(month), day, hour, minute, 0, 0, time.UTC).Add(-time.Duration(offset) * time.Minute)
+		if t.Hour() != 23 || t.Minute() != 59 || t.Day() != DaysInMonth(t.Year(), t.Month()) {
+			return "invalid leap second", 17
+		}
+	}
+	return "", 0
+}
+
+// rfc3339Digits parses n digits at the given position of s.
+func rfc3339Digits(s string, pos, n int) (int, bool) {
+	if pos+n > len(s) {
+		return 0, false
+	}
+	v := 0
+	for i := pos; i < pos+n; i++ {
+		c := s[i]
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+		v = v*10 + int(c-'0')
+	}
+	return v, true
+}
+
+// rfc3339Char returns true if s has the given character at the given position.
+func rfc3339Char(s string, pos int, c byte) bool {
+	return pos < len(s) && s[pos] == c
+}
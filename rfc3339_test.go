@@ -0,0 +1,84 @@
+package utc_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+func TestValidateRFC3339(t *testing.T) {
+	for _, s := range []string{
+		"1985-04-12T23:20:50.52Z",
+		"1996-12-19T16:39:57-08:00",
+		"1990-12-31T23:59:60Z",
+		"1990-12-31T15:59:60-08:00",
+		"1937-01-01T12:00:27.87+00:20",
+		"2021-09-09t01:46:40z",
+		"2021-09-09T01:46:40.123456789012Z",
+		"2020-02-29T00:00:00Z",
+		"2000-02-29T00:00:00Z",
+		"0000-01-01T00:00:00Z",
+		"9999-12-31T23:59:59.999-00:00",
+		"2021-06-30T23:59:60.5Z",
+		"2021-07-01T01:59:60+02:00",
+		"2021-09-09T01:46:40+23:59",
+	} {
+		require.NoError(t, utc.ValidateRFC3339(s), s)
+	}
+}
+
+func TestValidateRFC3339_invalid(t *testing.T) {
+	for _, test := range []struct {
+		s      string
+		reason string
+	}{
+		{"", "invalid year"},
+		{"21-09-09T01:46:40Z", "invalid year"},
+		{"2021/09-09T01:46:40Z", "expected '-'"},
+		{"2021-13-09T01:46:40Z", "invalid month"},
+		{"2021-00-09T01:46:40Z", "invalid month"},
+		{"2021-09-31T01:46:40Z", "invalid day"},
+		{"2021-02-29T01:46:40Z", "invalid day"},
+		{"1900-02-29T01:46:40Z", "invalid day"},
+		{"2021-09-00T01:46:40Z", "invalid day"},
+		{"2021-09-09 01:46:40Z", "expected 'T'"},
+		{"2021-09-09", "expected 'T'"},
+		{"2021-09-09T24:00:00Z", "invalid hour"},
+		{"2021-09-09T01:60:00Z", "invalid minute"},
+		{"2021-09-09T01:46Z", "expected ':'"},
+		{"2021-09-09T01:46:61Z", "invalid second"},
+		{"2021-09-09T01:46:4Z", "invalid second"},
+		{"2021-09-09T01:46:40.Z", "missing fraction digits"},
+		{"2021-09-09T01:46:40", "missing time offset"},
+		{"2021-09-09T01:46:40+0100", "expected ':'"},
+		{"2021-09-09T01:46:40+24:00", "invalid offset hour"},
+		{"2021-09-09T01:46:40+01:60", "invalid offset minute"},
+		{"2021-09-09T01:46:40+01", "expected ':'"},
+		{"2021-09-09T01:46:40ZZ", "trailing characters"},
+		{"2021-09-09T01:46:40Z ", "trailing characters"},
+		{"2021-09-09T23:59:60Z", "invalid leap second"},
+		{"2021-12-31T23:58:60Z", "invalid leap second"},
+		{"2021-12-31T23:59:60+01:00", "invalid leap second"},
+	} {
+		err := utc.ValidateRFC3339(test.s)
+		require.Error(t, err, test.s)
+		require.Contains(t, err.Error(), test.reason, test.s)
+	}
+}
+
+func FuzzValidateRFC3339(f *testing.F) {
+	f.Add("2021-09-09T01:46:40.123Z")
+	f.Add("1990-12-31T15:59:60-08:00")
+	f.Add("2021-09-09t01:46:40z")
+	f.Fuzz(func(t *testing.T, s string) {
+		if utc.ValidateRFC3339(s) != nil {
+			return
+		}
+		// everything valid is accepted by the lenient parser, which requires upper case 'T' and 'Z'
+		_, err := utc.FromString(strings.ToUpper(s))
+		require.NoError(t, err, s)
+	})
+}
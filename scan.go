@@ -0,0 +1,45 @@
+package utc
+
+import (
+	"fmt"
+	"io"
+	"unicode"
+
+	"github.com/eluv-io/errors-go"
+)
+
+// ScanInto returns a fmt.Scanner that reads an ISO 8601 timestamp into u, for use with fmt.Sscan, fmt.Fscan and
+// similar functions:
+//
+//	var u utc.UTC
+//	_, err := fmt.Sscan("2020-01-01T10:00:00.000Z", utc.ScanInto(&u))
+//
+// UTC itself cannot implement fmt.Scanner, since its Scan method implements the sql.Scanner interface. The timestamp
+// is read up to the next white space and parsed with FromString. The verbs %v and %s are supported.
+func ScanInto(u *UTC) fmt.Scanner {
+	return fmtScanner{u: u}
+}
+
+type fmtScanner struct {
+	u *UTC
+}
+
+// Scan implements the fmt.Scanner interface.
+func (s fmtScanner) Scan(state fmt.ScanState, verb rune) error {
+	if verb != 'v' && verb != 's' {
+		return errors.E("UTC.Scan", errors.K.Invalid, ErrParse, "reason", "unsupported verb", "verb", string(verb))
+	}
+	token, err := state.Token(true, func(r rune) bool { return !unicode.IsSpace(r) })
+	if err != nil {
+		return err
+	}
+	if len(token) == 0 {
+		return io.ErrUnexpectedEOF
+	}
+	res, err := FromString(string(token))
+	if err != nil {
+		return errors.E("UTC.Scan", errors.K.Invalid, err)
+	}
+	*s.u = res
+	return nil
+}
@@ -0,0 +1,45 @@
+package utc_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+func TestScanInto(t *testing.T) {
+	var u1, u2 utc.UTC
+	var name string
+	n, err := fmt.Sscan("  2020-01-01T10:00:00.000Z start 2020-01-02 ", utc.ScanInto(&u1), &name, utc.ScanInto(&u2))
+	require.NoError(t, err)
+	require.Equal(t, 3, n)
+	require.Equal(t, "2020-01-01T10:00:00.000Z", u1.String())
+	require.Equal(t, "start", name)
+	require.Equal(t, "2020-01-02T00:00:00.000Z", u2.String())
+
+	n, err = fmt.Sscanf("at 2020-01-01T10:00:00+02:00", "at %v", utc.ScanInto(&u1))
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	require.Equal(t, "2020-01-01T08:00:00.000Z", u1.String())
+
+	var lines []utc.UTC
+	r := strings.NewReader("2020-01-01\n2020-01-02\n")
+	for {
+		var u utc.UTC
+		if _, err := fmt.Fscanln(r, utc.ScanInto(&u)); err != nil {
+			break
+		}
+		lines = append(lines, u)
+	}
+	require.Len(t, lines, 2)
+
+	_, err = fmt.Sscan("blub", utc.ScanInto(&u1))
+	require.Error(t, err)
+	_, err = fmt.Sscan("", utc.ScanInto(&u1))
+	require.Error(t, err)
+	_, err = fmt.Sscanf("2020-01-01", "%d", utc.ScanInto(&u1))
+	require.Error(t, err)
+}
@@ -0,0 +1,23 @@
+package utc
+
+// JSONSchemaDateTime is the JSON Schema (and OpenAPI) fragment describing the JSON representation of UTC values.
+const JSONSchemaDateTime = `{"type":"string","format":"date-time"}`
+
+// JSONSchema returns the JSON Schema fragment describing the JSON representation of UTC values as generic map, ready to
+// be embedded in a larger schema: {"type": "string", "format": "date-time"}.
+//
+// Schema generators based on reflection describe UTC as object with the members of the embedded time.Time, since UTC
+// is a struct. For kin-openapi, use the schema customizer of the github.com/eluv-io/utc-go/openapiutc submodule. For
+// swaggo, either annotate UTC fields with
+//
+//	Created utc.UTC `json:"created" swaggertype:"string" format:"date-time"`
+//
+// or add the following line to the overrides file (.swaggo) of the project:
+//
+//	replace github.com/eluv-io/utc-go.UTC string
+func JSONSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type":   "string",
+		"format": "date-time",
+	}
+}
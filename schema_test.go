@@ -0,0 +1,16 @@
+package utc_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+func TestJSONSchema(t *testing.T) {
+	jsn, err := json.Marshal(utc.JSONSchema())
+	require.NoError(t, err)
+	require.JSONEq(t, utc.JSONSchemaDateTime, string(jsn))
+}
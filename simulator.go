@@ -0,0 +1,130 @@
+package utc
+
+import (
+	"time"
+)
+
+// Simulator runs code scheduled in virtual time. It owns a TestClock and advances it from one timer to the next, so
+// that days of scheduler behavior can be simulated in milliseconds. Timers fire synchronously in the goroutine running
+// the simulation, in the order of their instants and, for equal instants, in the order of their registration - hence
+// the interleaving of callbacks is deterministic.
+//
+// Callbacks are scheduled with At, After and Every, or directly as timers of the simulator's clock. Code that uses the
+// global clock (Now, AfterFunc, WaitUntil, ContextWithDeadline, TimerWheel...) participates in the simulation if the
+// simulator's clock is installed as global clock with MockNow.
+//
+// A Simulator is not meant to be used concurrently: callbacks that start goroutines waiting for timers make the
+// simulation non-deterministic.
+type Simulator struct {
+	clock TestClock
+	start UTC
+}
+
+// NewSimulator creates a Simulator with its clock set to the given start time, or to the current wall clock rounded
+// to the millisecond if Zero.
+func NewSimulator(start UTC) *Simulator {
+	if start.IsZero() {
+		start = WallNowMs()
+	}
+	return &Simulator{clock: NewWallClock(start), start: start}
+}
+
+// Clock returns the clock of the simulator.
+func (s *Simulator) Clock() TestClock {
+	return s.clock
+}
+
+// Now returns the current virtual time.
+func (s *Simulator) Now() UTC {
+	return s.clock.Now()
+}
+
+// Elapsed returns the virtual time elapsed since the start of the simulation.
+func (s *Simulator) Elapsed() time.Duration {
+	return s.clock.Now().Sub(s.start)
+}
+
+// MockNow installs the simulator's clock as global clock and returns a function that restores the default clock.
+func (s *Simulator) MockNow() (reset func()) {
+	s.clock.MockNow()
+	return s.clock.UnmockNow
+}
+
+// At schedules fn to be called when the virtual time reaches the given instant. A function scheduled for an instant
+// that is not after the current virtual time is called immediately. The returned function cancels the call.
+func (s *Simulator) At(u UTC, fn func()) (stop func() bool) {
+	return s.clock.AfterFunc(u, fn)
+}
+
+// After schedules fn to be called after the given duration of virtual time.
+func (s *Simulator) After(d time.Duration, fn func()) (stop func() bool) {
+	return s.At(s.Now().Add(d), fn)
+}
+
+// Every schedules fn to be called periodically with the given interval of virtual time, starting one interval from
+// now. The returned function stops the periodic calls. It panics if the interval is not positive.
+func (s *Simulator) Every(d time.Duration, fn func()) (stop func()) {
+	if d <= 0 {
+		panic("utc.Simulator.Every: non-positive interval")
+	}
+	stopped := false
+	var cancel func() bool
+	var schedule func(at UTC)
+	schedule = func(at UTC) {
+		cancel = s.At(at, func() {
+			if stopped {
+				return
+			}
+			schedule(at.Add(d))
+			fn()
+		})
+	}
+	schedule(s.Now().Add(d))
+	return func() {
+		stopped = true
+		cancel()
+	}
+}
+
+// Step advances the virtual time to the earliest pending timer and fires all timers due at that instant. It returns
+// false if there are no pending timers.
+func (s *Simulator) Step() bool {
+	next, ok := s.clock.timers.next()
+	if !ok {
+		return false
+	}
+	if next.After(s.clock.Now()) {
+		s.clock.Set(next)
+	} else {
+		s.clock.timers.fireDue(s.clock)
+	}
+	return true
+}
+
+// RunFor advances the virtual time by the given budget, firing all timers in between in order. The virtual time is
+// start + budget afterwards.
+func (s *Simulator) RunFor(budget time.Duration) {
+	s.RunUntil(func() bool { return false }, budget)
+}
+
+// RunUntil advances the virtual time from timer to timer until the predicate holds or the given budget of virtual
+// time is exhausted. The predicate is evaluated before the first step and after each step. It returns true if the
+// predicate holds, in which case the virtual time is that of the last step. Otherwise, the virtual time is advanced to
+// the end of the budget and the result is false.
+func (s *Simulator) RunUntil(pred func() bool, budget time.Duration) bool {
+	end := s.Now().Add(budget)
+	for {
+		if pred() {
+			return true
+		}
+		next, ok := s.clock.timers.next()
+		if !ok || next.After(end) {
+			break
+		}
+		s.Step()
+	}
+	if end.After(s.Now()) {
+		s.clock.Set(end)
+	}
+	return pred()
+}
@@ -0,0 +1,101 @@
+package utc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+func TestSimulator(t *testing.T) {
+	start := utc.MustParse("2020-01-01T00:00:00Z")
+	sim := utc.NewSimulator(start)
+	require.Equal(t, start, sim.Now())
+
+	var log []string
+	record := func(s string) func() {
+		return func() { log = append(log, sim.Now().Format("15:04")+" "+s) }
+	}
+	sim.After(2*time.Hour, record("b"))
+	sim.After(time.Hour, record("a1"))
+	sim.After(time.Hour, record("a2"))
+	stop := sim.After(3*time.Hour, record("cancelled"))
+	require.True(t, stop())
+	sim.At(start.Add(90*time.Minute), func() {
+		record("c")()
+		sim.After(0, record("c-now"))
+		sim.After(time.Minute, record("c-later"))
+	})
+
+	require.True(t, sim.Step())
+	require.Equal(t, []string{"01:00 a1", "01:00 a2"}, log)
+
+	sim.RunFor(24 * time.Hour)
+	require.Equal(t, []string{
+		"01:00 a1",
+		"01:00 a2",
+		"01:30 c",
+		"01:30 c-now",
+		"01:31 c-later",
+		"02:00 b",
+	}, log)
+	require.Equal(t, 25*time.Hour, sim.Elapsed())
+	require.False(t, sim.Step())
+}
+
+func TestSimulator_RunUntil(t *testing.T) {
+	sim := utc.NewSimulator(utc.MustParse("2020-01-01T00:00:00Z"))
+
+	count := 0
+	stop := sim.Every(10*time.Minute, func() { count++ })
+
+	// simulate days in milliseconds
+	ok := sim.RunUntil(func() bool { return count == 6*24*7 }, 30*24*time.Hour)
+	require.True(t, ok)
+	require.Equal(t, 7*24*time.Hour, sim.Elapsed())
+
+	// budget exhausted
+	ok = sim.RunUntil(func() bool { return count == 10_000 }, time.Hour)
+	require.False(t, ok)
+	require.Equal(t, 7*24*time.Hour+time.Hour, sim.Elapsed())
+	require.Equal(t, 6*24*7+6, count)
+
+	stop()
+	sim.RunFor(time.Hour)
+	require.Equal(t, 6*24*7+6, count)
+	require.False(t, sim.Step())
+}
+
+func TestSimulator_MockNow(t *testing.T) {
+	sim := utc.NewSimulator(utc.Zero)
+	reset := sim.MockNow()
+	defer reset()
+
+	start := utc.Now()
+	require.Equal(t, sim.Now(), start)
+
+	// code using the global clock participates in the simulation
+	ctx, cancel := utc.ContextWithTimeout(context.Background(), time.Hour)
+	defer cancel()
+	var expired utc.UTC
+	ticker := utc.NewJitteredTicker(time.Minute, 0.1, nil)
+	defer ticker.Stop()
+	ticks := 0
+	sim.Every(time.Second, func() {
+		select {
+		case <-ticker.C:
+			ticks++
+		default:
+		}
+		if expired.IsZero() && ctx.Err() != nil {
+			expired = utc.Now()
+		}
+	})
+
+	require.True(t, sim.RunUntil(func() bool { return !expired.IsZero() }, 24*time.Hour))
+	require.Equal(t, start.Add(time.Hour), expired)
+	require.InDelta(t, 60, ticks, 6)
+}
@@ -0,0 +1,87 @@
+package utc
+
+import (
+	"time"
+
+	"github.com/eluv-io/errors-go"
+)
+
+// Slug formats produced by FormatSlug and FormatSlugNano: the ISO 8601 basic format, without dashes and colons.
+const (
+	SlugFormat     = "20060102T150405.000Z"
+	SlugFormatNano = "20060102T150405.000000000Z"
+)
+
+// slugBase32Len is the length of the slugs produced by FormatSlugBase32: 13 digits of 5 bits hold 64 bits.
+const slugBase32Len = 13
+
+// FormatSlug formats the time as compact slug in ISO 8601 basic format with milliseconds: 20210909T014640.123Z. Slugs
+// contain only characters that are safe in file names, object keys and URLs, have a fixed length and sort
+// lexicographically in chronological order. They are the same as String() without dashes and colons, and like String(),
+// clamp years to [0000, 9999] and truncate the time to milliseconds: ParseSlug(u.FormatSlug()) is equal to
+// u.Truncate(time.Millisecond) for u in [Min, Max].
+func (u UTC) FormatSlug() string {
+	return u.clampISO8601().Format(SlugFormat)
+}
+
+// FormatSlugNano formats the time as slug with nanoseconds: 20210909T014640.123456789Z - see FormatSlug. Slugs of
+// FormatSlug and FormatSlugNano do not sort correctly against each other.
+func (u UTC) FormatSlugNano() string {
+	return u.clampISO8601().Format(SlugFormatNano)
+}
+
+// FormatSlugBase32 formats the time as slug of 13 characters that encodes the milliseconds since the unix epoch in the
+// "extended hex" base32 alphabet (RFC 4648) in lower case: 0-9, a-v. The slug is shorter than the one of FormatSlug and
+// is case-insensitive, but not human-readable. Like FormatSlug, it has a fixed length, sorts in chronological order and
+// truncates the time to milliseconds.
+func (u UTC) FormatSlugBase32() string {
+	// flip the sign bit so that negative values sort before positive values
+	n := uint64(u.UnixMilli()) ^ (1 << 63)
+	var buf [slugBase32Len]byte
+	return string(appendDigits(buf[:0], n, base32Alphabet, slugBase32Len))
+}
+
+// ParseSlug parses a slug produced by FormatSlug, FormatSlugNano or FormatSlugBase32.
+func ParseSlug(s string) (UTC, error) {
+	if len(s) == slugBase32Len {
+		u, ok := parseSlugBase32(s)
+		if !ok {
+			return Zero, errors.E("ParseSlug", errors.K.Invalid, ErrParse, "reason", "invalid base32 slug", "slug", s)
+		}
+		return u, nil
+	}
+	// time.Parse accepts any number of fractional digits after the seconds
+	u, err := Parse("20060102T150405Z", s)
+	if err != nil {
+		return Zero, errors.E("ParseSlug", errors.K.Invalid, WrapSentinel(ErrParse, err), "slug", s)
+	}
+	return u, nil
+}
+
+// MustParseSlug parses a slug like ParseSlug, but panics in case of errors.
+func MustParseSlug(s string) UTC {
+	u, err := ParseSlug(s)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
+func parseSlugBase32(s string) (UTC, bool) {
+	n, ok := parseDigits(s, &Base32Millis.digits, 32)
+	if !ok {
+		return Zero, false
+	}
+	return NewWall(time.UnixMilli(int64(n ^ (1 << 63)))), true
+}
+
+// clampISO8601 returns the time clamped to [Min, Max], the range of the ISO 8601 formats.
+func (u UTC) clampISO8601() UTC {
+	switch {
+	case u.Before(Min):
+		return Min
+	case u.After(Max):
+		return Max
+	}
+	return u
+}
@@ -0,0 +1,78 @@
+package utc_test
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+func TestFormatSlug(t *testing.T) {
+	u := utc.MustParse("2021-09-09T01:46:40.123456789Z")
+	require.Equal(t, "20210909T014640.123Z", u.FormatSlug())
+	require.Equal(t, "20210909T014640.123456789Z", u.FormatSlugNano())
+	require.Len(t, u.FormatSlugBase32(), 13)
+
+	require.Equal(t, "00010101T000000.000Z", utc.Zero.FormatSlug())
+	require.Equal(t, "99991231T235959.999Z", utc.Max.FormatSlug())
+	require.Equal(t, "99991231T235959.999Z", utc.Max.Add(time.Hour).FormatSlug())
+
+	for _, slug := range []string{u.FormatSlug(), u.FormatSlugBase32()} {
+		parsed, err := utc.ParseSlug(slug)
+		require.NoError(t, err, slug)
+		require.Equal(t, u.Truncate(time.Millisecond), parsed, slug)
+	}
+	require.Equal(t, u, utc.MustParseSlug(u.FormatSlugNano()))
+
+	// base32 slugs are case-insensitive
+	upper := []byte(u.FormatSlugBase32())
+	for i, c := range upper {
+		if c >= 'a' && c <= 'z' {
+			upper[i] = c - 'a' + 'A'
+		}
+	}
+	require.Equal(t, u.Truncate(time.Millisecond), utc.MustParseSlug(string(upper)))
+
+	for _, u := range []utc.UTC{utc.Zero, utc.Min, utc.Max, utc.UnixMilli(0), utc.UnixMilli(-1)} {
+		require.True(t, u.Truncate(time.Millisecond).Equal(utc.MustParseSlug(u.FormatSlug())), u)
+		require.True(t, u.Truncate(time.Millisecond).Equal(utc.MustParseSlug(u.FormatSlugBase32())), u)
+	}
+}
+
+func TestFormatSlug_sortable(t *testing.T) {
+	times := []utc.UTC{
+		utc.Zero,
+		utc.MustParse("1969-12-31T23:59:59.999Z"),
+		utc.UnixMilli(0),
+		utc.MustParse("1970-01-01T00:00:00.001Z"),
+		utc.MustParse("2021-09-09T01:46:40.123Z"),
+		utc.MustParse("2021-09-09T01:46:40.124Z"),
+		utc.MustParse("2021-09-09T01:46:41Z"),
+		utc.Max,
+	}
+	for _, format := range []func(utc.UTC) string{utc.UTC.FormatSlug, utc.UTC.FormatSlugNano, utc.UTC.FormatSlugBase32} {
+		slugs := make([]string, len(times))
+		for i, u := range times {
+			slugs[i] = format(u)
+		}
+		require.True(t, sort.StringsAreSorted(slugs), slugs)
+	}
+}
+
+func TestParseSlug_invalid(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"2021-09-09T01:46:40.123Z",
+		"20210909T014640.123",
+		"0123456789abw",
+		"w123456789abc",
+		"g123456789abc",
+	} {
+		_, err := utc.ParseSlug(s)
+		require.Error(t, err, s)
+	}
+	require.Panics(t, func() { utc.MustParseSlug("invalid") })
+}
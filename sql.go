@@ -0,0 +1,95 @@
+package utc
+
+import (
+	"database/sql/driver"
+	"time"
+
+	"github.com/eluv-io/errors-go"
+)
+
+// sqlFormats are the additional formats accepted by Scan for textual time values, as returned by some SQL drivers
+// (e.g. SQLite or MySQL without parseTime).
+var sqlFormats = []string{
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999-0700",
+	"2006-01-02 15:04:05.999999999",
+}
+
+// Value implements the driver.Valuer interface. The zero value is stored as NULL, all other values as time.Time in
+// the UTC timezone.
+func (u UTC) Value() (driver.Value, error) {
+	if u.IsZero() {
+		return nil, nil
+	}
+	return u.Time, nil
+}
+
+// Scan implements the sql.Scanner interface. It accepts time.Time values, ISO 8601 strings as well as the textual
+// formats "2006-01-02 15:04:05.999999999" (with or without timezone) used by some SQL drivers. NULL is converted to the
+// zero value.
+func (u *UTC) Scan(src interface{}) error {
+	switch val := src.(type) {
+	case nil:
+		*u = Zero
+		return nil
+	case time.Time:
+		*u = NewWall(val)
+		return nil
+	case string:
+		return u.scanString(val)
+	case []byte:
+		return u.scanString(string(val))
+	}
+	return errors.E("UTC.Scan", errors.K.Invalid, ErrParse, "reason", "unsupported type", "type", errors.TypeOf(src))
+}
+
+func (u *UTC) scanString(s string) error {
+	res, err := FromString(s)
+	if err == nil {
+		*u = res
+		return nil
+	}
+	for _, format := range sqlFormats {
+		t, terr := time.ParseInLocation(format, s, time.UTC)
+		if terr == nil {
+			*u = NewWall(t)
+			return nil
+		}
+	}
+	return errors.E("UTC.Scan", errors.K.Invalid, WrapSentinel(ErrParse, err))
+}
+
+// GormDataType returns the generic GORM data type "time", so that GORM maps UTC fields to the dialect's timestamp
+// column type. The column precision can be set with the "precision" tag, e.g. `gorm:"precision:3"` for milliseconds,
+// or with the types of the submodule github.com/eluv-io/utc-go/gormutc.
+func (UTC) GormDataType() string {
+	return "time"
+}
+
+// SQLMillis is a UTC that is truncated to milliseconds when stored in a database, matching columns with millisecond
+// precision (e.g. TIMESTAMP(3) / DATETIME(3)). Values therefore compare equal after a round trip through the database.
+//
+// With GORM, use gormutc.Millis or declare the column with `gorm:"precision:3"`. With ent, use
+// field.Time("...").GoType(utc.SQLMillis{}).SchemaType(entutc.SchemaType(3)).
+type SQLMillis struct {
+	UTC
+}
+
+// Value implements the driver.Valuer interface.
+func (u SQLMillis) Value() (driver.Value, error) {
+	return u.UTC.Truncate(time.Millisecond).Value()
+}
+
+// SQLMicros is a UTC that is truncated to microseconds when stored in a database, matching columns with microsecond
+// precision (e.g. TIMESTAMP(6) / DATETIME(6) or PostgreSQL's default timestamp precision).
+//
+// With GORM, use gormutc.Micros or declare the column with `gorm:"precision:6"`. With ent, use
+// field.Time("...").GoType(utc.SQLMicros{}).SchemaType(entutc.SchemaType(6)).
+type SQLMicros struct {
+	UTC
+}
+
+// Value implements the driver.Valuer interface.
+func (u SQLMicros) Value() (driver.Value, error) {
+	return u.UTC.Truncate(time.Microsecond).Value()
+}
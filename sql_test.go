@@ -0,0 +1,77 @@
+package utc_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+var (
+	_ driver.Valuer = utc.UTC{}
+	_ sql.Scanner   = (*utc.UTC)(nil)
+	_ driver.Valuer = utc.SQLMillis{}
+	_ driver.Valuer = utc.SQLMicros{}
+	_ sql.Scanner   = (*utc.SQLMillis)(nil)
+)
+
+func TestUTC_Value(t *testing.T) {
+	val, err := utc.Zero.Value()
+	require.NoError(t, err)
+	require.Nil(t, val)
+
+	u := utc.MustParse("2021-12-25T12:20:00.123456789Z")
+	val, err = u.Value()
+	require.NoError(t, err)
+	require.Equal(t, u.Time, val)
+
+	val, err = utc.SQLMillis{UTC: u}.Value()
+	require.NoError(t, err)
+	require.Equal(t, utc.MustParse("2021-12-25T12:20:00.123Z").Time, val)
+
+	val, err = utc.SQLMicros{UTC: u}.Value()
+	require.NoError(t, err)
+	require.Equal(t, utc.MustParse("2021-12-25T12:20:00.123456Z").Time, val)
+
+	require.Equal(t, "time", u.GormDataType())
+	require.Equal(t, "time", utc.SQLMillis{}.GormDataType())
+}
+
+func TestUTC_Scan(t *testing.T) {
+	want := utc.MustParse("2021-12-25T12:20:00.123456Z")
+	tests := []struct {
+		name string
+		src  interface{}
+	}{
+		{"time", want.Time},
+		{"time in other zone", want.Time.In(time.FixedZone("X", 3600))},
+		{"iso string", "2021-12-25T12:20:00.123456Z"},
+		{"iso bytes", []byte("2021-12-25T13:20:00.123456+01:00")},
+		{"sql text", "2021-12-25 12:20:00.123456"},
+		{"sql text with zone", "2021-12-25 13:20:00.123456+01:00"},
+		{"sql text with numeric zone", []byte("2021-12-25 13:20:00.123456+0100")},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var u utc.UTC
+			require.NoError(t, u.Scan(test.src))
+			require.True(t, want.Equal(u), u)
+			assertTimezone(t, u)
+		})
+	}
+
+	u := utc.Now()
+	require.NoError(t, u.Scan(nil))
+	require.True(t, u.IsZero())
+
+	require.Error(t, u.Scan(42))
+	require.Error(t, u.Scan("blub"))
+
+	var ms utc.SQLMillis
+	require.NoError(t, ms.Scan("2021-12-25T12:20:00.123Z"))
+	require.Equal(t, "2021-12-25T12:20:00.123Z", ms.String())
+}
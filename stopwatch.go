@@ -0,0 +1,71 @@
+package utc
+
+import (
+	"sync"
+	"time"
+)
+
+// Stopwatch is a lightweight timer for measuring elapsed time and laps based on a Clock. With a nil clock, it uses
+// utc.Now() and hence follows a mocked global clock. Measurements use the monotonic clock reading if the clock
+// provides it - see UTC.Sub. A Stopwatch is safe for concurrent use.
+type Stopwatch struct {
+	clock Clock
+
+	mu    sync.Mutex
+	start UTC
+	lap   UTC // start of the current lap
+	laps  []time.Duration
+}
+
+// NewStopwatch creates a Stopwatch that is started at the current time of the given clock, or of utc.Now() if nil.
+func NewStopwatch(clock Clock) *Stopwatch {
+	now := clockNow(clock)
+	return &Stopwatch{clock: clock, start: now, lap: now}
+}
+
+// Started returns the instant the stopwatch was created or last reset.
+func (s *Stopwatch) Started() UTC {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.start
+}
+
+// Elapsed returns the duration since the stopwatch was created or last reset.
+func (s *Stopwatch) Elapsed() time.Duration {
+	now := clockNow(s.clock)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return now.Sub(s.start)
+}
+
+// Reset restarts the stopwatch at the current time, discards all laps and returns the duration elapsed before the
+// reset.
+func (s *Stopwatch) Reset() time.Duration {
+	now := clockNow(s.clock)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	elapsed := now.Sub(s.start)
+	s.start = now
+	s.lap = now
+	s.laps = nil
+	return elapsed
+}
+
+// Lap ends the current lap and starts a new one. It returns the duration of the ended lap, i.e. the duration since the
+// previous call to Lap or since the stopwatch was created or reset.
+func (s *Stopwatch) Lap() time.Duration {
+	now := clockNow(s.clock)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	d := now.Sub(s.lap)
+	s.lap = now
+	s.laps = append(s.laps, d)
+	return d
+}
+
+// Laps returns the durations of all laps ended since the stopwatch was created or reset.
+func (s *Stopwatch) Laps() []time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]time.Duration(nil), s.laps...)
+}
@@ -0,0 +1,44 @@
+package utc_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+func TestStopwatch(t *testing.T) {
+	now := utc.MustParse("2020-01-01T10:00:00Z")
+	clock := utc.NewWallClock(now)
+
+	sw := utc.NewStopwatch(clock)
+	require.Equal(t, now, sw.Started())
+	require.Equal(t, time.Duration(0), sw.Elapsed())
+
+	clock.Add(time.Second)
+	require.Equal(t, time.Second, sw.Lap())
+	clock.Add(2 * time.Second)
+	require.Equal(t, 2*time.Second, sw.Lap())
+	require.Equal(t, 3*time.Second, sw.Elapsed())
+	require.Equal(t, []time.Duration{time.Second, 2 * time.Second}, sw.Laps())
+
+	clock.Add(time.Minute)
+	require.Equal(t, time.Minute+3*time.Second, sw.Reset())
+	require.Equal(t, now.Add(time.Minute+3*time.Second), sw.Started())
+	require.Empty(t, sw.Laps())
+	require.Equal(t, time.Duration(0), sw.Elapsed())
+
+	clock.Add(time.Hour)
+	require.Equal(t, time.Hour, sw.Lap())
+}
+
+func TestStopwatch_globalClock(t *testing.T) {
+	clock := utc.NewWallClock(utc.MustParse("2020-01-01T10:00:00Z")).MockNow()
+	defer clock.UnmockNow()
+
+	sw := utc.NewStopwatch(nil)
+	clock.Add(time.Hour)
+	require.Equal(t, time.Hour, sw.Elapsed())
+}
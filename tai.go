@@ -0,0 +1,239 @@
+package utc
+
+import (
+	"bufio"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/eluv-io/errors-go"
+)
+
+// TAI is an instant on the International Atomic Time scale. Unlike UTC, TAI is a continuous time scale without leap
+// seconds, hence arithmetic (Sub, Add) on TAI values is exact across leap seconds.
+//
+// The embedded time.Time holds the TAI clock reading. Its location is time.UTC for technical reasons only - the value is
+// NOT a UTC time and must be converted back with FromTAI() or TAI.UTC().
+type TAI struct {
+	time.Time
+}
+
+// String returns the TAI time formatted like UTC.String(), but with a " TAI" suffix instead of the "Z" timezone.
+func (t TAI) String() string {
+	s := New(t.Time).String()
+	return s[:len(s)-1] + " TAI"
+}
+
+// Sub returns the duration t-other.
+func (t TAI) Sub(other TAI) time.Duration {
+	return t.Time.Sub(other.Time)
+}
+
+// Add returns t+d.
+func (t TAI) Add(d time.Duration) TAI {
+	return TAI{t.Time.Add(d)}
+}
+
+// UTC converts the TAI time to UTC with the current leap second table. See FromTAI.
+func (t TAI) UTC() UTC {
+	return FromTAI(t)
+}
+
+// LeapSecond is an entry of the leap second table: from Since onwards, TAI is ahead of UTC by Offset seconds.
+type LeapSecond struct {
+	Since  UTC // the UTC instant from which the offset is valid
+	Offset int // TAI - UTC in seconds
+}
+
+// LeapSecondTable is a table of leap seconds sorted by time.
+type LeapSecondTable struct {
+	Entries []LeapSecond // the leap seconds, sorted by time
+	Expires UTC          // the expiration date of the table or Zero if unknown
+}
+
+// ntpEpochOffset is the offset in seconds between the NTP epoch 1900-01-01 used in leap-seconds.list and the unix epoch
+const ntpEpochOffset = 2208988800
+
+var (
+	// builtinLeapSeconds contains all leap seconds since the introduction of the current UTC definition in 1972. No leap
+	// second has been announced after the one of 2017-01-01.
+	builtinLeapSeconds = func() *LeapSecondTable {
+		dates := []string{
+			"1972-01-01", "1972-07-01", "1973-01-01", "1974-01-01", "1975-01-01", "1976-01-01", "1977-01-01",
+			"1978-01-01", "1979-01-01", "1980-01-01", "1981-07-01", "1982-07-01", "1983-07-01", "1985-07-01",
+			"1988-01-01", "1990-01-01", "1991-01-01", "1992-07-01", "1993-07-01", "1994-07-01", "1996-01-01",
+			"1997-07-01", "1999-01-01", "2006-01-01", "2009-01-01", "2012-07-01", "2015-07-01", "2017-01-01",
+		}
+		table := &LeapSecondTable{}
+		for i, date := range dates {
+			table.Entries = append(table.Entries, LeapSecond{Since: MustParse(date), Offset: 10 + i})
+		}
+		return table
+	}()
+	leapSeconds atomic.Pointer[LeapSecondTable]
+)
+
+func init() {
+	leapSeconds.Store(builtinLeapSeconds)
+}
+
+// LeapSeconds returns the leap second table currently used by ToTAI and FromTAI. By default, this is the table built
+// into this package.
+func LeapSeconds() LeapSecondTable {
+	return *leapSeconds.Load()
+}
+
+// SetLeapSeconds replaces the leap second table used by ToTAI and FromTAI, e.g. with a newer table published by the
+// IERS. The table must contain at least one entry and must be sorted by time.
+func SetLeapSeconds(table LeapSecondTable) error {
+	if err := table.Validate(); err != nil {
+		return err
+	}
+	leapSeconds.Store(&table)
+	return nil
+}
+
+// ResetLeapSeconds resets the leap second table to the table built into this package.
+func ResetLeapSeconds() {
+	leapSeconds.Store(builtinLeapSeconds)
+}
+
+// LoadLeapSeconds parses a leap second table in the format of the IETF/IERS leap-seconds.list file from the given
+// reader and installs it with SetLeapSeconds.
+func LoadLeapSeconds(r io.Reader) error {
+	table, err := ParseLeapSeconds(r)
+	if err != nil {
+		return err
+	}
+	return SetLeapSeconds(table)
+}
+
+// ParseLeapSeconds parses a leap second table in the format of the IETF/IERS leap-seconds.list file: lines of NTP
+// timestamps (seconds since 1900-01-01) and TAI-UTC offsets, comments starting with '#' and the expiration date in a
+// line starting with "#@".
+func ParseLeapSeconds(r io.Reader) (LeapSecondTable, error) {
+	e := errors.Template("ParseLeapSeconds", errors.K.Invalid, ErrParse)
+
+	table := LeapSecondTable{}
+	scanner := bufio.NewScanner(r)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "#@") {
+			ntp, err := strconv.ParseInt(strings.TrimSpace(line[2:]), 10, 64)
+			if err != nil {
+				return LeapSecondTable{}, e(WrapSentinel(ErrParse, err), "reason", "invalid expiration date", "line", lineNo)
+			}
+			table.Expires = Unix(ntp-ntpEpochOffset, 0)
+			continue
+		}
+		if idx := strings.IndexByte(line, '#'); idx >= 0 {
+			line = line[:idx]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			return LeapSecondTable{}, e("reason", "invalid entry", "line", lineNo)
+		}
+		ntp, err := strconv.ParseInt(fields[0], 10, 64)
+		if err != nil {
+			return LeapSecondTable{}, e(WrapSentinel(ErrParse, err), "reason", "invalid timestamp", "line", lineNo)
+		}
+		offset, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return LeapSecondTable{}, e(WrapSentinel(ErrParse, err), "reason", "invalid offset", "line", lineNo)
+		}
+		table.Entries = append(table.Entries, LeapSecond{Since: Unix(ntp-ntpEpochOffset, 0), Offset: offset})
+	}
+	if err := scanner.Err(); err != nil {
+		return LeapSecondTable{}, e(err)
+	}
+	if err := table.Validate(); err != nil {
+		return LeapSecondTable{}, e(WrapSentinel(ErrParse, err))
+	}
+	return table, nil
+}
+
+// Validate validates that the table is not empty and sorted by time.
+func (l LeapSecondTable) Validate() error {
+	e := errors.Template("LeapSecondTable.Validate", errors.K.Invalid, ErrParse)
+	if len(l.Entries) == 0 {
+		return e("reason", "empty table")
+	}
+	for i := 1; i < len(l.Entries); i++ {
+		if !l.Entries[i-1].Since.Before(l.Entries[i].Since) {
+			return e("reason", "entries not sorted", "entry", l.Entries[i].Since)
+		}
+	}
+	return nil
+}
+
+// Offset returns TAI - UTC at the given UTC instant. Instants before the first entry of the table use the offset of the
+// first entry.
+func (l LeapSecondTable) Offset(u UTC) time.Duration {
+	idx := sort.Search(len(l.Entries), func(i int) bool {
+		return l.Entries[i].Since.After(u)
+	})
+	if idx > 0 {
+		idx--
+	}
+	return time.Duration(l.Entries[idx].Offset) * time.Second
+}
+
+// insertsLeapSecond returns true if the table has an entry at the given instant that increases the offset, i.e. if a
+// leap second was inserted right before the given instant.
+func (l LeapSecondTable) insertsLeapSecond(u UTC) bool {
+	idx := sort.Search(len(l.Entries), func(i int) bool {
+		return !l.Entries[i].Since.Before(u)
+	})
+	return idx > 0 &&
+		idx < len(l.Entries) &&
+		l.Entries[idx].Since.Equal(u) &&
+		l.Entries[idx].Offset > l.Entries[idx-1].Offset
+}
+
+// ToTAI converts the given UTC instant to TAI.
+func (l LeapSecondTable) ToTAI(u UTC) TAI {
+	return TAI{u.Time.Add(l.Offset(u))}
+}
+
+// FromTAI converts the given TAI instant to UTC. Since UTC cannot represent the inserted leap second 23:59:60, TAI
+// instants within a leap second are mapped to the last nanosecond before the leap second.
+func (l LeapSecondTable) FromTAI(t TAI) UTC {
+	idx := sort.Search(len(l.Entries), func(i int) bool {
+		entry := l.Entries[i]
+		return entry.Since.Time.Add(time.Duration(entry.Offset) * time.Second).After(t.Time)
+	})
+	if idx > 0 {
+		idx--
+	}
+	entry := l.Entries[idx]
+	res := New(t.Time.Add(-time.Duration(entry.Offset) * time.Second))
+	if idx+1 < len(l.Entries) {
+		next := l.Entries[idx+1].Since
+		if !res.Before(next) {
+			// within an inserted leap second
+			return next.Add(-1)
+		}
+	}
+	return res
+}
+
+// ToTAI converts the given UTC instant to TAI using the current leap second table.
+func ToTAI(u UTC) TAI {
+	return leapSeconds.Load().ToTAI(u)
+}
+
+// FromTAI converts the given TAI instant to UTC using the current leap second table.
+func FromTAI(t TAI) UTC {
+	return leapSeconds.Load().FromTAI(t)
+}
+
+// TAIOffset returns TAI - UTC at the given UTC instant using the current leap second table.
+func TAIOffset(u UTC) time.Duration {
+	return leapSeconds.Load().Offset(u)
+}
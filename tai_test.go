@@ -0,0 +1,93 @@
+package utc_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+func TestToTAI(t *testing.T) {
+	tests := []struct {
+		utc  string
+		want string
+	}{
+		{"1970-01-01T00:00:00.000Z", "1970-01-01T00:00:10.000 TAI"},
+		{"1972-01-01T00:00:00.000Z", "1972-01-01T00:00:10.000 TAI"},
+		{"2016-12-31T23:59:59.000Z", "2017-01-01T00:00:35.000 TAI"},
+		{"2017-01-01T00:00:00.000Z", "2017-01-01T00:00:37.000 TAI"},
+		{"2021-12-25T12:20:00.123Z", "2021-12-25T12:20:37.123 TAI"},
+	}
+	for _, test := range tests {
+		t.Run(test.utc, func(t *testing.T) {
+			u := utc.MustParse(test.utc)
+			tai := utc.ToTAI(u)
+			assert.Equal(t, test.want, tai.String())
+			assert.True(t, u.Equal(utc.FromTAI(tai)))
+			assert.True(t, u.Equal(tai.UTC()))
+		})
+	}
+}
+
+func TestTAI_Sub(t *testing.T) {
+	before := utc.MustParse("2016-12-31T23:59:59.000Z")
+	after := utc.MustParse("2017-01-01T00:00:00.000Z")
+
+	require.Equal(t, time.Second, after.Sub(before))
+	require.Equal(t, 2*time.Second, utc.ToTAI(after).Sub(utc.ToTAI(before)))
+
+	// the leap second itself maps to the last nanosecond before the leap second
+	leap := utc.ToTAI(before).Add(1500 * time.Millisecond)
+	require.True(t, after.Add(-1).Equal(utc.FromTAI(leap)))
+	require.True(t, after.Equal(utc.FromTAI(utc.ToTAI(before).Add(2*time.Second))))
+}
+
+const leapSecondsList = `
+# a made-up table with a leap second on 2028-01-01, expiring 2030-01-01
+#$	 3676924800
+#@	 4102444800
+#
+2272060800	10	# 1 Jan 1972
+3692217600	37	# 1 Jan 2017
+4039286400	38	# 1 Jan 2028
+`
+
+func TestLoadLeapSeconds(t *testing.T) {
+	defer utc.ResetLeapSeconds()
+
+	require.Len(t, utc.LeapSeconds().Entries, 28)
+	require.Equal(t, utc.Zero, utc.LeapSeconds().Expires)
+
+	err := utc.LoadLeapSeconds(strings.NewReader(leapSecondsList))
+	require.NoError(t, err)
+
+	table := utc.LeapSeconds()
+	require.Len(t, table.Entries, 3)
+	require.Equal(t, "2030-01-01T00:00:00.000Z", table.Expires.String())
+	require.Equal(t, "2028-01-01T00:00:00.000Z", table.Entries[2].Since.String())
+	require.Equal(t, 38*time.Second, utc.TAIOffset(utc.MustParse("2030-01-01")))
+	require.Equal(t, 37*time.Second, utc.TAIOffset(utc.MustParse("2027-01-01")))
+
+	utc.ResetLeapSeconds()
+	require.Equal(t, 37*time.Second, utc.TAIOffset(utc.MustParse("2030-01-01")))
+}
+
+func TestParseLeapSeconds_invalid(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"# only comments",
+		"2272060800",
+		"2272060800 ten",
+		"x 10",
+		"#@ never\n2272060800 10",
+		"3692217600 37\n2272060800 10",
+	} {
+		_, err := utc.ParseLeapSeconds(strings.NewReader(s))
+		require.Error(t, err, s)
+	}
+	require.Error(t, utc.SetLeapSeconds(utc.LeapSecondTable{}))
+}
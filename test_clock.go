@@ -12,34 +12,112 @@ import (
 // A TestClock becomes effectively used as 'the global clock' after calling its
 // function MockNow(). When the clock is effective, func IsMock returns true.
 type TestClock struct {
-	mono            bool
-	millisPrecision bool
-	now             *atomic.Pointer[UTC]
-	isMock          *atomic.Bool
+	mono      bool
+	precision time.Duration // rounding precision of the wall clock, no rounding if 0
+	now       *atomic.Pointer[UTC]
+	isMock    *atomic.Bool
+	timers    *clockTimers
+}
+
+// TestClockOption is an option for NewTestClock.
+type TestClockOption func(*testClockConfig)
+
+type testClockConfig struct {
+	mono      bool
+	precision time.Duration
+	at        []UTC
+	mock      bool
+}
+
+// WithMono configures the TestClock to retain the monotonic clock reading. The default is a wall clock with the
+// monotonic clock reading stripped.
+func WithMono() TestClockOption {
+	return func(c *testClockConfig) {
+		c.mono = true
+	}
+}
+
+// WithPrecision configures the TestClock to round its time to the given precision, e.g. time.Millisecond. The
+// precision applies to wall clocks only and is ignored in combination with WithMono.
+func WithPrecision(precision time.Duration) TestClockOption {
+	return func(c *testClockConfig) {
+		c.precision = precision
+	}
+}
+
+// WithTime configures the TestClock to be set to the given time initially.
+func WithTime(u UTC) TestClockOption {
+	return func(c *testClockConfig) {
+		c.at = []UTC{u}
+	}
+}
+
+// WithMock configures the TestClock to be installed as the global clock - see MockNow.
+func WithMock() TestClockOption {
+	return func(c *testClockConfig) {
+		c.mock = true
+	}
+}
+
+// NewTestClock returns a TestClock configured with the given options. Without options, it is equivalent to
+// NewWallClock(). The other constructors are shorthands for common configurations:
+//
+//   - NewMonoClock(u...) = NewTestClock(WithMono(), WithTime(u))
+//   - NewWallClock(u...) = NewTestClock(WithTime(u))
+//   - NewWallClockMs(u...) = NewTestClock(WithPrecision(time.Millisecond), WithTime(u))
+//   - NewWallClockUs(u...) = NewTestClock(WithPrecision(time.Microsecond), WithTime(u))
+//   - NewWallClockSec(u...) = NewTestClock(WithPrecision(time.Second), WithTime(u))
+func NewTestClock(opts ...TestClockOption) TestClock {
+	cfg := &testClockConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	precision := cfg.precision
+	if cfg.mono {
+		precision = 0
+	}
+	c := newTestClock(cfg.mono, precision, cfg.at...)
+	if cfg.mock {
+		c.MockNow()
+	}
+	return c
 }
 
 // NewMonoClock returns a TestClock with the monotonic clock reading.
 func NewMonoClock(u ...UTC) TestClock {
-	return newTestClock(true, false, u...)
+	return newTestClock(true, 0, u...)
 }
 
 // NewWallClock returns a TestClock with the monotonic clock reading stripped.
 func NewWallClock(u ...UTC) TestClock {
-	return newTestClock(false, false, u...)
+	return newTestClock(false, 0, u...)
 }
 
 // NewWallClockMs returns a TestClock with the monotonic clock reading stripped
 // and time rounded to the millisecond.
 func NewWallClockMs(u ...UTC) TestClock {
-	return newTestClock(false, true, u...)
+	return newTestClock(false, time.Millisecond, u...)
+}
+
+// NewWallClockUs returns a TestClock with the monotonic clock reading stripped
+// and time rounded to the microsecond.
+func NewWallClockUs(u ...UTC) TestClock {
+	return newTestClock(false, time.Microsecond, u...)
 }
 
-func newTestClock(mono, ms bool, u ...UTC) TestClock {
+// NewWallClockSec returns a TestClock with the monotonic clock reading stripped
+// and time rounded to the second.
+func NewWallClockSec(u ...UTC) TestClock {
+	return newTestClock(false, time.Second, u...)
+}
+
+func newTestClock(mono bool, precision time.Duration, u ...UTC) TestClock {
 	ret := TestClock{
-		mono:            mono,
-		millisPrecision: ms,
-		now:             new(atomic.Pointer[UTC]),
-		isMock:          &atomic.Bool{},
+		mono:      mono,
+		precision: precision,
+		now:       new(atomic.Pointer[UTC]),
+		isMock:    &atomic.Bool{},
+		timers:    &clockTimers{},
 	}
 	if len(u) > 0 {
 		ret.Set(u[0])
@@ -59,23 +137,36 @@ func (c TestClock) unMocked() {
 	c.isMock.Store(false)
 }
 
+// is returns true if the given clock is this TestClock or a copy of it.
+func (c TestClock) is(other Clock) bool {
+	o, ok := other.(TestClock)
+	return ok && o.now == c.now
+}
+
 // IsMock returns true if this clock is effectively the 'global clock'.
 func (c TestClock) IsMock() bool {
 	return c.isMock.Load()
 }
 
 // UnmockNow removes this clock from being the 'global clock' and resets the
-// utc.Now func to the default.
+// utc.Now func to the default - see ResetNow.
 func (c TestClock) UnmockNow() {
 	ResetNow()
 }
 
 func (c TestClock) wc() UTC {
 	if !c.mono {
-		if c.millisPrecision {
+		switch c.precision {
+		case time.Millisecond:
 			return WallClockMs()
+		case time.Microsecond:
+			return WallClockUs()
+		case time.Second:
+			return WallClockSec()
+		case 0:
+			return WallClock()
 		}
-		return WallClock()
+		return WallClock().Round(c.precision)
 	}
 	return Mono()
 }
@@ -115,38 +206,88 @@ func (c TestClock) Unset() UTC {
 }
 
 func (c TestClock) set(u UTC) UTC {
-	var n *UTC
-
-	switch u {
-	case Zero:
-		n = nil
-	default:
-		w := u
-		if !c.mono {
-			w = w.StripMono()
-			if c.millisPrecision {
-				w = w.Round(time.Millisecond)
-			}
-		}
-		n = &w
-	}
-
-	ret := c.now.Swap(n)
+	ret := c.now.Swap(c.value(u))
+	c.timers.clockChanged(c)
 	if ret == nil {
 		return Zero
 	}
 	return *ret
 }
 
+// value returns the value to store for the given UTC: nil for Zero, the UTC stripped of the monotonic clock reading
+// and rounded to the clock's precision otherwise, unless it is a mono clock.
+func (c TestClock) value(u UTC) *UTC {
+	if u == Zero {
+		return nil
+	}
+	w := u
+	if !c.mono {
+		w = w.StripMono()
+		if c.precision > 0 {
+			w = w.Round(c.precision)
+		}
+	}
+	return &w
+}
+
+// CompareAndSet sets the time of this TestClock to new if the currently set time is equal to old, and returns true if
+// it did. Like Get, an unset clock has the time Zero; setting new to Zero unsets the clock. Use it in concurrent test
+// helpers to modify the clock without racing with other modifications between Get and Set.
+func (c TestClock) CompareAndSet(old, new UTC) bool {
+	n := c.value(new)
+	for {
+		p := c.now.Load()
+		cur := Zero
+		if p != nil {
+			cur = *p
+		}
+		if !cur.Equal(old) {
+			return false
+		}
+		if c.now.CompareAndSwap(p, n) {
+			c.timers.clockChanged(c)
+			return true
+		}
+	}
+}
+
 // Add adds the given duration to the UTC time of this TestClock and returns the
 // resulting UTC.
 // If this TestClock was started without a time, the addition is made on top of
 // the current wall clock (and results in a time in the future when t is positive).
+// Add is atomic: concurrent calls do not lose updates.
 func (c TestClock) Add(t time.Duration) UTC {
-	n := c.Now()
-	ret := n.Add(t)
-	c.Set(ret)
-	return ret
+	for {
+		p := c.now.Load()
+		var n UTC
+		if p != nil && *p != Zero {
+			n = *p
+		} else {
+			n = c.wc()
+		}
+		ret := n.Add(t)
+		if c.now.CompareAndSwap(p, c.value(ret)) {
+			c.timers.clockChanged(c)
+			return ret
+		}
+	}
+}
+
+// AddIfSet atomically adds the given duration to the time of this TestClock if a time is set and returns the resulting
+// time and true. It returns Zero and false without changing the clock if no time is set, i.e. if the clock follows the
+// wall clock.
+func (c TestClock) AddIfSet(d time.Duration) (UTC, bool) {
+	for {
+		p := c.now.Load()
+		if p == nil || *p == Zero {
+			return Zero, false
+		}
+		ret := p.Add(d)
+		if c.now.CompareAndSwap(p, c.value(ret)) {
+			c.timers.clockChanged(c)
+			return ret, true
+		}
+	}
 }
 
 // SetNow sets this TestClock to the current wall clock and returns the previously
@@ -1,6 +1,8 @@
 package utc_test
 
 import (
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -15,9 +17,17 @@ func TestNewWallClock(t *testing.T) {
 	mono := utc.NewMonoClock(u)
 	wall := utc.NewWallClock(u)
 	wms := utc.NewWallClockMs(u)
+	wus := utc.NewWallClockUs(u)
+	wsec := utc.NewWallClockSec(u)
 
 	require.Equal(t, mono.Get().StripMono(), wall.Get())
 	require.Equal(t, wall.Get().Round(time.Millisecond), wms.Get())
+	require.Equal(t, wall.Get().Round(time.Microsecond), wus.Get())
+	require.Zero(t, utc.NewWallClockUs().Now().Nanosecond()%1000)
+	require.Equal(t, wall.Get().Round(time.Second), wsec.Get())
+	require.Zero(t, utc.NewWallClockSec().Now().Nanosecond())
+	wsec.Add(1500 * time.Millisecond)
+	require.Zero(t, wsec.Now().Nanosecond())
 }
 
 func TestClockMock(t *testing.T) {
@@ -73,13 +83,10 @@ func TestClockUntil(t *testing.T) {
 	assert.Equal(t, time.Minute, utc.Until(thenUTC))
 }
 
-// TestRace shows a race between utc.Now() and assigning nowFn = nowFnClock
-// and how to avoid it.
+// TestRace ensures that calling utc.Now() concurrently with installing clocks is
+// free of races. Run with -race.
 func TestRace(t *testing.T) {
-	// without the call below to ResetNow, running the test with -race fails. Note
-	// that installing any clock - like with utc.MockNowClock(utc.NewMonoClock()) -
-	// instead of calling ResetNow also works.
-	utc.ResetNow()
+	defer utc.ResetNow()
 
 	stopCh := make(chan struct{})
 	defer close(stopCh)
@@ -88,7 +95,7 @@ func TestRace(t *testing.T) {
 		for {
 			select {
 			case <-stopCh:
-				break
+				return
 			default:
 				utc.Now()
 				time.Sleep(time.Millisecond)
@@ -100,3 +107,224 @@ func TestRace(t *testing.T) {
 	}
 
 }
+
+func TestMockHooks(t *testing.T) {
+	defer utc.ResetNow()
+
+	var events []string
+	removeMock := utc.OnMock(func(c utc.Clock) {
+		_, ok := c.(utc.TestClock)
+		events = append(events, fmt.Sprintf("mock %v", ok))
+	})
+	removeUnmock := utc.OnUnmock(func(c utc.Clock) {
+		_, ok := c.(utc.TestClock)
+		events = append(events, fmt.Sprintf("unmock %v", ok))
+	})
+
+	c1 := utc.NewWallClock().MockNow()
+	utc.NewWallClock().MockNow()
+	require.False(t, c1.IsMock())
+	utc.ResetNow()
+	utc.ResetNow() // no clock installed: no events
+	reset := utc.MockNow(utc.Now())
+	reset()
+
+	require.Equal(t, []string{
+		"mock true",
+		"unmock true",
+		"mock true",
+		"unmock true",
+		"mock false",
+		"unmock false",
+	}, events)
+
+	removeMock()
+	removeUnmock()
+	events = nil
+	utc.NewWallClock().MockNow()
+	utc.ResetNow()
+	require.Empty(t, events)
+}
+
+type testM func() int
+
+func (m testM) Run() int { return m() }
+
+func TestRunWithClock(t *testing.T) {
+	defer utc.ResetNow()
+
+	start := utc.MustParse("2020-01-01")
+	clock := utc.NewWallClock(start)
+	code := utc.RunWithClock(testM(func() int {
+		require.Equal(t, start, utc.Now())
+		require.True(t, clock.IsMock())
+
+		other := utc.NewWallClock(start.Add(time.Hour)).MockNow()
+		require.Equal(t, start.Add(time.Hour), utc.Now())
+		other.UnmockNow()
+
+		// UnmockNow restores the suite clock
+		require.Equal(t, start, utc.Now())
+		require.True(t, clock.IsMock())
+		return 0
+	}), clock)
+	require.Equal(t, 0, code)
+	require.False(t, clock.IsMock())
+	require.NotEqual(t, start, utc.Now())
+
+	// leaked mock
+	code = utc.RunWithClock(testM(func() int {
+		utc.NewWallClock(start.Add(time.Hour)).MockNow()
+		return 0
+	}), clock)
+	require.Equal(t, 1, code)
+	require.NotEqual(t, start.Add(time.Hour), utc.Now())
+
+	// failure code is retained
+	code = utc.RunWithClock(testM(func() int { return 2 }), clock)
+	require.Equal(t, 2, code)
+}
+
+func TestTestClock_AfterFunc(t *testing.T) {
+	start := utc.MustParse("2020-01-01T10:00:00Z")
+	clock := utc.NewWallClock(start)
+
+	var fired []string
+	clock.AfterFunc(start.Add(2*time.Second), func() { fired = append(fired, "2s") })
+	clock.AfterFunc(start.Add(time.Second), func() { fired = append(fired, "1s") })
+	clock.AfterFunc(start.Add(time.Second), func() { fired = append(fired, "1s-b") })
+	stop := clock.AfterFunc(start.Add(3*time.Second), func() { fired = append(fired, "3s") })
+	clock.AfterFunc(start, func() { fired = append(fired, "now") })
+	require.Equal(t, []string{"now"}, fired)
+
+	clock.Add(500 * time.Millisecond)
+	require.Equal(t, []string{"now"}, fired)
+
+	clock.Add(2 * time.Second)
+	require.Equal(t, []string{"now", "1s", "1s-b", "2s"}, fired)
+
+	require.True(t, stop())
+	require.False(t, stop())
+	clock.Add(time.Hour)
+	require.Equal(t, []string{"now", "1s", "1s-b", "2s"}, fired)
+}
+
+func TestTestClock_AfterFunc_wall(t *testing.T) {
+	clock := utc.NewWallClock()
+	done := make(chan struct{})
+	clock.AfterFunc(clock.Now().Add(10*time.Millisecond), func() { close(done) })
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "timer did not fire")
+	}
+}
+
+func TestAfterFunc(t *testing.T) {
+	start := utc.MustParse("2020-01-01T10:00:00Z")
+	clock := utc.NewWallClock(start).MockNow()
+	defer clock.UnmockNow()
+
+	fired := false
+	utc.AfterFunc(start.Add(time.Minute), func() { fired = true })
+	clock.Add(time.Minute)
+	require.True(t, fired)
+}
+
+func TestNewTestClock(t *testing.T) {
+	now := utc.MustParse("2020-01-01T10:00:00.123456789Z")
+
+	c := utc.NewTestClock()
+	require.Equal(t, utc.Zero, c.Get())
+	require.False(t, c.IsMock())
+	n := c.Now()
+	require.Equal(t, n, n.StripMono())
+
+	c = utc.NewTestClock(utc.WithTime(now))
+	require.Equal(t, now, c.Now())
+
+	c = utc.NewTestClock(utc.WithTime(now), utc.WithPrecision(time.Millisecond))
+	require.Equal(t, utc.MustParse("2020-01-01T10:00:00.123Z"), c.Now())
+
+	c = utc.NewTestClock(utc.WithPrecision(time.Second))
+	require.Equal(t, 0, c.Now().Nanosecond())
+
+	c = utc.NewTestClock(utc.WithMono(), utc.WithPrecision(time.Millisecond))
+	n = c.Now()
+	require.NotEqual(t, n, n.StripMono())
+
+	c = utc.NewTestClock(utc.WithTime(now), utc.WithMock())
+	defer c.UnmockNow()
+	require.True(t, c.IsMock())
+	require.Equal(t, now, utc.Now())
+}
+
+func TestTestClock_CompareAndSet(t *testing.T) {
+	d0 := utc.MustParse("2020-01-01T00:00:00Z")
+	d1 := d0.Add(time.Hour)
+
+	clock := utc.NewWallClock()
+	require.False(t, clock.CompareAndSet(d0, d1))
+	require.True(t, clock.CompareAndSet(utc.Zero, d0))
+	require.Equal(t, d0, clock.Get())
+
+	require.False(t, clock.CompareAndSet(d1, d0))
+	require.Equal(t, d0, clock.Get())
+	require.True(t, clock.CompareAndSet(d0, d1))
+	require.Equal(t, d1, clock.Get())
+
+	require.True(t, clock.CompareAndSet(d1, utc.Zero))
+	require.Equal(t, utc.Zero, clock.Get())
+
+	// the stored value is normalized, the comparison ignores the monotonic clock
+	clock = utc.NewWallClockMs(d0)
+	require.True(t, clock.CompareAndSet(d0, d1.Add(400*time.Microsecond)))
+	require.Equal(t, d1, clock.Get())
+
+	// timers fire
+	fired := false
+	clock.AfterFunc(d1.Add(time.Minute), func() { fired = true })
+	require.True(t, clock.CompareAndSet(d1, d1.Add(time.Minute)))
+	require.True(t, fired)
+}
+
+func TestTestClock_AddIfSet(t *testing.T) {
+	clock := utc.NewWallClock()
+	res, ok := clock.AddIfSet(time.Hour)
+	require.False(t, ok)
+	require.Equal(t, utc.Zero, res)
+	require.Equal(t, utc.Zero, clock.Get())
+
+	d0 := utc.MustParse("2020-01-01T00:00:00Z")
+	clock.Set(d0)
+	res, ok = clock.AddIfSet(time.Hour)
+	require.True(t, ok)
+	require.Equal(t, d0.Add(time.Hour), res)
+	require.Equal(t, d0.Add(time.Hour), clock.Get())
+}
+
+func TestTestClock_concurrentAdd(t *testing.T) {
+	d0 := utc.MustParse("2020-01-01T00:00:00Z")
+	for _, clock := range []utc.TestClock{utc.NewWallClock(d0), utc.NewWallClock()} {
+		start := clock.Now()
+		wg := sync.WaitGroup{}
+		for g := 0; g < 10; g++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < 100; i++ {
+					if i%2 == 0 {
+						clock.Add(time.Second)
+					} else if _, ok := clock.AddIfSet(time.Second); !ok {
+						clock.Add(time.Second)
+					}
+				}
+			}()
+		}
+		wg.Wait()
+		require.True(t, clock.Get().Sub(start) >= 1000*time.Second)
+		if start.Equal(d0) {
+			require.Equal(t, d0.Add(1000*time.Second), clock.Get())
+		}
+	}
+}
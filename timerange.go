@@ -0,0 +1,177 @@
+package utc
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eluv-io/errors-go"
+)
+
+// ParseTimeRange parses a time range given as pair of "from" and "to" expressions, as sent by dashboards like
+// Grafana. Each expression is one of:
+//
+//   - an ISO 8601 timestamp: 2020-01-01T10:00:00Z
+//   - the number of milliseconds since the unix epoch: 1577872800000
+//   - a relative expression based on the current time of the given clock (utc.Now() if nil): "now", followed by any
+//     number of additions or subtractions ("-6h", "+1d", "-M" for one month) and roundings to the start of a unit
+//     ("/d"), e.g. "now-1d/d" for the start of yesterday. Supported units are s, m, h, d, w (ISO week starting on
+//     Monday), M (month) and y (year).
+//
+// Roundings in the "to" expression round up to the end of the unit, which is the start of the next unit since the
+// returned Range excludes its end: from "now-1d/d" to "now-1d/d" is the whole day of yesterday. The current time is
+// evaluated once for both expressions. An error is returned if an expression is invalid or "to" is before "from".
+func ParseTimeRange(from, to string, clock Clock) (Range, error) {
+	e := errors.Template("ParseTimeRange", errors.K.Invalid, "from", from, "to", to)
+
+	now := clockNow(clock)
+	start, err := parseRangeExpr(from, now, false)
+	if err != nil {
+		return Range{}, e(err)
+	}
+	end, err := parseRangeExpr(to, now, true)
+	if err != nil {
+		return Range{}, e(err)
+	}
+	if end.Before(start) {
+		return Range{}, e(ErrOutOfRange, "reason", "to is before from")
+	}
+	return NewRange(start, end), nil
+}
+
+// parseRangeExpr parses a single expression of a time range. If roundUp is true, roundings advance to the start of
+// the next unit.
+func parseRangeExpr(s string, now UTC, roundUp bool) (UTC, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Zero, errors.E("parseRangeExpr", errors.K.Invalid, ErrParse, "reason", "empty expression")
+	}
+	if isDigits(s) {
+		millis, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return Zero, errors.E("parseRangeExpr", errors.K.Invalid, WrapSentinel(ErrOutOfRange, err), "expr", s)
+		}
+		return UnixMilli(millis), nil
+	}
+	if !strings.HasPrefix(s, "now") {
+		return FromString(s)
+	}
+
+	u := now
+	ops := s[len("now"):]
+	for ops != "" {
+		op := ops[0]
+		ops = ops[1:]
+		switch op {
+		case '+', '-':
+			i := 0
+			for i < len(ops) && ops[i] >= '0' && ops[i] <= '9' {
+				i++
+			}
+			n := 1
+			if i > 0 {
+				var err error
+				if n, err = strconv.Atoi(ops[:i]); err != nil {
+					return Zero, errors.E("parseRangeExpr", errors.K.Invalid, WrapSentinel(ErrOutOfRange, err), "expr", s)
+				}
+			}
+			if i >= len(ops) {
+				return Zero, errors.E("parseRangeExpr", errors.K.Invalid, ErrParse, "reason", "missing unit", "expr", s)
+			}
+			if op == '-' {
+				n = -n
+			}
+			res, err := addRangeUnit(u, n, ops[i])
+			if err != nil {
+				return Zero, errors.E("parseRangeExpr", errors.K.Invalid, err, "expr", s)
+			}
+			u = res
+			ops = ops[i+1:]
+		case '/':
+			if ops == "" {
+				return Zero, errors.E("parseRangeExpr", errors.K.Invalid, ErrParse, "reason", "missing unit", "expr", s)
+			}
+			res, ok := roundRangeUnit(u, ops[0])
+			if !ok {
+				return Zero, errors.E("parseRangeExpr", errors.K.Invalid, ErrParse, "reason", "invalid unit", "expr", s)
+			}
+			if roundUp {
+				var err error
+				if res, err = addRangeUnit(res, 1, ops[0]); err != nil {
+					return Zero, errors.E("parseRangeExpr", errors.K.Invalid, err, "expr", s)
+				}
+			}
+			u = res
+			ops = ops[1:]
+		default:
+			return Zero, errors.E("parseRangeExpr", errors.K.Invalid, ErrParse, "reason", "invalid operator", "expr", s)
+		}
+	}
+	return u, nil
+}
+
+// addRangeUnit adds n of the given unit to u. It returns an ErrOutOfRange error if the result does not fit in a
+// time.Duration or lies outside of [Min, Max], and an ErrParse error if the unit is invalid.
+func addRangeUnit(u UTC, n int, unit byte) (UTC, error) {
+	var d time.Duration
+	switch unit {
+	case 's':
+		d = time.Second
+	case 'm':
+		d = time.Minute
+	case 'h':
+		d = time.Hour
+	case 'd', 'w', 'M', 'y':
+		// no date within [Min, Max] is more than maxRangeDays apart from any other
+		if n > maxRangeDays || n < -maxRangeDays {
+			return Zero, errors.E("addRangeUnit", errors.K.Invalid, ErrOutOfRange, "reason", "overflow", "n", n)
+		}
+		var res UTC
+		switch unit {
+		case 'd':
+			res = New(u.Time.AddDate(0, 0, n))
+		case 'w':
+			res = New(u.Time.AddDate(0, 0, 7*n))
+		case 'M':
+			res = New(u.Time.AddDate(0, n, 0))
+		case 'y':
+			res = New(u.Time.AddDate(n, 0, 0))
+		}
+		if res.Before(Min) || res.After(Max) {
+			return Zero, errors.E("addRangeUnit", errors.K.Invalid, ErrOutOfRange,
+				"reason", "year outside of range [0,9999]", "n", n)
+		}
+		return res, nil
+	default:
+		return Zero, errors.E("addRangeUnit", errors.K.Invalid, ErrParse, "reason", "invalid unit")
+	}
+	if int64(n) > math.MaxInt64/int64(d) || int64(n) < math.MinInt64/int64(d) {
+		return Zero, errors.E("addRangeUnit", errors.K.Invalid, ErrOutOfRange, "reason", "overflow", "n", n)
+	}
+	return u.AddChecked(time.Duration(n) * d)
+}
+
+// maxRangeDays is the number of days between Min and Max, rounded up.
+const maxRangeDays = 10000 * 366
+
+// roundRangeUnit rounds u down to the start of the given unit.
+func roundRangeUnit(u UTC, unit byte) (UTC, bool) {
+	switch unit {
+	case 's':
+		return u.Truncate(time.Second), true
+	case 'm':
+		return u.Truncate(time.Minute), true
+	case 'h':
+		return u.Truncate(time.Hour), true
+	case 'd':
+		return u.StartOfDay(), true
+	case 'w':
+		return u.StartOfISOWeek(), true
+	case 'M':
+		return u.FirstOfMonth(), true
+	case 'y':
+		return u.FirstOfYear(), true
+	}
+	return Zero, false
+}
@@ -0,0 +1,82 @@
+package utc_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+func TestParseTimeRange(t *testing.T) {
+	// Wednesday
+	now := utc.MustParse("2020-03-18T10:20:30.400Z")
+	clock := utc.NewWallClock(now)
+
+	tests := []struct {
+		from, to   string
+		start, end string
+	}{
+		{"now-6h", "now", "2020-03-18T04:20:30.400Z", "2020-03-18T10:20:30.400Z"},
+		{"now-1d/d", "now-1d/d", "2020-03-17T00:00:00.000Z", "2020-03-18T00:00:00.000Z"},
+		{"now/d", "now", "2020-03-18T00:00:00.000Z", "2020-03-18T10:20:30.400Z"},
+		{"now/w", "now/w", "2020-03-16T00:00:00.000Z", "2020-03-23T00:00:00.000Z"},
+		{"now-M/M", "now-M/M", "2020-02-01T00:00:00.000Z", "2020-03-01T00:00:00.000Z"},
+		{"now/y", "now+1y/y", "2020-01-01T00:00:00.000Z", "2022-01-01T00:00:00.000Z"},
+		{"now-90m/h", "now-30s/m", "2020-03-18T08:00:00.000Z", "2020-03-18T10:21:00.000Z"},
+		{"now-2w", "now-1d-12h", "2020-03-04T10:20:30.400Z", "2020-03-16T22:20:30.400Z"},
+		{"1577872800000", "2020-01-02", "2020-01-01T10:00:00.000Z", "2020-01-02T00:00:00.000Z"},
+		{" 2020-01-01T10:00:00+01:00 ", "now", "2020-01-01T09:00:00.000Z", "2020-03-18T10:20:30.400Z"},
+	}
+	for _, test := range tests {
+		t.Run(test.from+"_"+test.to, func(t *testing.T) {
+			r, err := utc.ParseTimeRange(test.from, test.to, clock)
+			require.NoError(t, err)
+			require.Equal(t, test.start, r.Start.String())
+			require.Equal(t, test.end, r.End.String())
+		})
+	}
+}
+
+func TestParseTimeRange_nilClock(t *testing.T) {
+	defer utc.NewWallClock(utc.MustParse("2020-03-18T10:20:30Z")).MockNow().UnmockNow()
+
+	r, err := utc.ParseTimeRange("now-1h", "now", nil)
+	require.NoError(t, err)
+	require.Equal(t, "2020-03-18T09:20:30.000Z/2020-03-18T10:20:30.000Z", r.String())
+}
+
+func TestParseTimeRange_invalid(t *testing.T) {
+	clock := utc.NewWallClock(utc.MustParse("2020-03-18T10:20:30Z"))
+	for _, test := range [][2]string{
+		{"", "now"},
+		{"now", ""},
+		{"now-", "now"},
+		{"now-1", "now"},
+		{"now-1x", "now"},
+		{"now/", "now"},
+		{"now/x", "now"},
+		{"now*2", "now"},
+		{"nowhere", "now"},
+		{"blub", "now"},
+		{"now", "now-1h"},
+	} {
+		_, err := utc.ParseTimeRange(test[0], test[1], clock)
+		require.Error(t, err, test)
+	}
+}
+
+func TestParseTimeRange_overflow(t *testing.T) {
+	clock := utc.NewWallClock(utc.MustParse("2020-03-18T10:20:30Z"))
+	for _, test := range [][2]string{
+		{"now-3000000h", "now"},
+		{"now-200000000m", "now"},
+		{"now-10000000000s", "now"},
+		{"now-3000y", "now"},
+		{"now-99999999999d", "now"},
+		{"now", "now+9223372036854775807w"},
+	} {
+		_, err := utc.ParseTimeRange(test[0], test[1], clock)
+		require.ErrorIs(t, err, utc.ErrOutOfRange, test)
+	}
+}
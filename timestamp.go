@@ -0,0 +1,109 @@
+package utc
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/eluv-io/errors-go"
+)
+
+// TimeUnit is the unit of a Timestamp. The values correspond to the values of arrow.TimeUnit of the Apache Arrow Go
+// library, so they can be converted directly: arrow.TimeUnit(utc.Millisecond).
+type TimeUnit int
+
+const (
+	Second TimeUnit = iota
+	Millisecond
+	Microsecond
+	Nanosecond
+)
+
+var timeUnitNames = [...]string{"s", "ms", "us", "ns"}
+
+// String returns the abbreviated name of the unit: "s", "ms", "us" or "ns".
+func (u TimeUnit) String() string {
+	if !u.IsValid() {
+		return "TimeUnit(" + strconv.Itoa(int(u)) + ")"
+	}
+	return timeUnitNames[u]
+}
+
+// IsValid returns true if the unit is one of the defined units.
+func (u TimeUnit) IsValid() bool {
+	return u >= Second && u <= Nanosecond
+}
+
+// Duration returns the duration of the unit, e.g. time.Millisecond for Millisecond.
+func (u TimeUnit) Duration() time.Duration {
+	switch u {
+	case Second:
+		return time.Second
+	case Millisecond:
+		return time.Millisecond
+	case Microsecond:
+		return time.Microsecond
+	}
+	return time.Nanosecond
+}
+
+// Timestamp is a unix timestamp tagged with its unit, as used by Arrow timestamp arrays and Parquet timestamp
+// columns. Carrying the unit along with the value avoids writing values of one precision to a column declared with
+// another.
+//
+// With the Arrow Go library, a Timestamp is converted to and from an arrow.Timestamp of an arrow.TimestampType as
+// follows:
+//
+//	typ := &arrow.TimestampType{Unit: arrow.TimeUnit(ts.Unit), TimeZone: "UTC"}
+//	val := arrow.Timestamp(ts.Value)
+//	...
+//	u := utc.FromTimestamp(int64(val), utc.TimeUnit(typ.Unit))
+//
+// For Parquet, the unit must match the timestamp annotation of the column, e.g. the struct tag
+// `parquet:"created,timestamp(millisecond)"` of parquet-go. Parquet does not support timestamps in seconds.
+type Timestamp struct {
+	Value int64
+	Unit  TimeUnit
+}
+
+// String returns the value followed by the unit, e.g. 1646370367123ms.
+func (t Timestamp) String() string {
+	return strconv.FormatInt(t.Value, 10) + t.Unit.String()
+}
+
+// UTC returns the UTC corresponding to the timestamp.
+func (t Timestamp) UTC() UTC {
+	return FromTimestamp(t.Value, t.Unit)
+}
+
+// ToTimestamp returns u as unix timestamp in the given unit. Precision finer than the unit is truncated (towards the
+// past). An error is returned if the unit is invalid or the timestamp does not fit into an int64, which is the case
+// for nanosecond timestamps outside the years 1678 to 2262.
+func (u UTC) ToTimestamp(unit TimeUnit) (Timestamp, error) {
+	e := errors.Template("ToTimestamp", errors.K.Invalid, ErrOutOfRange, "unit", unit)
+	if !unit.IsValid() {
+		return Timestamp{}, e("reason", "invalid unit")
+	}
+	perSec := int64(time.Second / unit.Duration())
+	sec := u.Unix()
+	frac := int64(u.Nanosecond()) / int64(unit.Duration())
+	if sec < 0 && frac > 0 {
+		// keep the fraction and the seconds on the same side of zero for the range check
+		sec++
+		frac -= perSec
+	}
+	if sec > 0 && sec > (math.MaxInt64-frac)/perSec || sec < 0 && sec < (math.MinInt64-frac)/perSec {
+		return Timestamp{}, e("reason", "timestamp out of range", "utc", u)
+	}
+	return Timestamp{Value: sec*perSec + frac, Unit: unit}, nil
+}
+
+// FromTimestamp returns the UTC corresponding to the given unix timestamp in the given unit. An invalid unit is
+// treated as Nanosecond.
+func FromTimestamp(value int64, unit TimeUnit) UTC {
+	if !unit.IsValid() {
+		unit = Nanosecond
+	}
+	perSec := int64(time.Second / unit.Duration())
+	return New(time.Unix(value/perSec, value%perSec*int64(unit.Duration())))
+}
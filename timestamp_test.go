@@ -0,0 +1,79 @@
+package utc_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+func TestTimestamp(t *testing.T) {
+	u := utc.MustParse("2022-03-04T05:06:07.123456789Z")
+	tests := []struct {
+		unit utc.TimeUnit
+		want int64
+		str  string
+	}{
+		{utc.Second, 1646370367, "1646370367s"},
+		{utc.Millisecond, 1646370367123, "1646370367123ms"},
+		{utc.Microsecond, 1646370367123456, "1646370367123456us"},
+		{utc.Nanosecond, 1646370367123456789, "1646370367123456789ns"},
+	}
+	for _, test := range tests {
+		t.Run(test.unit.String(), func(t *testing.T) {
+			ts, err := u.ToTimestamp(test.unit)
+			require.NoError(t, err)
+			require.Equal(t, utc.Timestamp{Value: test.want, Unit: test.unit}, ts)
+			require.Equal(t, test.str, ts.String())
+			require.True(t, u.Truncate(test.unit.Duration()).Equal(ts.UTC()))
+			require.True(t, ts.UTC().Equal(utc.FromTimestamp(test.want, test.unit)))
+		})
+	}
+}
+
+func TestTimestamp_negative(t *testing.T) {
+	u := utc.MustParse("1969-12-31T23:59:59.9985Z")
+	ts, err := u.ToTimestamp(utc.Millisecond)
+	require.NoError(t, err)
+	require.Equal(t, int64(-2), ts.Value)
+	require.Equal(t, "1969-12-31T23:59:59.998Z", ts.UTC().String())
+}
+
+func TestTimestamp_range(t *testing.T) {
+	for _, unit := range []utc.TimeUnit{utc.Second, utc.Millisecond, utc.Microsecond} {
+		for _, u := range []utc.UTC{utc.Min, utc.Max} {
+			ts, err := u.ToTimestamp(unit)
+			require.NoError(t, err)
+			require.True(t, u.Truncate(unit.Duration()).Equal(ts.UTC()))
+		}
+	}
+
+	_, err := utc.Max.ToTimestamp(utc.Nanosecond)
+	require.Error(t, err)
+	_, err = utc.Min.ToTimestamp(utc.Nanosecond)
+	require.Error(t, err)
+	_, err = utc.Unix(0, 0).ToTimestamp(utc.TimeUnit(7))
+	require.Error(t, err)
+
+	last := utc.Unix(0, 1<<63-1)
+	ts, err := last.ToTimestamp(utc.Nanosecond)
+	require.NoError(t, err)
+	require.Equal(t, int64(1<<63-1), ts.Value)
+	_, err = last.Add(time.Nanosecond).ToTimestamp(utc.Nanosecond)
+	require.Error(t, err)
+
+	first := utc.Unix(0, -1<<63)
+	ts, err = first.ToTimestamp(utc.Nanosecond)
+	require.NoError(t, err)
+	require.Equal(t, int64(-1<<63), ts.Value)
+	_, err = first.Add(-time.Nanosecond).ToTimestamp(utc.Nanosecond)
+	require.Error(t, err)
+}
+
+func TestTimeUnit(t *testing.T) {
+	require.Equal(t, "TimeUnit(7)", utc.TimeUnit(7).String())
+	require.False(t, utc.TimeUnit(-1).IsValid())
+	require.Equal(t, time.Microsecond, utc.Microsecond.Duration())
+}
@@ -0,0 +1,142 @@
+package utc
+
+import (
+	"sync"
+	"time"
+)
+
+// TTLMap is a minimal map with expiring entries. Expiration is based on a Clock, so that tests can advance a TestClock
+// instead of sleeping. Expired entries are removed when accessed, by Purge, or periodically with PurgeEvery. A TTLMap
+// is safe for concurrent use.
+type TTLMap[K comparable, V any] struct {
+	clock   Clock
+	mu      sync.Mutex
+	entries map[K]ttlEntry[V]
+}
+
+type ttlEntry[V any] struct {
+	value   V
+	expires UTC
+}
+
+// NewTTLMap creates a TTLMap with expiration based on the given clock, or on utc.Now() if nil.
+func NewTTLMap[K comparable, V any](clock Clock) *TTLMap[K, V] {
+	return &TTLMap[K, V]{clock: clock, entries: map[K]ttlEntry[V]{}}
+}
+
+// Set sets the value for the given key, expiring after the given TTL.
+func (m *TTLMap[K, V]) Set(key K, value V, ttl time.Duration) {
+	expires := clockNow(m.clock).Add(ttl)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries[key] = ttlEntry[V]{value: value, expires: expires}
+}
+
+// Get returns the value for the given key and true, or the zero value and false if there is no entry or it has
+// expired. Expired entries are removed.
+func (m *TTLMap[K, V]) Get(key K) (V, bool) {
+	v, _, ok := m.GetWithExpiry(key)
+	return v, ok
+}
+
+// GetWithExpiry is like Get, but additionally returns the expiration time of the entry.
+func (m *TTLMap[K, V]) GetWithExpiry(key K) (V, UTC, bool) {
+	now := clockNow(m.clock)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.entries[key]
+	if !ok || !now.Before(e.expires) {
+		if ok {
+			delete(m.entries, key)
+		}
+		var zero V
+		return zero, Zero, false
+	}
+	return e.value, e.expires, true
+}
+
+// Delete removes the entry for the given key.
+func (m *TTLMap[K, V]) Delete(key K) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.entries, key)
+}
+
+// Len returns the number of entries, including expired entries that have not been removed yet.
+func (m *TTLMap[K, V]) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.entries)
+}
+
+// Purge removes all expired entries and returns the number of removed entries.
+func (m *TTLMap[K, V]) Purge() int {
+	now := clockNow(m.clock)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	n := 0
+	for k, e := range m.entries {
+		if !now.Before(e.expires) {
+			delete(m.entries, k)
+			n++
+		}
+	}
+	return n
+}
+
+// PurgeEvery calls Purge periodically with the given interval, using timers of the map's clock - see AfterFunc. The
+// returned function stops the periodic purge. It panics if the interval is not positive.
+func (m *TTLMap[K, V]) PurgeEvery(interval time.Duration) (stop func()) {
+	if interval <= 0 {
+		panic("utc.TTLMap.PurgeEvery: non-positive interval")
+	}
+	mu := sync.Mutex{}
+	stopped := false
+	seq := 0 // incremented on every schedule
+	var cancel func() bool
+	var schedule func()
+	schedule = func() {
+		mu.Lock()
+		if stopped {
+			mu.Unlock()
+			return
+		}
+		seq++
+		n := seq
+		mu.Unlock()
+
+		// arm without holding the lock: timers of a TestClock may fire synchronously
+		armed := false // guarded by mu
+		c := clockAfterFunc(m.clock, clockNow(m.clock).Add(interval), func() {
+			m.Purge()
+			mu.Lock()
+			arming := !armed
+			mu.Unlock()
+			if arming {
+				// fired while arming on a TestClock following the wall clock - see Ticker.schedule
+				go schedule()
+				return
+			}
+			schedule()
+		})
+
+		mu.Lock()
+		defer mu.Unlock()
+		armed = true
+		switch {
+		case stopped:
+			c()
+		case seq == n:
+			cancel = c
+		}
+	}
+	schedule()
+	return func() {
+		mu.Lock()
+		defer mu.Unlock()
+		stopped = true
+		if cancel != nil {
+			cancel()
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package utc_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+func TestTTLMap(t *testing.T) {
+	now := utc.MustParse("2020-01-01T00:00:00Z")
+	clock := utc.NewWallClock(now)
+	m := utc.NewTTLMap[string, int](clock)
+
+	m.Set("a", 1, time.Minute)
+	m.Set("b", 2, time.Hour)
+
+	v, ok := m.Get("a")
+	require.True(t, ok)
+	require.Equal(t, 1, v)
+
+	v, expires, ok := m.GetWithExpiry("b")
+	require.True(t, ok)
+	require.Equal(t, 2, v)
+	require.Equal(t, now.Add(time.Hour), expires)
+
+	_, ok = m.Get("c")
+	require.False(t, ok)
+
+	clock.Add(time.Minute)
+	_, ok = m.Get("a")
+	require.False(t, ok)
+	require.Equal(t, 1, m.Len()) // removed on access
+
+	// overwrite extends the TTL
+	m.Set("b", 3, time.Hour)
+	clock.Add(time.Hour - time.Second)
+	v, ok = m.Get("b")
+	require.True(t, ok)
+	require.Equal(t, 3, v)
+
+	m.Delete("b")
+	_, ok = m.Get("b")
+	require.False(t, ok)
+
+	m.Set("x", 1, time.Second)
+	m.Set("y", 2, time.Second)
+	m.Set("z", 3, time.Hour)
+	clock.Add(time.Second)
+	require.Equal(t, 3, m.Len())
+	require.Equal(t, 2, m.Purge())
+	require.Equal(t, 1, m.Len())
+}
+
+func TestTTLMap_PurgeEvery(t *testing.T) {
+	clock := utc.NewWallClock(utc.MustParse("2020-01-01T00:00:00Z"))
+	m := utc.NewTTLMap[int, string](clock)
+	stop := m.PurgeEvery(time.Minute)
+
+	for i := 0; i < 10; i++ {
+		m.Set(i, "v", time.Duration(i+1)*30*time.Second)
+	}
+	clock.Add(time.Minute)
+	require.Equal(t, 8, m.Len())
+	clock.Add(time.Minute)
+	require.Equal(t, 6, m.Len())
+
+	stop()
+	clock.Add(time.Hour)
+	require.Equal(t, 6, m.Len())
+	require.Equal(t, 6, m.Purge())
+}
+
+func TestTTLMap_PurgeEvery_wallClock(t *testing.T) {
+	// a TestClock following the wall clock fires due timers synchronously when they are armed
+	m := utc.NewTTLMap[string, int](utc.NewTestClock())
+	m.Set("a", 1, time.Nanosecond)
+	stop := m.PurgeEvery(time.Nanosecond)
+	defer stop()
+
+	require.Eventually(t, func() bool { return m.Len() == 0 }, time.Second, time.Millisecond)
+}
@@ -1,6 +1,7 @@
 package utc
 
 import (
+	"bytes"
 	"encoding/json"
 	"time"
 
@@ -9,6 +10,7 @@ import (
 
 const (
 	ISO8601             = "2006-01-02T15:04:05.000Z07:00"
+	ISO8601Nano         = "2006-01-02T15:04:05.000000000Z07:00"
 	ISO8601DateOnlyNoTZ = "2006-01-02"
 	ISO8601DateOnly     = "2006-01-02Z07:00"
 	ISO8601NoMilli      = "2006-01-02T15:04:05Z07:00"
@@ -22,7 +24,7 @@ const (
 var (
 	Zero    = UTC{}                                                           // 0001-01-01T00:00:00.000000000 the zero value of UTC
 	Min     = New(time.Date(0, 1, 1, 0, 0, 0, 0, time.UTC))                   // 0000-01-01T00:00:00.000000000 (Zero - 1 year!)
-	Max     = New(time.Date(9999, 12, 31, 23, 59, 59, 999_999_999, time.UTC)) // 9999-12-31T23:59:59.999999999
+	Max     = New(time.Date(9999, 12, 31, 23, 59, 59, 999_999_999, time.UTC)) // 9999-12-31T23:59:59.999999999 - see IsMax and AddSticky
 	formats = []string{
 		ISO8601,
 		ISO8601DateOnlyNoTZ,
@@ -40,6 +42,11 @@ var (
 // Years smaller than "0000" and larger than "9999" cannot be marshaled to bytes, text, or JSON, and generate an error
 // if attempted.
 //
+// The zero value (Zero) is marshaled to an empty representation: "" in JSON, empty text and empty (nil) binary. All
+// unmarshalers convert the empty representation back to Zero. Zero is distinct from Min (0000-01-01T00:00:00.000Z),
+// which marshals like any other value. IsZero() reports the zero value, hence UTC fields may be tagged with `omitzero`
+// in encoding/json (Go 1.24+). Use ExplicitZero to marshal the zero value as a regular timestamp instead.
+//
 // time.Time keeps track of a "wall clock" for "time telling" as well as a "monotonic clock" for "time measurements" -
 // see documentation of the time package. The monotonic clock is automatically stripped from a Time instance that
 // results from a time operation (e.g. Add, Truncate) as well as timezone changes, unmarshalling, etc.
@@ -71,12 +78,41 @@ func (u *UTC) Mono() time.Time {
 // This is sometimes needed when comparing UTC instances with Go's == operator, which when used on time.Time instances,
 // also compares the mono clock. See doc of time package.
 func (u UTC) StripMono() UTC {
-	return New(u.Time.Truncate(0))
+	// values built with New are in UTC without monotonic clock reading, but struct literals like UTC{Time: time.Now()}
+	// may have both a location and a monotonic clock reading
+	t := u.Time.Truncate(0).UTC()
+	return UTC{Time: t, mono: t}
 }
 
 // String returns the time formatted ISO 8601 format: 2006-01-02T15:04:05.000Z
+//
+// Years outside of [0000, 9999] are clamped by default or formatted with an expanded year depending on the current
+// StringRangePolicy. Use StringChecked to detect such years.
 func (u UTC) String() string {
-	s := []byte("0000-00-00T00:00:00.000Z")
+	if (u.Time.Before(Min.Time) || u.Time.After(Max.Time)) && GetStringRangePolicy() == StringRangeExpand {
+		return u.Expanded().String()
+	}
+	var buf [len(iso8601Template)]byte
+	return string(u.appendISO8601(buf[:0]))
+}
+
+// StringChecked returns the time formatted in ISO 8601 format like String, but returns an error instead of clamping
+// for years outside of [0000, 9999] - independent of the current StringRangePolicy.
+func (u UTC) StringChecked() (string, error) {
+	if err := u.ValidateISO8601(); err != nil {
+		return "", err
+	}
+	var buf [len(iso8601Template)]byte
+	return string(u.appendISO8601(buf[:0])), nil
+}
+
+// iso8601Template is the template for the fixed-size ISO 8601 format produced by appendISO8601.
+const iso8601Template = "0000-00-00T00:00:00.000Z"
+
+// appendISO8601 appends the time formatted in ISO 8601 format 2006-01-02T15:04:05.000Z to b. Years outside of
+// [0000, 9999] are clamped.
+func (u UTC) appendISO8601(b []byte) []byte {
+	s := [len(iso8601Template)]byte([]byte(iso8601Template))
 	year, month, day := u.Date()
 	hour, min, sec := u.Clock()
 	millis := u.Nanosecond() / 1000000
@@ -115,7 +151,7 @@ func (u UTC) String() string {
 	millis /= 10
 	s[20] = byte('0' + millis)
 
-	return string(s)
+	return append(b, s[:]...)
 }
 
 // UnixMilli returns the unix time in milliseconds since 1970-01-01T00:00:00.000Z.
@@ -127,10 +163,80 @@ func (u UTC) Add(d time.Duration) UTC {
 	return New(u.mono.Add(d))
 }
 
+// AddChecked returns u+d like Add, but returns an error if the addition overflows or if the result is outside of the
+// valid range [Min, Max], i.e. if it could not be marshaled.
+func (u UTC) AddChecked(d time.Duration) (UTC, error) {
+	res := u.Add(d)
+	if d > 0 && res.Before(u) || d < 0 && res.After(u) {
+		return Zero, errors.E("UTC.AddChecked", errors.K.Invalid, ErrOutOfRange,
+			"reason", "overflow",
+			"utc", u,
+			"duration", d)
+	}
+	if res.Before(Min) || res.After(Max) {
+		return Zero, errors.E("UTC.AddChecked", errors.K.Invalid, ErrOutOfRange,
+			"reason", "year outside of range [0,9999]",
+			"utc", u,
+			"duration", d)
+	}
+	return res, nil
+}
+
+// AddSat returns u+d like Add, but saturates the result at Min and Max instead of producing an instant outside of the
+// valid range.
+func (u UTC) AddSat(d time.Duration) UTC {
+	res := u.Add(d)
+	switch {
+	case d > 0 && (res.Before(u) || res.After(Max)):
+		return Max
+	case d < 0 && (res.After(u) || res.Before(Min)):
+		return Min
+	}
+	return res
+}
+
+// IsMax returns true if u is Max. Max is commonly used as "end of time" sentinel, e.g. for "never expires". Values after
+// Max (which cannot be marshaled) are not considered Max - see AddSat and AddSticky for arithmetic that does not exceed
+// Max.
+func (u UTC) IsMax() bool {
+	return u.Time.Equal(Max.Time)
+}
+
+// IsMin returns true if u is Min, the "beginning of time" sentinel. Note that Min is distinct from Zero.
+func (u UTC) IsMin() bool {
+	return u.Time.Equal(Min.Time)
+}
+
+// AddSticky returns u+d like AddSat, but treats Min and Max as sentinels: they are returned unchanged for any duration.
+// Use it for instants that may hold the "end of time" or "beginning of time" sentinel, so that e.g. an expiration of
+// Max ("never expires") stays Max when a grace period is added or subtracted:
+//
+//	utc.Max.AddSticky(-time.Hour) == utc.Max
+//	utc.Max.AddSat(-time.Hour)    == utc.Max - 1h
+//
+// Durations between a sentinel and other instants do not need special treatment: Sub saturates at the minimum and
+// maximum time.Duration.
+func (u UTC) AddSticky(d time.Duration) UTC {
+	if u.IsMax() || u.IsMin() {
+		return u
+	}
+	return u.AddSat(d)
+}
+
+// Sub returns the duration u-other. Like time.Time.Sub, it uses the monotonic clock readings if both u and other have
+// one (e.g. both were obtained from Now() or New(time.Now())), and the wall clock times otherwise. The two may differ if
+// the system's wall clock was adjusted in between. Use Sub for measuring elapsed time and SubWall for computing the
+// difference between the timestamps as they are displayed or marshaled.
 func (u UTC) Sub(other UTC) time.Duration {
 	return u.mono.Sub(other.mono)
 }
 
+// SubWall returns the duration u-other computed from the wall clock times, ignoring any monotonic clock readings. The
+// result is always consistent with the difference of the marshaled timestamps (apart from their precision).
+func (u UTC) SubWall(other UTC) time.Duration {
+	return u.Time.Sub(other.Time)
+}
+
 func (u UTC) Truncate(d time.Duration) UTC {
 	return New(u.mono.Truncate(d))
 }
@@ -151,6 +257,69 @@ func (u UTC) Equal(other UTC) bool {
 	return u.Time.Equal(other.Time)
 }
 
+// Identical returns true if u and other represent the same wall clock instant, and either both have no monotonic clock
+// reading or both have the same one. It is stricter than Equal, which ignores the monotonic clock, but unlike Go's ==
+// operator or reflect.DeepEqual, it ignores the time.Location pointers. Use it in tests to verify that a value was
+// passed through untouched.
+func (u UTC) Identical(other UTC) bool {
+	if !u.Time.Equal(other.Time) {
+		return false
+	}
+	hasMono, otherHasMono := hasMonotonic(u.mono), hasMonotonic(other.mono)
+	if hasMono != otherHasMono {
+		return false
+	}
+	// Sub uses the monotonic clock readings if both times have one
+	return !hasMono || u.mono.Sub(other.mono) == 0
+}
+
+// Earliest returns the earliest of the given times, or Zero if none are given. Zero values are compared like any other
+// time: if any of the given times is Zero, the result is Zero unless an even earlier time in year 0 like Min is given
+// - see EarliestNonZero to ignore zero values.
+func Earliest(ts ...UTC) UTC {
+	return pick(ts, false, UTC.Before)
+}
+
+// Latest returns the latest of the given times, or Zero if none are given.
+func Latest(ts ...UTC) UTC {
+	return pick(ts, false, UTC.After)
+}
+
+// EarliestNonZero returns the earliest of the given times that is not Zero, or Zero if there is none.
+func EarliestNonZero(ts ...UTC) UTC {
+	return pick(ts, true, UTC.Before)
+}
+
+// LatestNonZero returns the latest of the given times that is not Zero, or Zero if there is none. It differs from Latest
+// only if all non-zero times are before Zero, e.g. Latest(Min, Zero) returns Zero while LatestNonZero(Min, Zero)
+// returns Min.
+func LatestNonZero(ts ...UTC) UTC {
+	return pick(ts, true, UTC.After)
+}
+
+// pick returns the first of the given times for which better returns true compared to all others, optionally ignoring
+// zero values.
+func pick(ts []UTC, nonZero bool, better func(u, other UTC) bool) UTC {
+	res := Zero
+	found := false
+	for _, u := range ts {
+		if nonZero && u.IsZero() {
+			continue
+		}
+		if !found || better(u, res) {
+			res = u
+			found = true
+		}
+	}
+	return res
+}
+
+// hasMonotonic returns true if the given time has a monotonic clock reading.
+func hasMonotonic(t time.Time) bool {
+	// Round(0) strips the monotonic clock reading and leaves everything else unchanged
+	return t != t.Round(0)
+}
+
 // MarshalJSON implements the json.Marshaler interface. Unlike time.Time, it always marshals milliseconds, even if they
 // are all zeros, i.e. 2006-01-02T15:04:05.000Z instead of 2006-01-02T15:04:05Z
 func (u UTC) MarshalJSON() ([]byte, error) {
@@ -160,24 +329,43 @@ func (u UTC) MarshalJSON() ([]byte, error) {
 	if err := u.ValidateISO8601(); err != nil {
 		return nil, err
 	}
-	return []byte(`"` + u.String() + `"`), nil
+	b := make([]byte, 0, len(iso8601Template)+2)
+	b = append(b, '"')
+	b = u.appendISO8601(b)
+	return append(b, '"'), nil
 }
 
-// UnmarshalJSON implements the json.Unmarshaler interface.
+// UnmarshalJSON implements the json.Unmarshaler interface. Like time.Time, JSON null is a no-op and hence results in
+// the zero value when decoding into a new value. Whitespace surrounding the time string within the quotes is ignored.
 func (u *UTC) UnmarshalJSON(data []byte) error {
-	var s string
-	err := json.Unmarshal(data, &s)
-	if err != nil {
-		return err
+	if string(data) == "null" {
+		return nil
+	}
+	if len(data) < 2 || data[0] != '"' || data[len(data)-1] != '"' {
+		return errors.E("UTC.UnmarshalJSON", errors.K.Invalid, ErrParse, "reason", "not a JSON string", "data", string(data))
 	}
-	return u.UnmarshalText([]byte(s))
+	inner := data[1 : len(data)-1]
+	if bytes.IndexByte(inner, '\\') >= 0 {
+		// slow path for escaped strings
+		var s string
+		err := json.Unmarshal(data, &s)
+		if err != nil {
+			return errors.E("UTC.UnmarshalJSON", errors.K.Invalid, WrapSentinel(ErrParse, err))
+		}
+		inner = []byte(s)
+	}
+	return u.UnmarshalText(bytes.TrimSpace(inner))
 }
 
 // UnmarshalText implements the encoding.TextUnmarshaler interface.
 func (u *UTC) UnmarshalText(data []byte) error {
-	utc, err := FromString(string(data))
-	if err != nil {
-		return err
+	utc, ok := parseISO8601UTC(data)
+	if !ok {
+		var err error
+		utc, err = FromString(string(data))
+		if err != nil {
+			return err
+		}
 	}
 	*(&u.Time) = utc.Time
 	*(&u.mono) = u.Time
@@ -190,10 +378,19 @@ func (u UTC) MarshalText() ([]byte, error) {
 	if u.IsZero() {
 		return nil, nil
 	}
+	return u.AppendText(make([]byte, 0, len(iso8601Template)))
+}
+
+// AppendText implements the encoding.TextAppender interface: it appends the text representation of MarshalText to b
+// and returns the extended buffer. Like MarshalText, the zero value appends nothing.
+func (u UTC) AppendText(b []byte) ([]byte, error) {
+	if u.IsZero() {
+		return b, nil
+	}
 	if err := u.ValidateISO8601(); err != nil {
 		return nil, err
 	}
-	return []byte(u.String()), nil
+	return u.appendISO8601(b), nil
 }
 
 // MarshalBinary implements the encoding.BinaryMarshaler interface.
@@ -204,7 +401,11 @@ func (u UTC) MarshalBinary() ([]byte, error) {
 	if err := u.ValidateISO8601(); err != nil {
 		return nil, err
 	}
+	return u.encodeBinary(), nil
+}
 
+// encodeBinary encodes this UTC to its binary form. The year must be in the range [0000, 9999].
+func (u UTC) encodeBinary() []byte {
 	// marshal/unmarshal adapted from time.Time
 	// reduces binary form to 9 bytes (from 15) because of the limited year range.
 
@@ -228,7 +429,7 @@ func (u UTC) MarshalBinary() ([]byte, error) {
 		//byte(offsetMin >> 8), // bytes 13-14: zone offset in minutes
 		//byte(offsetMin),
 	}
-	return enc, nil
+	return enc
 }
 
 // UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
@@ -242,7 +443,7 @@ func (u *UTC) UnmarshalBinary(data []byte) error {
 
 	expectedLen := /*sec*/ 5 + /*nsec*/ 4
 	if len(buf) != expectedLen {
-		return errors.E("UTC.UnmarshalBinary", errors.K.Invalid,
+		return errors.E("UTC.UnmarshalBinary", errors.K.Invalid, ErrInvalidLength,
 			"reason", "invalid length (expected 9)",
 			"length", len(buf))
 	}
@@ -261,28 +462,124 @@ func (u *UTC) UnmarshalBinary(data []byte) error {
 // ValidateISO8601 validates that this UTC represents a valid ISO 8601 date, where the year is in [0000, 9999].
 func (u UTC) ValidateISO8601() error {
 	// see time.Time.MarshalJSON()
-	if y := u.Year(); y < 0 || y >= 10000 {
+	if !u.IsValid() {
 		// ISO8601 / RFC3339 is clear that years are 4 digits exactly.
 		// See golang.org/issue/4556#c15 for more discussion.
-		return errors.E("UTC.ValidateISO8601", errors.K.Invalid, "reason", "year outside of range [0,9999]", "utc", u)
+		return errors.E("UTC.ValidateISO8601", errors.K.Invalid, ErrOutOfRange,
+			"reason", "year outside of range [0,9999]",
+			"utc", u)
 	}
 	return nil
 }
 
-// FromString parses the given time string.
+// IsValid returns true if this UTC represents a valid ISO 8601 date, where the year is in [0000, 9999]. Only valid
+// dates can be marshaled.
+func (u UTC) IsValid() bool {
+	y := u.Year()
+	return y >= 0 && y < 10000
+}
+
+// ClampToValid returns this UTC if it is valid, otherwise Min for dates before year 0000 and Max for dates after year
+// 9999.
+func (u UTC) ClampToValid() UTC {
+	switch {
+	case u.Year() < 0:
+		return Min
+	case u.Year() >= 10000:
+		return Max
+	}
+	return u
+}
+
+// FromString parses the given time string. Timestamps with a leap second (seconds field set to 60) are handled
+// according to the current LeapSecondPolicy. Years outside of [0000, 9999] are accepted in the ISO 8601 expanded year
+// representation ±YYYYYY, e.g. +010000-01-01T00:00:00.000Z - see ExpandedYear. Results may be cached - see
+// SetParseCacheSize.
 func FromString(s string) (UTC, error) {
-	var t time.Time
-	var err error
 	if s == "" {
 		return Zero, nil
 	}
+	cache := parseCache.Load()
+	if cache != nil {
+		if u, ok := cache.get(s); ok {
+			return smearPrecedingSecond(u), nil
+		}
+	}
+	u, err := parseFormats(s)
+	if err == nil {
+		if cache != nil {
+			cache.add(s, u)
+		}
+		return smearPrecedingSecond(u), nil
+	}
+	if u, _, ok, lerr := parseLeapSecond(s); ok {
+		return u, lerr
+	}
+	return Zero, errors.E("parse", WrapSentinel(ErrParse, err), "utc", s)
+}
+
+// parseFormats parses the given time string with the supported ISO 8601 formats.
+func parseFormats(s string) (UTC, error) {
+	t, err := parseTime(s)
+	if err != nil {
+		return Zero, err
+	}
+	return New(t.UTC()), nil
+}
+
+// parseTime parses the given time string with the supported ISO 8601 formats and returns the time in the parsed
+// timezone offset, or in UTC if the string has no timezone.
+func parseTime(s string) (t time.Time, err error) {
 	for _, format := range formats {
 		t, err = time.ParseInLocation(format, s, time.UTC)
 		if err == nil {
-			return New(t.UTC()), nil
+			return t, nil
+		}
+	}
+	if et, ok, eerr := parseExpandedYear(s); ok {
+		return et, eerr
+	}
+	return time.Time{}, err
+}
+
+// parseISO8601UTC is a fast, allocation-free parser for the most common format: ISO 8601 in UTC with an optional
+// fraction of up to 9 digits, e.g. 2006-01-02T15:04:05.000Z. It returns false for all other formats and for leap
+// seconds, which are handled by FromString.
+func parseISO8601UTC(b []byte) (UTC, bool) {
+	// 2006-01-02T15:04:05Z
+	if len(b) < 20 || b[4] != '-' || b[7] != '-' || b[10] != 'T' || b[13] != ':' || b[16] != ':' || b[len(b)-1] != 'Z' {
+		return Zero, false
+	}
+	num := func(from, to int) int {
+		n := 0
+		for i := from; i < to; i++ {
+			c := b[i]
+			if c < '0' || c > '9' {
+				return -1
+			}
+			n = n*10 + int(c-'0')
 		}
+		return n
+	}
+	year, month, day := num(0, 4), num(5, 7), num(8, 10)
+	hour, min, sec := num(11, 13), num(14, 16), num(17, 19)
+	if year < 0 || month < 1 || month > 12 || day < 1 || day > DaysInMonth(year, time.Month(month)) ||
+		hour < 0 || hour > 23 || min < 0 || min > 59 || sec < 0 || sec > 59 {
+		return Zero, false
 	}
-	return Zero, errors.E("parse", err, "utc", s)
+	nsec := 0
+	if frac := b[19 : len(b)-1]; len(frac) > 0 {
+		if len(frac) < 2 || len(frac) > 10 || frac[0] != '.' {
+			return Zero, false
+		}
+		if nsec = num(20, len(b)-1); nsec < 0 {
+			return Zero, false
+		}
+		for i := len(frac) - 1; i < 9; i++ {
+			nsec *= 10
+		}
+	}
+	return New(time.Date(year, time.Month(month), day, hour, min, sec, nsec, time.UTC)), true
 }
 
 // MustParse parses the given time string according to ISO 8601 format, panicking in case of errors.
@@ -294,13 +591,14 @@ func MustParse(s string) UTC {
 	return utc
 }
 
-// Parse parses the given time value string with the provided layout - see Time.Parse()
+// Parse parses the given time value string with the provided layout - see Time.Parse(). Errors wrap ErrParse and the
+// *time.ParseError.
 func Parse(layout string, value string) (UTC, error) {
 	t, err := time.Parse(layout, value)
 	if err != nil {
-		return Zero, err
+		return Zero, errors.E("Parse", errors.K.Invalid, WrapSentinel(ErrParse, err), "layout", layout, "value", value)
 	}
-	return New(t), nil
+	return NewWall(t), nil
 }
 
 // Unix returns the local Time corresponding to the given Unix time, sec seconds and nsec nanoseconds since January 1,
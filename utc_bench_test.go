@@ -1,12 +1,13 @@
 package utc
 
 import (
+	"fmt"
 	"testing"
 	"time"
 )
 
 // BenchmarkNow test performances of the various now functions:
-// - utc.Now using nowFn
+// - utc.Now using the default clock or a mocked clock
 // - utc.Mono returns the straight time.Now
 // - utc.WallClock strips the monotonic clock
 // - utc.WallClockMs strips the monotonic clock and rounds to millisecond
@@ -47,9 +48,10 @@ func BenchmarkNowClock(b *testing.B) {
 func doBenchmarkNow(b *testing.B, withClock bool) {
 	b.StopTimer()
 	if !withClock {
-		nowFn = now
+		ResetNow()
 	} else {
-		allowClock()
+		setClock(ClockFn(now))
+		defer ResetNow()
 	}
 	b.StartTimer()
 	benchmarks := []struct {
@@ -86,7 +88,7 @@ func doBenchmarkNow(b *testing.B, withClock bool) {
 // BenchmarkTimeNow/utc.WallClockMs-8  	 5173959	       223.3 ns/op	       0 B/op	       0 allocs/op
 func BenchmarkTimeNow(b *testing.B) {
 	b.StopTimer()
-	nowFn = now
+	ResetNow()
 	b.StartTimer()
 	benchmarks := []struct {
 		name string
@@ -108,3 +110,80 @@ func BenchmarkTimeNow(b *testing.B) {
 		})
 	}
 }
+
+func BenchmarkUnmarshalJSON(b *testing.B) {
+	benchmarks := []struct {
+		name string
+		jsn  []byte
+	}{
+		{"utc", []byte(`"2006-01-02T15:04:05.000Z"`)},
+		{"offset", []byte(`"2006-01-02T15:04:05.000+01:00"`)},
+		{"escaped", []byte(`"2006-01-02T15:04:05\u002e000Z"`)},
+	}
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			b.ReportAllocs()
+			var u UTC
+			for i := 0; i < b.N; i++ {
+				_ = u.UnmarshalJSON(bm.jsn)
+			}
+		})
+	}
+}
+
+func BenchmarkFromStringCached(b *testing.B) {
+	s := "2006-01-02T15:04:05.000+01:00"
+	for _, size := range []int{0, 1000} {
+		b.Run(fmt.Sprintf("cache-%d", size), func(b *testing.B) {
+			SetParseCacheSize(size)
+			defer SetParseCacheSize(0)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_, _ = FromString(s)
+			}
+		})
+	}
+}
+
+func BenchmarkNewWall(b *testing.B) {
+	t := time.Now()
+	u := New(t)
+	benchmarks := []struct {
+		name string
+		fn   func()
+	}{
+		{"New", func() { _ = New(t) }},
+		{"NewWall", func() { _ = NewWall(t) }},
+		{"StripMono", func() { _ = u.StripMono() }},
+	}
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				bm.fn()
+			}
+		})
+	}
+}
+
+func BenchmarkMarshal(b *testing.B) {
+	u := New(time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC))
+	buf := make([]byte, 0, 64)
+	benchmarks := []struct {
+		name string
+		fn   func()
+	}{
+		{"String", func() { _ = u.String() }},
+		{"MarshalText", func() { _, _ = u.MarshalText() }},
+		{"MarshalJSON", func() { _, _ = u.MarshalJSON() }},
+		{"AppendText", func() { _, _ = u.AppendText(buf[:0]) }},
+	}
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				bm.fn()
+			}
+		})
+	}
+}
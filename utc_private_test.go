@@ -2,6 +2,7 @@ package utc
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -9,3 +10,78 @@ import (
 func TestYearZeroOffset(t *testing.T) {
 	require.Equal(t, -yearZeroOffsetSec, Min.Unix())
 }
+
+func TestParseISO8601UTC(t *testing.T) {
+	for _, s := range []string{
+		"2006-01-02T15:04:05Z",
+		"2006-01-02T15:04:05.1Z",
+		"2006-01-02T15:04:05.000Z",
+		"2006-01-02T15:04:05.123456789Z",
+		"0000-01-01T00:00:00.000Z",
+		"9999-12-31T23:59:59.999999999Z",
+		"2020-02-29T00:00:00.000Z",
+	} {
+		fast, ok := parseISO8601UTC([]byte(s))
+		require.True(t, ok, s)
+		slow, err := parseFormats(s)
+		require.NoError(t, err, s)
+		require.Equal(t, slow, fast, s)
+	}
+
+	// formats not handled by the fast path
+	for _, s := range []string{
+		"",
+		"2006-01-02",
+		"2006-01-02T15:04:05+01:00",
+		"2006-01-02T15:04:05.Z",
+		"2006-01-02T15:04:05.1234567890Z",
+		"2006-01-02T15:04:05,000Z",
+		"2006-01-02T23:59:60Z",
+		"2006-02-29T00:00:00Z",
+		"2006-13-01T00:00:00Z",
+		"2006-01-02T24:00:00Z",
+		"2006-01-02T15:04:5xZ",
+		"-006-01-02T15:04:05Z",
+	} {
+		_, ok := parseISO8601UTC([]byte(s))
+		require.False(t, ok, s)
+	}
+}
+
+func TestUTC_SubWall(t *testing.T) {
+	start := New(time.Now())
+	later := start.Add(time.Second)
+	require.Equal(t, time.Second, later.Sub(start))
+	require.Equal(t, time.Second, later.SubWall(start))
+
+	// simulate a wall clock adjustment of one hour between the two readings: the monotonic clock is unaffected
+	adjusted := UTC{Time: later.Time.Add(time.Hour), mono: later.mono}
+	require.Equal(t, time.Second, adjusted.Sub(start))
+	require.Equal(t, time.Hour+time.Second, adjusted.SubWall(start))
+
+	// without monotonic clock, both are the same
+	parsed := MustParse(adjusted.Time.Format(time.RFC3339Nano))
+	require.Equal(t, time.Hour+time.Second, parsed.Sub(start.StripMono()))
+	require.Equal(t, time.Hour+time.Second, parsed.SubWall(start))
+}
+
+func TestUTC_Identical(t *testing.T) {
+	now := New(time.Now())
+	require.True(t, now.Identical(now))
+	require.True(t, now.Identical(UTC{Time: now.Time, mono: now.mono}))
+
+	stripped := now.StripMono()
+	require.True(t, now.Equal(stripped))
+	require.False(t, now.Identical(stripped))
+	require.False(t, stripped.Identical(now))
+	require.True(t, stripped.Identical(stripped))
+
+	// same wall time, different monotonic readings
+	other := UTC{Time: now.Time, mono: now.mono.Add(time.Nanosecond)}
+	require.False(t, now.Identical(other))
+
+	// different locations of the same instant
+	parsed := MustParse("2020-01-01T10:00:00Z")
+	require.True(t, parsed.Identical(New(time.Date(2020, 1, 1, 11, 0, 0, 0, time.FixedZone("", 3600)))))
+	require.False(t, parsed.Identical(parsed.Add(time.Nanosecond)))
+}
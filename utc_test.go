@@ -36,7 +36,7 @@ var dates = func() []utc.UTC {
 	}
 	// add random dates
 	for i := 0; i < 5; i++ {
-		d = append(d, utc.UnixMilli(utc.Min.UnixMilli()+rnd.Int63n(utc.Max.UnixMilli())))
+		d = append(d, utc.RandBetween(rnd, utc.Min, utc.Max))
 	}
 	return d
 }()
@@ -49,8 +49,8 @@ var durations = func() []time.Duration {
 	d := []time.Duration{time.Millisecond, 5 * time.Second, 10 * time.Hour}
 	// add random durations
 	for i := 0; i < 5; i++ {
-		max := 1_000_000 * int64(time.Hour)
-		d = append(d, time.Duration(rnd.Int63n(2*max)-max))
+		max := 1_000_000 * time.Hour
+		d = append(d, utc.RandDuration(rnd, -max, max))
 	}
 	return d
 }()
@@ -600,9 +600,273 @@ func TestWall(t *testing.T) {
 	now := utc.Now()
 	wall := utc.WallNow()
 	wallMs := utc.WallNowMs()
+	wallUs := utc.WallNowUs()
+	wallSec := utc.WallNowSec()
 
 	ws := wall.Sub(now)
 	require.True(t, ws <= time.Microsecond*100, "ws: %v", ws)
 	ws = wallMs.Sub(now)
 	require.True(t, ws <= time.Millisecond, "ws: %v", ws)
+	ws = wallUs.Sub(now)
+	require.True(t, ws <= time.Microsecond*100, "ws: %v", ws)
+	require.Zero(t, wallUs.Nanosecond()%1000)
+	ws = wallSec.Sub(now)
+	require.True(t, ws <= time.Second, "ws: %v", ws)
+	require.Zero(t, wallSec.Nanosecond())
+}
+
+func TestEarliestLatest(t *testing.T) {
+	d2020 := utc.MustParse("2020-01-01")
+	d2021 := utc.MustParse("2021-01-01")
+	d2022 := utc.MustParse("2022-01-01")
+
+	require.Equal(t, utc.Zero, utc.Earliest())
+	require.Equal(t, utc.Zero, utc.Latest())
+	require.Equal(t, utc.Zero, utc.EarliestNonZero())
+	require.Equal(t, utc.Zero, utc.LatestNonZero())
+	require.Equal(t, utc.Zero, utc.EarliestNonZero(utc.Zero, utc.Zero))
+
+	require.Equal(t, d2020, utc.Earliest(d2021, d2020, d2022))
+	require.Equal(t, d2022, utc.Latest(d2021, d2022, d2020))
+	require.Equal(t, d2021, utc.Earliest(d2021))
+
+	require.Equal(t, utc.Zero, utc.Earliest(d2021, utc.Zero, d2020))
+	require.Equal(t, d2020, utc.EarliestNonZero(d2021, utc.Zero, d2020))
+	require.Equal(t, d2021, utc.Latest(utc.Zero, d2021, d2020))
+	require.Equal(t, d2021, utc.LatestNonZero(utc.Zero, d2021, d2020))
+
+	require.Equal(t, utc.Min, utc.EarliestNonZero(utc.Max, utc.Min, utc.Zero))
+	require.Equal(t, utc.Max, utc.Latest(utc.Max, utc.Min))
+
+	// Min is before Zero
+	require.Equal(t, utc.Min, utc.Earliest(utc.Min, utc.Zero))
+	require.Equal(t, utc.Min, utc.EarliestNonZero(utc.Min, utc.Zero))
+	require.Equal(t, utc.Zero, utc.Latest(utc.Min, utc.Zero))
+	require.Equal(t, utc.Min, utc.LatestNonZero(utc.Min, utc.Zero))
+
+	// the first of equal times is returned
+	now := utc.Now()
+	require.True(t, now.Identical(utc.Earliest(now.Add(time.Hour), now, now.StripMono())))
+	require.True(t, now.Identical(utc.Latest(now.Add(-time.Hour), now, now.StripMono())))
+}
+
+func TestUTC_AddChecked(t *testing.T) {
+	d2020 := utc.MustParse("2020-01-01")
+	res, err := d2020.AddChecked(time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, d2020.Add(time.Hour), res)
+
+	for _, test := range []struct {
+		u utc.UTC
+		d time.Duration
+	}{
+		{utc.Max, time.Hour},
+		{utc.Max, 1},
+		{utc.Min, -1},
+		{utc.Min, math.MinInt64},
+	} {
+		_, err = test.u.AddChecked(test.d)
+		require.Error(t, err, "%s %s", test.u, test.d)
+	}
+
+	res, err = utc.Max.AddChecked(-time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, utc.Max.Add(-time.Hour), res)
+}
+
+func TestUTC_AddSat(t *testing.T) {
+	d2020 := utc.MustParse("2020-01-01")
+	require.Equal(t, d2020.Add(time.Hour), d2020.AddSat(time.Hour))
+	require.Equal(t, utc.Max, utc.Max.AddSat(time.Hour))
+	require.Equal(t, utc.Max, utc.Max.Add(-time.Hour).AddSat(2*time.Hour))
+	require.Equal(t, utc.Min, utc.Min.AddSat(-time.Hour))
+	require.Equal(t, utc.Min, utc.Min.AddSat(math.MinInt64))
+	require.Equal(t, utc.Max.Add(-time.Hour), utc.Max.AddSat(-time.Hour))
+
+	_, err := utc.Max.AddSat(time.Hour).MarshalText()
+	require.NoError(t, err)
+}
+
+func TestUTC_IsMaxIsMin(t *testing.T) {
+	require.True(t, utc.Max.IsMax())
+	require.True(t, utc.Max.StripMono().IsMax())
+	require.True(t, utc.MustParse("9999-12-31T23:59:59.999999999Z").IsMax())
+	require.False(t, utc.Max.Add(-1).IsMax())
+	require.False(t, utc.Max.Add(1).IsMax())
+	require.False(t, utc.Max.IsMin())
+
+	require.True(t, utc.Min.IsMin())
+	require.True(t, utc.MustParse("0000-01-01T00:00:00Z").IsMin())
+	require.False(t, utc.Min.Add(1).IsMin())
+	require.False(t, utc.Min.IsMax())
+
+	require.False(t, utc.Zero.IsMin())
+	require.False(t, utc.Zero.IsMax())
+}
+
+func TestUTC_AddSticky(t *testing.T) {
+	d2020 := utc.MustParse("2020-01-01")
+	require.Equal(t, d2020.Add(time.Hour), d2020.AddSticky(time.Hour))
+	require.Equal(t, d2020.Add(-time.Hour), d2020.AddSticky(-time.Hour))
+
+	for _, d := range []time.Duration{time.Hour, -time.Hour, math.MaxInt64, math.MinInt64, 0} {
+		require.True(t, utc.Max.AddSticky(d).IsMax(), d)
+		require.True(t, utc.Min.AddSticky(d).IsMin(), d)
+	}
+
+	// saturates like AddSat
+	require.True(t, utc.Max.Add(-time.Hour).AddSticky(2*time.Hour).IsMax())
+	require.True(t, utc.Min.Add(time.Hour).AddSticky(-2*time.Hour).IsMin())
+
+	// durations to and from the sentinels saturate
+	require.Equal(t, time.Duration(math.MaxInt64), utc.Max.Sub(d2020))
+	require.Equal(t, time.Duration(math.MinInt64), utc.Min.Sub(d2020))
+}
+
+func TestUTC_IsValid(t *testing.T) {
+	testFnOneDate(t, func(t *testing.T, date utc.UTC) {
+		require.True(t, date.IsValid())
+		require.Equal(t, date, date.ClampToValid())
+	})
+	require.False(t, yearTooSmall.IsValid())
+	require.False(t, yearTooLarge.IsValid())
+	require.Equal(t, utc.Min, yearTooSmall.ClampToValid())
+	require.Equal(t, utc.Max, yearTooLarge.ClampToValid())
+
+	for _, date := range invalidISO8601 {
+		_, err := date.ClampToValid().MarshalJSON()
+		require.NoError(t, err)
+	}
+}
+
+func TestNewChecked(t *testing.T) {
+	testFnOneDate(t, func(t *testing.T, date utc.UTC) {
+		u, err := utc.NewChecked(date.Time)
+		require.NoError(t, err)
+		require.True(t, date.Equal(u))
+	})
+	for _, date := range []utc.UTC{yearTooSmall, yearTooLarge} {
+		_, err := utc.NewChecked(date.Time)
+		require.Error(t, err)
+		_, err = utc.NewCheckedNonZero(date.Time)
+		require.Error(t, err)
+	}
+
+	u, err := utc.NewChecked(time.Time{})
+	require.NoError(t, err)
+	require.True(t, u.IsZero())
+
+	_, err = utc.NewCheckedNonZero(time.Time{})
+	require.Error(t, err)
+	u, err = utc.NewCheckedNonZero(time.Date(2020, 1, 1, 0, 0, 0, 0, time.FixedZone("", 3600)))
+	require.NoError(t, err)
+	require.Equal(t, "2019-12-31T23:00:00.000Z", u.String())
+}
+
+func TestStripMono_literal(t *testing.T) {
+	now := time.Now()
+	u := utc.UTC{Time: now}.StripMono()
+	require.Equal(t, time.UTC, u.Location())
+	require.Equal(t, now.Round(0).UTC(), u.Time)
+	require.NotContains(t, u.Time.String(), "m=")
+	require.True(t, u == utc.New(now).StripMono())
+	require.True(t, u.Equal(utc.New(now)))
+}
+
+func TestNewWall(t *testing.T) {
+	now := time.Now()
+	u := utc.NewWall(now)
+	require.True(t, u.Equal(utc.New(now)))
+	require.True(t, u.Identical(utc.New(now).StripMono()))
+	require.True(t, u == utc.New(now).StripMono())
+	require.Equal(t, time.UTC, u.Mono().Location())
+
+	zoned := time.Date(2020, 1, 1, 0, 0, 0, 0, time.FixedZone("", 3600))
+	u = utc.NewWall(zoned)
+	require.Equal(t, "2019-12-31T23:00:00.000Z", u.String())
+	require.Equal(t, time.UTC, u.Mono().Location())
+	require.True(t, u == utc.MustParse("2019-12-31T23:00:00Z"))
+
+	require.True(t, utc.NewWall(time.Time{}).IsZero())
+}
+
+func TestJSONUnmarshal_lenient(t *testing.T) {
+	type withDate struct {
+		Date utc.UTC `json:"date"`
+	}
+	tests := []struct {
+		jsn  string
+		want utc.UTC
+	}{
+		{`{"date":null}`, utc.Zero},
+		{`{"date":" ` + oneBillionString + `\t"}`, utc.New(oneBillion)},
+		{`{"date":"  "}`, utc.Zero},
+	}
+	for _, test := range tests {
+		t.Run(test.jsn, func(t *testing.T) {
+			var res withDate
+			require.NoError(t, json.Unmarshal([]byte(test.jsn), &res))
+			require.True(t, test.want.Equal(res.Date))
+		})
+	}
+
+	// null is a no-op, like for time.Time
+	res := withDate{Date: utc.New(oneBillion)}
+	require.NoError(t, json.Unmarshal([]byte(`{"date":null}`), &res))
+	require.True(t, utc.New(oneBillion).Equal(res.Date))
+}
+
+func TestJSONUnmarshal_escaped(t *testing.T) {
+	var res utc.UTC
+	require.NoError(t, json.Unmarshal([]byte(`"2001-09-09T01:46:40\u002e000Z"`), &res))
+	require.True(t, utc.New(oneBillion).Equal(res))
+
+	for _, jsn := range []string{`123`, `true`, `"`, `"2001-09-09T01:46:40.000Z`} {
+		require.Error(t, res.UnmarshalJSON([]byte(jsn)), jsn)
+	}
+}
+
+func TestUTC_AppendText(t *testing.T) {
+	u := utc.New(oneBillion)
+	b, err := u.AppendText([]byte("at "))
+	require.NoError(t, err)
+	require.Equal(t, "at "+oneBillionString, string(b))
+
+	b, err = utc.Zero.AppendText([]byte("at "))
+	require.NoError(t, err)
+	require.Equal(t, "at ", string(b))
+
+	_, err = utc.New(time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC)).AppendText(nil)
+	require.Error(t, err)
+
+	z, err := utc.ParseZoned("2001-09-09T03:46:40.000+02:00")
+	require.NoError(t, err)
+	b, err = z.AppendText(nil)
+	require.NoError(t, err)
+	require.Equal(t, "2001-09-09T03:46:40.000+02:00", string(b))
+
+	b, err = utc.ExplicitZero{}.AppendText(nil)
+	require.NoError(t, err)
+	require.Equal(t, "0001-01-01T00:00:00.000Z", string(b))
+
+	b, err = utc.UTCUnixMilli{UTC: u}.AppendText(nil)
+	require.NoError(t, err)
+	require.Equal(t, "1000000000000", string(b))
+}
+
+func TestUTC_MarshalAllocs(t *testing.T) {
+	u := utc.New(oneBillion)
+	buf := make([]byte, 0, 64)
+	require.Equal(t, 0.0, testing.AllocsPerRun(100, func() {
+		_, _ = u.AppendText(buf[:0])
+	}))
+	require.LessOrEqual(t, testing.AllocsPerRun(100, func() {
+		_, _ = u.MarshalJSON()
+	}), 1.0)
+	require.LessOrEqual(t, testing.AllocsPerRun(100, func() {
+		_, _ = u.MarshalText()
+	}), 1.0)
+	require.LessOrEqual(t, testing.AllocsPerRun(100, func() {
+		_ = u.String()
+	}), 1.0)
 }
@@ -0,0 +1,68 @@
+package utc
+
+import (
+	"math"
+	"time"
+
+	"github.com/eluv-io/errors-go"
+)
+
+// Validity is the validity window of a token, e.g. the "nbf" and "exp" claims of a JWT or the expiration of a signed
+// URL. Zero bounds are not checked.
+type Validity struct {
+	NotBefore UTC // the token is not valid before this instant
+	ExpiresAt UTC // the token is not valid at or after this instant
+}
+
+// ValidityFromUnix creates a Validity from the given unix timestamps in seconds, as used by the NumericDate claims of
+// JWTs. Timestamps of 0 denote a missing claim.
+func ValidityFromUnix(notBefore, expiresAt int64) Validity {
+	v := Validity{}
+	if notBefore != 0 {
+		v.NotBefore = Unix(notBefore, 0)
+	}
+	if expiresAt != 0 {
+		v.ExpiresAt = Unix(expiresAt, 0)
+	}
+	return v
+}
+
+// Valid returns nil if the token is valid at the current time of the given clock (or utc.Now() if nil), and an error
+// otherwise. The leeway allows for clock skew between issuer and validator: it extends the window on both sides, i.e.
+// the token is valid in [NotBefore - leeway, ExpiresAt + leeway). Typical values are a few seconds up to a minute.
+func (v Validity) Valid(clock Clock, leeway time.Duration) error {
+	now := clockNow(clock)
+	if !v.ExpiresAt.IsZero() && !now.Before(v.ExpiresAt.Add(leeway)) {
+		return errors.E("Validity.Valid", errors.K.Permission,
+			"reason", "token expired",
+			"expires_at", v.ExpiresAt,
+			"now", now,
+			"leeway", leeway)
+	}
+	if !v.NotBefore.IsZero() && now.Before(v.NotBefore.Add(-leeway)) {
+		return errors.E("Validity.Valid", errors.K.Permission,
+			"reason", "token not yet valid",
+			"not_before", v.NotBefore,
+			"now", now,
+			"leeway", leeway)
+	}
+	return nil
+}
+
+// IsValid returns true if Valid returns nil.
+func (v Validity) IsValid(clock Clock, leeway time.Duration) bool {
+	return v.Valid(clock, leeway) == nil
+}
+
+// Remaining returns the time until the token expires at the current time of the given clock, not including any
+// leeway. It returns 0 if the token has expired, and the maximum duration if it has no expiration.
+func (v Validity) Remaining(clock Clock) time.Duration {
+	if v.ExpiresAt.IsZero() {
+		return time.Duration(math.MaxInt64)
+	}
+	d := v.ExpiresAt.Sub(clockNow(clock))
+	if d < 0 {
+		return 0
+	}
+	return d
+}
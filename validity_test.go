@@ -0,0 +1,57 @@
+package utc_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+func TestValidity(t *testing.T) {
+	now := utc.MustParse("2020-01-01T00:00:00Z")
+	clock := utc.NewWallClock(now)
+	v := utc.Validity{NotBefore: now.Add(time.Minute), ExpiresAt: now.Add(time.Hour)}
+
+	require.Error(t, v.Valid(clock, 0))
+	require.Error(t, v.Valid(clock, 59*time.Second))
+	require.NoError(t, v.Valid(clock, time.Minute))
+
+	clock.Set(now.Add(time.Minute))
+	require.NoError(t, v.Valid(clock, 0))
+	require.True(t, v.IsValid(clock, 0))
+	require.Equal(t, 59*time.Minute, v.Remaining(clock))
+
+	clock.Set(now.Add(time.Hour - time.Nanosecond))
+	require.NoError(t, v.Valid(clock, 0))
+	clock.Set(now.Add(time.Hour))
+	require.Error(t, v.Valid(clock, 0))
+	require.False(t, v.IsValid(clock, 0))
+	require.NoError(t, v.Valid(clock, time.Second))
+	require.Equal(t, time.Duration(0), v.Remaining(clock))
+
+	clock.Set(now.Add(time.Hour + time.Second))
+	require.Error(t, v.Valid(clock, time.Second))
+
+	// unbounded
+	require.NoError(t, utc.Validity{}.Valid(clock, 0))
+	require.Equal(t, time.Duration(math.MaxInt64), utc.Validity{}.Remaining(clock))
+}
+
+func TestValidityFromUnix(t *testing.T) {
+	v := utc.ValidityFromUnix(0, 1577836800)
+	require.True(t, v.NotBefore.IsZero())
+	require.Equal(t, "2020-01-01T00:00:00.000Z", v.ExpiresAt.String())
+
+	clock := utc.NewWallClock(utc.MustParse("2019-12-31T23:59:30Z"))
+	require.NoError(t, v.Valid(clock, 0))
+	clock.Add(time.Minute)
+	require.Error(t, v.Valid(clock, 0))
+	require.NoError(t, v.Valid(clock, time.Minute))
+
+	v = utc.ValidityFromUnix(1577836800, 0)
+	require.Equal(t, "2020-01-01T00:00:00.000Z", v.NotBefore.String())
+	require.True(t, v.ExpiresAt.IsZero())
+}
@@ -0,0 +1,66 @@
+package utc
+
+import (
+	"time"
+)
+
+// VirtualBench runs benchmark loops in virtual time: it owns a TestClock that is installed as global clock while the
+// loop is running and advanced by a scripted step after each iteration. Time-dependent code - caches with expiry, rate
+// limiters, schedulers - is thereby benchmarked deterministically, independently of the speed of the machine and
+// without wall clock noise:
+//
+//	func BenchmarkLimiter(b *testing.B) {
+//		vb := utc.NewVirtualBench(utc.MustParse("2020-01-01"), time.Millisecond)
+//		limiter := NewLimiter(vb.Clock(), 100)
+//		vb.Run(b, b.N, func(i int) {
+//			limiter.Allow()
+//		})
+//	}
+//
+// Timers of the clock that are due after a step fire synchronously in the benchmark loop - see TestClock.AfterFunc -
+// and their cost is therefore part of the measurement, as is the (small) cost of advancing the clock.
+type VirtualBench struct {
+	clock TestClock
+	step  func(i int) time.Duration
+}
+
+// NewVirtualBench creates a VirtualBench with its clock set to the given start time, or to the current wall clock
+// rounded to the millisecond if Zero, and advancing the clock by the given step after each iteration.
+func NewVirtualBench(start UTC, step time.Duration) *VirtualBench {
+	if start.IsZero() {
+		start = WallNowMs()
+	}
+	return &VirtualBench{
+		clock: NewWallClock(start),
+		step:  func(int) time.Duration { return step },
+	}
+}
+
+// WithSchedule replaces the constant step with the given function, which returns the duration by which the clock is
+// advanced after iteration i. Use it to script bursts and pauses, e.g. to advance the clock by a second after every
+// hundredth iteration only. It returns the VirtualBench for chaining.
+func (v *VirtualBench) WithSchedule(step func(i int) time.Duration) *VirtualBench {
+	v.step = step
+	return v
+}
+
+// Clock returns the clock of the VirtualBench.
+func (v *VirtualBench) Clock() TestClock {
+	return v.clock
+}
+
+// Run installs the clock as global clock, resets the benchmark timer of b (a *testing.B) and calls fn n times (b.N),
+// advancing the clock after each call according to the schedule. The global clock is restored with UnmockNow when Run
+// returns. Run must not be called concurrently, e.g. from b.RunParallel.
+func (v *VirtualBench) Run(b interface{ ResetTimer() }, n int, fn func(i int)) {
+	v.clock.MockNow()
+	defer v.clock.UnmockNow()
+
+	b.ResetTimer()
+	for i := 0; i < n; i++ {
+		fn(i)
+		if d := v.step(i); d != 0 {
+			v.clock.Add(d)
+		}
+	}
+}
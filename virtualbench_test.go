@@ -0,0 +1,70 @@
+package utc_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+type benchTimer struct {
+	resets int
+}
+
+func (b *benchTimer) ResetTimer() {
+	b.resets++
+}
+
+func TestVirtualBench(t *testing.T) {
+	start := utc.MustParse("2020-01-01T00:00:00Z")
+	vb := utc.NewVirtualBench(start, time.Millisecond)
+	require.Equal(t, start, vb.Clock().Now())
+
+	b := &benchTimer{}
+	var seen []utc.UTC
+	vb.Run(b, 3, func(i int) {
+		seen = append(seen, utc.Now())
+	})
+	require.Equal(t, 1, b.resets)
+	require.Equal(t, []utc.UTC{start, start.Add(time.Millisecond), start.Add(2 * time.Millisecond)}, seen)
+	require.Equal(t, start.Add(3*time.Millisecond), vb.Clock().Now())
+
+	// the global clock is restored
+	require.False(t, vb.Clock().IsMock())
+	require.NotEqual(t, vb.Clock().Now(), utc.Now())
+
+	// timers fire within the loop
+	fired := 0
+	vb.Clock().AfterFunc(start.Add(5*time.Millisecond), func() { fired++ })
+	vb.Run(b, 2, func(i int) {})
+	require.Equal(t, 1, fired)
+}
+
+func TestVirtualBench_schedule(t *testing.T) {
+	start := utc.MustParse("2020-01-01T00:00:00Z")
+	vb := utc.NewVirtualBench(start, 0).WithSchedule(func(i int) time.Duration {
+		if i%10 == 9 {
+			return time.Second
+		}
+		return 0
+	})
+	vb.Run(&benchTimer{}, 100, func(i int) {})
+	require.Equal(t, start.Add(10*time.Second), vb.Clock().Now())
+
+	require.False(t, utc.NewVirtualBench(utc.Zero, 0).Clock().Now().IsZero())
+}
+
+func BenchmarkVirtualBench_TTLMap(b *testing.B) {
+	vb := utc.NewVirtualBench(utc.MustParse("2020-01-01T00:00:00Z"), time.Millisecond)
+	m := utc.NewTTLMap[int, int](vb.Clock())
+	vb.Run(b, b.N, func(i int) {
+		if _, ok := m.Get(i % 1000); !ok {
+			m.Set(i%1000, i, 100*time.Millisecond)
+		}
+		if i%1000 == 0 {
+			m.Purge()
+		}
+	})
+}
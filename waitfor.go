@@ -0,0 +1,99 @@
+package utc
+
+import (
+	"context"
+	"time"
+)
+
+// WaitForPollInterval is the interval at which WaitFor re-evaluates its predicate if the clock is not a TestClock that
+// is set to a specific time, e.g. on the real clock.
+const WaitForPollInterval = 10 * time.Millisecond
+
+// WaitFor blocks until the given predicate holds for the current time or the context is done. It returns nil if the
+// predicate holds, and the context's error otherwise. The predicate is evaluated immediately and then re-evaluated
+// whenever the clock changes, so that conditions like "wait until 02:00 on a weekday" can be tested without polling
+// loops:
+//
+//   - if a TestClock is the global clock, the predicate is re-evaluated each time the TestClock is set or advanced
+//     (Set, Add, CompareAndSet...), in the goroutine of WaitFor
+//   - otherwise, e.g. on the real clock, the predicate is re-evaluated every WaitForPollInterval
+//
+// The predicate must not block. Use WaitUntil in order to wait for a specific instant.
+func WaitFor(ctx context.Context, pred func(now UTC) bool) error {
+	return waitFor(ctx, nil, pred)
+}
+
+// WaitFor blocks until the given predicate holds for the time of this TestClock or the context is done - see
+// utc.WaitFor.
+func (c TestClock) WaitFor(ctx context.Context, pred func(now UTC) bool) error {
+	return waitFor(ctx, c, pred)
+}
+
+// WaitFor blocks until the given predicate holds for the time of the domain's clock or the context is done - see
+// utc.WaitFor.
+func (d *Domain) WaitFor(ctx context.Context, pred func(now UTC) bool) error {
+	return waitFor(ctx, d, pred)
+}
+
+// waitFor implements WaitFor for the given clock, or the global clock if nil.
+func waitFor(ctx context.Context, clock Clock, pred func(now UTC) bool) error {
+	var poll *time.Timer
+	defer func() {
+		if poll != nil {
+			poll.Stop()
+		}
+	}()
+	for {
+		// subscribe to changes before evaluating the predicate in order not to miss any change in between
+		var changed <-chan struct{}
+		tc, ok := testClockOf(clock)
+		if ok && tc.timers != nil {
+			changed = tc.timers.changes()
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if pred(clockNow(clock)) {
+			return nil
+		}
+
+		var polled <-chan time.Time
+		if !ok || tc.now.Load() == nil {
+			// the clock follows the wall clock
+			if poll == nil {
+				poll = time.NewTimer(WaitForPollInterval)
+			} else {
+				poll.Reset(WaitForPollInterval)
+			}
+			polled = poll.C
+		}
+		select {
+		case <-changed:
+		case <-polled:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if polled != nil && !poll.Stop() {
+			select {
+			case <-poll.C:
+			default:
+			}
+		}
+	}
+}
+
+// testClockOf returns the TestClock that determines the time of the given clock, or of the global clock if nil.
+func testClockOf(clock Clock) (TestClock, bool) {
+	switch c := clock.(type) {
+	case nil:
+		return mockedTestClock()
+	case TestClock:
+		return c, true
+	case *Domain:
+		if m := c.clock.Load(); m != nil {
+			return testClockOf(m.c)
+		}
+		return mockedTestClock()
+	}
+	return TestClock{}, false
+}
@@ -0,0 +1,77 @@
+package utc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+func TestWaitFor(t *testing.T) {
+	now := utc.MustParse("2020-01-01T22:00:00Z")
+	clock := utc.NewWallClock(now).MockNow()
+	defer clock.UnmockNow()
+
+	at2am := func(u utc.UTC) bool { return u.Hour() == 2 }
+
+	done := make(chan error)
+	go func() {
+		done <- utc.WaitFor(context.Background(), at2am)
+	}()
+
+	for i := 0; i < 3; i++ {
+		clock.Add(time.Hour)
+		select {
+		case <-done:
+			require.Fail(t, "returned too early", "now", clock.Now())
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	clock.Add(time.Hour)
+	require.NoError(t, <-done)
+
+	// returns immediately if the predicate holds
+	require.NoError(t, utc.WaitFor(context.Background(), at2am))
+}
+
+func TestWaitFor_context(t *testing.T) {
+	clock := utc.NewWallClock(utc.MustParse("2020-01-01T22:00:00Z"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		clock.Add(time.Hour)
+		cancel()
+	}()
+	err := clock.WaitFor(ctx, func(utc.UTC) bool { return false })
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestWaitFor_domain(t *testing.T) {
+	d := utc.NewDomain("test")
+	clock := utc.NewWallClock(utc.MustParse("2020-01-01T22:00:00Z"))
+	defer d.Mock(clock)()
+
+	target := clock.Now().Add(time.Hour)
+	done := make(chan error)
+	go func() {
+		done <- d.WaitFor(context.Background(), func(u utc.UTC) bool { return !u.Before(target) })
+	}()
+	clock.Add(30 * time.Minute)
+	clock.Add(30 * time.Minute)
+	require.NoError(t, <-done)
+}
+
+func TestWaitFor_realClock(t *testing.T) {
+	target := utc.Now().Add(30 * time.Millisecond)
+	err := utc.WaitFor(context.Background(), func(u utc.UTC) bool { return !u.Before(target) })
+	require.NoError(t, err)
+	require.False(t, utc.Now().Before(target))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	err = utc.WaitFor(ctx, func(utc.UTC) bool { return false })
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
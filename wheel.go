@@ -0,0 +1,312 @@
+package utc
+
+import (
+	"math/bits"
+	"sync"
+	"time"
+)
+
+const (
+	wheelBits   = 6
+	wheelSlots  = 1 << wheelBits
+	wheelMask   = wheelSlots - 1
+	wheelLevels = (64 + wheelBits - 1) / wheelBits
+)
+
+// TimerWheel is a hierarchical timing wheel for managing large numbers of pending timers, e.g. for session expiry. It
+// is an alternative to runtime timers (time.AfterFunc), which incur noticeable scheduler overhead at hundreds of
+// thousands of timers. Inserting and stopping a timer is O(1). The wheel uses a single timer of its clock, armed for
+// the earliest pending expiration.
+//
+// Expirations are rounded up to the wheel's tick: a timer fires at the first tick boundary at or after its instant.
+// The tick boundaries are multiples of the tick since the unix epoch.
+//
+// The wheel is driven by the given clock - see clockAfterFunc: with a TestClock, timers fire synchronously when the
+// clock is set or advanced, in the order of their expiration. With the real clock, timer functions are called one
+// after the other in the goroutine of the wheel's runtime timer, hence they should not block.
+type TimerWheel struct {
+	clock  Clock
+	period Period // the tick boundaries, with the origin at the wheel's creation
+
+	mu       sync.Mutex
+	current  uint64 // the current tick, relative to the origin
+	levels   [wheelLevels][wheelSlots]wheelList
+	count    int
+	gen      uint64      // generation of the driver timer
+	driver   func() bool // stops the driver timer
+	driverAt uint64      // the tick the driver timer is armed for
+	closed   bool
+}
+
+// WheelTimer is a timer of a TimerWheel.
+type WheelTimer struct {
+	wheel *TimerWheel
+	at    UTC
+	tick  uint64
+	fn    func()
+
+	list       *wheelList // the list containing the timer, nil if not pending
+	prev, next *WheelTimer
+}
+
+// wheelList is a doubly linked list of timers.
+type wheelList struct {
+	head, tail *WheelTimer
+}
+
+// NewTimerWheel creates a TimerWheel driven by the given clock, or by the global clock if nil, with the given tick. It
+// panics if the tick is not positive.
+func NewTimerWheel(clock Clock, tick time.Duration) *TimerWheel {
+	if tick <= 0 {
+		panic("utc.NewTimerWheel: non-positive tick")
+	}
+	return &TimerWheel{
+		clock:  clock,
+		period: NewPeriod(clockNow(clock).PrevAligned(tick), tick),
+	}
+}
+
+// AfterFunc registers fn to be called when the wheel's clock reaches the given instant (rounded up to the wheel's
+// tick). If the instant is not after the current tick, fn is called immediately in the calling goroutine. Timers
+// registered after Close never fire.
+func (w *TimerWheel) AfterFunc(at UTC, fn func()) *WheelTimer {
+	t := &WheelTimer{wheel: w, at: at, fn: fn}
+
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return t
+	}
+	t.tick = w.ceilTick(at)
+	if t.tick <= w.current {
+		w.mu.Unlock()
+		fn()
+		return t
+	}
+	w.place(t)
+	w.count++
+	arm := w.prepareDriver()
+	w.mu.Unlock()
+
+	arm()
+	return t
+}
+
+// At returns the instant the timer was registered for.
+func (t *WheelTimer) At() UTC {
+	return t.at
+}
+
+// Stop stops the timer. It returns true if the call prevented the timer function from being called, false if it was
+// already called or the timer was already stopped.
+func (t *WheelTimer) Stop() bool {
+	w := t.wheel
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if t.list == nil {
+		return false
+	}
+	t.list.remove(t)
+	w.count--
+	return true
+}
+
+// Len returns the number of pending timers.
+func (w *TimerWheel) Len() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.count
+}
+
+// Close stops the wheel: pending timers are discarded without being called.
+func (w *TimerWheel) Close() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.closed = true
+	w.stopDriver()
+	for l := range w.levels {
+		for s := range w.levels[l] {
+			for t := w.levels[l][s].head; t != nil; t = t.next {
+				t.list = nil
+			}
+			w.levels[l][s] = wheelList{}
+		}
+	}
+	w.count = 0
+}
+
+// Advance fires all timers that are due at the current time of the wheel's clock. Advance is called automatically by
+// the wheel's driver timer and does not need to be called explicitly, except for clocks without timer support.
+func (w *TimerWheel) Advance() {
+	target := w.period.OccurrenceIndex(clockNow(w.clock))
+
+	w.mu.Lock()
+	if w.closed || target < 0 || uint64(target) <= w.current {
+		w.mu.Unlock()
+		return
+	}
+	due := w.advance(uint64(target))
+	arm := w.prepareDriver()
+	w.mu.Unlock()
+
+	arm()
+	for _, t := range due {
+		t.fn()
+	}
+}
+
+// ceilTick returns the first tick at or after the given instant.
+func (w *TimerWheel) ceilTick(u UTC) uint64 {
+	i := w.period.OccurrenceIndex(u)
+	if i < 0 {
+		return 0
+	}
+	if !w.period.At(i).Equal(u) {
+		i++
+	}
+	return uint64(i)
+}
+
+// place adds the pending timer to the slot of its tick. Timers are placed in the level of the highest bit group in
+// which their tick differs from the current tick, hence all timers of a level are within the current rotation of the
+// next higher level and no slot contains timers of different rotations.
+func (w *TimerWheel) place(t *WheelTimer) {
+	level := (bits.Len64(t.tick^w.current) - 1) / wheelBits
+	slot := (t.tick >> (level * wheelBits)) & wheelMask
+	w.levels[level][slot].append(t)
+}
+
+// nextEvent returns the next tick at which a slot has to be processed: the start of the first non-empty slot after the
+// current tick.
+func (w *TimerWheel) nextEvent() (tick uint64, level int, ok bool) {
+	if w.count == 0 {
+		return 0, 0, false
+	}
+	for l := 0; l < wheelLevels; l++ {
+		shift := uint(l * wheelBits)
+		idx := (w.current >> shift) & wheelMask
+		for s := idx + 1; s < wheelSlots; s++ {
+			if w.levels[l][s].head != nil {
+				// the current tick with the bits of this level set to the slot and all lower bits cleared
+				high := uint64(0)
+				if shift+wheelBits < 64 {
+					high = w.current >> (shift + wheelBits) << (shift + wheelBits)
+				}
+				return high | s<<shift, l, true
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+// advance advances the current tick to the given target and returns the timers that became due, in the order of
+// their ticks.
+func (w *TimerWheel) advance(target uint64) []*WheelTimer {
+	var due []*WheelTimer
+	for {
+		tick, level, ok := w.nextEvent()
+		if !ok || tick > target {
+			w.current = target
+			return due
+		}
+		w.current = tick
+		slot := &w.levels[level][(tick>>(level*wheelBits))&wheelMask]
+		for t := slot.head; t != nil; {
+			next := t.next
+			slot.remove(t)
+			if t.tick <= w.current {
+				w.count--
+				due = append(due, t)
+			} else {
+				w.place(t)
+			}
+			t = next
+		}
+	}
+}
+
+// prepareDriver determines the tick for the driver timer and returns a function that arms the driver timer. The
+// function must be called without holding the lock, since timers of a TestClock may fire synchronously.
+func (w *TimerWheel) prepareDriver() (arm func()) {
+	tick, _, ok := w.nextEvent()
+	if !ok {
+		w.stopDriver()
+		return func() {}
+	}
+	if w.driver != nil && w.driverAt == tick {
+		return func() {}
+	}
+	w.stopDriver()
+	w.gen++
+	gen := w.gen
+	w.driverAt = tick
+	w.driver = func() bool { return false }
+	at := w.period.At(int64(tick))
+
+	return func() {
+		stop := clockAfterFunc(w.clock, at, func() { w.onDriver(gen) })
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		if w.gen == gen {
+			w.driver = stop
+		} else {
+			// the driver fired synchronously or was replaced in the meantime
+			stop()
+		}
+	}
+}
+
+func (w *TimerWheel) onDriver(gen uint64) {
+	w.mu.Lock()
+	if w.gen == gen {
+		w.gen++
+		w.driver = nil
+	}
+	w.mu.Unlock()
+	w.Advance()
+
+	// re-arm if nothing was due, e.g. if the driver fired early
+	w.mu.Lock()
+	var arm func()
+	if !w.closed && w.driver == nil {
+		arm = w.prepareDriver()
+	}
+	w.mu.Unlock()
+	if arm != nil {
+		arm()
+	}
+}
+
+func (w *TimerWheel) stopDriver() {
+	if w.driver != nil {
+		w.driver()
+		w.driver = nil
+	}
+	w.gen++
+}
+
+func (l *wheelList) append(t *WheelTimer) {
+	t.list = l
+	t.prev = l.tail
+	t.next = nil
+	if l.tail != nil {
+		l.tail.next = t
+	} else {
+		l.head = t
+	}
+	l.tail = t
+}
+
+func (l *wheelList) remove(t *WheelTimer) {
+	if t.prev != nil {
+		t.prev.next = t.next
+	} else {
+		l.head = t.next
+	}
+	if t.next != nil {
+		t.next.prev = t.prev
+	} else {
+		l.tail = t.prev
+	}
+	t.list, t.prev, t.next = nil, nil, nil
+}
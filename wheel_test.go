@@ -0,0 +1,170 @@
+package utc_test
+
+import (
+	"math/rand"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+func TestTimerWheel(t *testing.T) {
+	now := utc.MustParse("2020-01-01T10:00:00Z")
+	clock := utc.NewWallClock(now)
+	w := utc.NewTimerWheel(clock, time.Millisecond)
+
+	var fired []int
+	w.AfterFunc(now.Add(time.Second), func() { fired = append(fired, 1) })
+	w.AfterFunc(now.Add(time.Hour), func() { fired = append(fired, 3) })
+	w.AfterFunc(now.Add(time.Minute), func() { fired = append(fired, 2) })
+	stopped := w.AfterFunc(now.Add(time.Minute), func() { fired = append(fired, -1) })
+	require.Equal(t, 4, w.Len())
+
+	require.True(t, stopped.Stop())
+	require.False(t, stopped.Stop())
+	require.Equal(t, 3, w.Len())
+
+	clock.Add(999 * time.Millisecond)
+	require.Empty(t, fired)
+	clock.Add(time.Millisecond)
+	require.Equal(t, []int{1}, fired)
+
+	clock.Add(2 * time.Hour)
+	require.Equal(t, []int{1, 2, 3}, fired)
+	require.Equal(t, 0, w.Len())
+
+	// due timers fire immediately
+	w.AfterFunc(clock.Now(), func() { fired = append(fired, 4) })
+	require.Equal(t, []int{1, 2, 3, 4}, fired)
+
+	// expirations are rounded up to the tick
+	w.AfterFunc(clock.Now().Add(time.Microsecond), func() { fired = append(fired, 5) })
+	clock.Add(time.Microsecond)
+	require.Len(t, fired, 4)
+	clock.Add(999 * time.Microsecond)
+	require.Equal(t, []int{1, 2, 3, 4, 5}, fired)
+
+	// timers registered from timer functions
+	w.AfterFunc(clock.Now().Add(time.Second), func() {
+		w.AfterFunc(clock.Now().Add(time.Second), func() { fired = append(fired, 7) })
+		fired = append(fired, 6)
+	})
+	clock.Add(10 * time.Second)
+	require.Equal(t, []int{1, 2, 3, 4, 5, 6}, fired)
+	clock.Add(time.Second)
+	require.Equal(t, []int{1, 2, 3, 4, 5, 6, 7}, fired)
+
+	w.AfterFunc(clock.Now().Add(time.Second), func() { fired = append(fired, -1) })
+	w.Close()
+	require.Equal(t, 0, w.Len())
+	clock.Add(time.Hour)
+	w.AfterFunc(clock.Now().Add(time.Second), func() { fired = append(fired, -1) })
+	clock.Add(time.Hour)
+	require.Equal(t, []int{1, 2, 3, 4, 5, 6, 7}, fired)
+}
+
+func TestTimerWheel_many(t *testing.T) {
+	now := utc.MustParse("2020-01-01T10:00:00Z")
+	clock := utc.NewWallClock(now)
+	w := utc.NewTimerWheel(clock, time.Millisecond)
+	rnd := rand.New(rand.NewSource(1))
+
+	const n = 100_000
+	type rec struct {
+		at    utc.UTC
+		fired utc.UTC
+	}
+	recs := make([]*rec, n)
+	timers := make([]*utc.WheelTimer, n)
+	var order []*rec
+	for i := range recs {
+		r := &rec{at: now.Add(utc.RandDuration(rnd, 0, 400*24*time.Hour).Truncate(time.Millisecond))}
+		recs[i] = r
+		timers[i] = w.AfterFunc(r.at, func() {
+			r.fired = clock.Now()
+			order = append(order, r)
+		})
+	}
+
+	// stop every tenth timer
+	stopped := 0
+	for i := 0; i < n; i += 10 {
+		if timers[i].Stop() {
+			stopped++
+		}
+	}
+	require.Equal(t, n-stopped, w.Len())
+
+	for clock.Now().Before(now.Add(401 * 24 * time.Hour)) {
+		clock.Add(utc.RandDuration(rnd, time.Millisecond, 3*time.Hour))
+	}
+	require.Equal(t, 0, w.Len())
+	require.Len(t, order, n-stopped)
+	require.True(t, sort.SliceIsSorted(order, func(i, j int) bool { return order[i].at.Before(order[j].at) }))
+	for i, r := range recs {
+		if i%10 == 0 {
+			require.True(t, r.fired.IsZero())
+			continue
+		}
+		require.False(t, r.fired.Before(r.at))
+	}
+}
+
+func TestTimerWheel_exact(t *testing.T) {
+	now := utc.MustParse("2020-01-01T10:00:00Z")
+	clock := utc.NewWallClock(now)
+	w := utc.NewTimerWheel(clock, time.Second)
+
+	// each timer fires exactly when the clock reaches it
+	rnd := rand.New(rand.NewSource(2))
+	for i := 0; i < 1000; i++ {
+		at := clock.Now().Add(utc.RandDuration(rnd, time.Second, 1000*time.Hour).Truncate(time.Second))
+		var fired utc.UTC
+		w.AfterFunc(at, func() { fired = clock.Now() })
+		clock.Set(at.Add(-time.Second))
+		require.True(t, fired.IsZero())
+		clock.Set(at)
+		require.Equal(t, at, fired)
+	}
+}
+
+func TestTimerWheel_realClock(t *testing.T) {
+	w := utc.NewTimerWheel(nil, time.Millisecond)
+	defer w.Close()
+
+	var count atomic.Int32
+	wg := sync.WaitGroup{}
+	for i := 1; i <= 20; i++ {
+		wg.Add(1)
+		w.AfterFunc(utc.Now().Add(time.Duration(i)*time.Millisecond), func() {
+			count.Add(1)
+			wg.Done()
+		})
+	}
+	wg.Wait()
+	require.Equal(t, int32(20), count.Load())
+	require.Equal(t, 0, w.Len())
+}
+
+func BenchmarkTimerWheel(b *testing.B) {
+	clock := utc.NewWallClock(utc.MustParse("2020-01-01T10:00:00Z"))
+	w := utc.NewTimerWheel(clock, time.Millisecond)
+	rnd := rand.New(rand.NewSource(1))
+	timers := make([]*utc.WheelTimer, b.N)
+	at := make([]utc.UTC, b.N)
+	for i := range at {
+		at[i] = clock.Now().Add(utc.RandDuration(rnd, time.Second, 24*time.Hour))
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		timers[i] = w.AfterFunc(at[i], func() {})
+	}
+	for i := 0; i < b.N; i++ {
+		timers[i].Stop()
+	}
+}
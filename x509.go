@@ -0,0 +1,40 @@
+package utc
+
+import (
+	"crypto/x509"
+	"time"
+)
+
+// CertValidity returns the validity period of the given certificate as Range. Since X.509 validity is inclusive of
+// NotAfter and has a precision of seconds, the end of the range is one second after NotAfter.
+func CertValidity(cert *x509.Certificate) Range {
+	return Range{Start: New(cert.NotBefore), End: New(cert.NotAfter).Add(time.Second)}
+}
+
+// CertRemainingValidity returns the remaining validity of the given certificate at the current time of the given
+// clock (or utc.Now() if nil), or 0 if the certificate has expired. The remaining validity of a certificate that is
+// not yet valid is its full validity period.
+func CertRemainingValidity(cert *x509.Certificate, clock Clock) time.Duration {
+	validity := CertValidity(cert)
+	now := clockNow(clock)
+	if now.Before(validity.Start) {
+		now = validity.Start
+	}
+	if d := validity.End.Sub(now); d > 0 {
+		return d
+	}
+	return 0
+}
+
+// CertRenewalTime returns the time at which the given certificate should be renewed, such that the given fraction of
+// its validity period remains, e.g. 1.0/3 for renewing with a third of the lifetime remaining.
+func CertRenewalTime(cert *x509.Certificate, remainingFraction float64) UTC {
+	validity := CertValidity(cert)
+	return validity.End.Add(-time.Duration(float64(validity.Duration()) * remainingFraction))
+}
+
+// CertNeedsRenewal returns true if the renewal time of the given certificate has been reached at the current time of
+// the given clock (or utc.Now() if nil) - see CertRenewalTime.
+func CertNeedsRenewal(cert *x509.Certificate, remainingFraction float64, clock Clock) bool {
+	return !clockNow(clock).Before(CertRenewalTime(cert, remainingFraction))
+}
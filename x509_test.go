@@ -0,0 +1,41 @@
+package utc_test
+
+import (
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+func TestCertValidity(t *testing.T) {
+	cert := &x509.Certificate{
+		NotBefore: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:  time.Date(2020, 3, 31, 23, 59, 59, 0, time.UTC), // 90 days
+	}
+
+	r := utc.CertValidity(cert)
+	require.Equal(t, "2020-01-01T00:00:00.000Z", r.Start.String())
+	require.Equal(t, "2020-04-01T00:00:00.000Z", r.End.String())
+	require.Equal(t, 91*24*time.Hour, r.Duration())
+
+	clock := utc.NewWallClock(utc.MustParse("2019-12-01T00:00:00Z"))
+	require.Equal(t, 91*24*time.Hour, utc.CertRemainingValidity(cert, clock))
+
+	clock.Set(utc.MustParse("2020-03-31T00:00:00Z"))
+	require.Equal(t, 24*time.Hour, utc.CertRemainingValidity(cert, clock))
+	clock.Set(utc.MustParse("2020-03-31T23:59:59.5Z"))
+	require.Equal(t, 500*time.Millisecond, utc.CertRemainingValidity(cert, clock))
+	clock.Set(utc.MustParse("2020-04-01T00:00:00Z"))
+	require.Equal(t, time.Duration(0), utc.CertRemainingValidity(cert, clock))
+
+	// renew with a third of the lifetime remaining
+	renewal := utc.CertRenewalTime(cert, 1.0/3)
+	require.Equal(t, "2020-03-01T16:00:00.000Z", renewal.String())
+	clock.Set(renewal.Add(-time.Second))
+	require.False(t, utc.CertNeedsRenewal(cert, 1.0/3, clock))
+	clock.Set(renewal)
+	require.True(t, utc.CertNeedsRenewal(cert, 1.0/3, clock))
+}
@@ -0,0 +1,38 @@
+package utc
+
+// ExplicitZero is a UTC that marshals the zero value like any other value: as 0001-01-01T00:00:00.000Z in text and JSON
+// and as a regular 9-byte binary encoding. This allows to round-trip the zero value through systems that treat empty
+// values as absent. Unmarshaling is the same as for UTC and therefore still accepts the empty representation.
+type ExplicitZero struct {
+	UTC
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (u ExplicitZero) MarshalJSON() ([]byte, error) {
+	b, err := u.AppendText(append(make([]byte, 0, len(iso8601Template)+2), '"'))
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '"'), nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (u ExplicitZero) MarshalText() ([]byte, error) {
+	return u.AppendText(make([]byte, 0, len(iso8601Template)))
+}
+
+// AppendText implements the encoding.TextAppender interface.
+func (u ExplicitZero) AppendText(b []byte) ([]byte, error) {
+	if err := u.ValidateISO8601(); err != nil {
+		return nil, err
+	}
+	return u.appendISO8601(b), nil
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (u ExplicitZero) MarshalBinary() ([]byte, error) {
+	if err := u.ValidateISO8601(); err != nil {
+		return nil, err
+	}
+	return u.encodeBinary(), nil
+}
@@ -0,0 +1,22 @@
+//go:build go1.24
+
+package utc_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+func TestOmitZero(t *testing.T) {
+	type withDates struct {
+		Zero utc.UTC `json:"zero,omitzero"`
+		Min  utc.UTC `json:"min,omitzero"`
+	}
+	jsn, err := json.Marshal(withDates{Min: utc.Min})
+	require.NoError(t, err)
+	require.Equal(t, `{"min":"0000-01-01T00:00:00.000Z"}`, string(jsn))
+}
@@ -0,0 +1,82 @@
+package utc_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+// TestZeroContract verifies the documented marshaling contract for the zero value across JSON, text and binary.
+func TestZeroContract(t *testing.T) {
+	jsn, err := json.Marshal(utc.Zero)
+	require.NoError(t, err)
+	require.Equal(t, `""`, string(jsn))
+
+	txt, err := utc.Zero.MarshalText()
+	require.NoError(t, err)
+	require.Empty(t, txt)
+
+	bin, err := utc.Zero.MarshalBinary()
+	require.NoError(t, err)
+	require.Empty(t, bin)
+
+	for name, unmarshal := range map[string]func(u *utc.UTC) error{
+		"json":   func(u *utc.UTC) error { return json.Unmarshal(jsn, u) },
+		"text":   func(u *utc.UTC) error { return u.UnmarshalText(txt) },
+		"binary": func(u *utc.UTC) error { return u.UnmarshalBinary(bin) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			u := utc.Now()
+			require.NoError(t, unmarshal(&u))
+			require.True(t, u.IsZero())
+			require.Equal(t, utc.Zero, u)
+		})
+	}
+
+	// Min is distinct from Zero and round-trips like any other value
+	require.False(t, utc.Min.IsZero())
+	jsn, err = json.Marshal(utc.Min)
+	require.NoError(t, err)
+	require.Equal(t, `"0000-01-01T00:00:00.000Z"`, string(jsn))
+	var u utc.UTC
+	require.NoError(t, json.Unmarshal(jsn, &u))
+	require.True(t, utc.Min.Equal(u))
+	bin, err = utc.Min.MarshalBinary()
+	require.NoError(t, err)
+	require.Len(t, bin, 9)
+	require.NoError(t, u.UnmarshalBinary(bin))
+	require.True(t, utc.Min.Equal(u))
+}
+
+func TestExplicitZero(t *testing.T) {
+	zero := utc.ExplicitZero{}
+
+	jsn, err := json.Marshal(zero)
+	require.NoError(t, err)
+	require.Equal(t, `"0001-01-01T00:00:00.000Z"`, string(jsn))
+
+	txt, err := zero.MarshalText()
+	require.NoError(t, err)
+	require.Equal(t, "0001-01-01T00:00:00.000Z", string(txt))
+
+	bin, err := zero.MarshalBinary()
+	require.NoError(t, err)
+	require.Len(t, bin, 9)
+
+	var res utc.ExplicitZero
+	require.NoError(t, json.Unmarshal(jsn, &res))
+	require.True(t, res.IsZero())
+	require.NoError(t, res.UnmarshalText(txt))
+	require.True(t, res.IsZero())
+	require.NoError(t, res.UnmarshalBinary(bin))
+	require.True(t, res.IsZero())
+
+	now := utc.ExplicitZero{UTC: utc.WallNowMs()}
+	jsn, err = json.Marshal(now)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(jsn, &res))
+	require.True(t, now.Equal(res.UTC))
+}
@@ -0,0 +1,174 @@
+package utc
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/eluv-io/errors-go"
+)
+
+// Zoned is a UTC instant that additionally retains the timezone offset it was created or parsed with. It is meant for
+// display purposes, e.g. for APIs that need to echo back a timestamp with the client's original offset:
+//
+//   - String, MarshalText and MarshalJSON format the instant in the original offset:
+//     2006-01-02T17:04:05.000+02:00
+//   - comparisons (Equal, Before, After, Sub...) and sorting operate on the embedded UTC instant and ignore the offset
+//
+// The zero value of Zoned is the zero UTC with offset 0.
+type Zoned struct {
+	UTC
+	offset int // offset in seconds east of UTC
+}
+
+// NewZoned creates a Zoned from the given time, retaining the time's timezone offset.
+func NewZoned(t time.Time) Zoned {
+	_, offset := t.Zone()
+	return Zoned{UTC: New(t), offset: offset}
+}
+
+// ParseZoned parses the given ISO 8601 time string like FromString, but retains the timezone offset of the string.
+// Strings without timezone are interpreted as UTC. Like FromString, leap seconds are handled according to the current
+// LeapSecondPolicy.
+func ParseZoned(s string) (Zoned, error) {
+	if s == "" {
+		return Zoned{}, nil
+	}
+	t, err := parseTime(s)
+	if err != nil {
+		if u, offset, ok, lerr := parseLeapSecond(s); ok {
+			if lerr != nil {
+				return Zoned{}, errors.E("ParseZoned", errors.K.Invalid, lerr)
+			}
+			return u.WithOffset(offset), nil
+		}
+		return Zoned{}, errors.E("ParseZoned", errors.K.Invalid, WrapSentinel(ErrParse, err), "utc", s)
+	}
+	_, offset := t.Zone()
+	return smearPrecedingSecond(New(t)).WithOffset(offset), nil
+}
+
+// WithOffset returns a Zoned for this UTC instant and the given offset in seconds east of UTC.
+func (u UTC) WithOffset(offset int) Zoned {
+	return Zoned{UTC: u, offset: offset}
+}
+
+// Offset returns the retained timezone offset in seconds east of UTC.
+func (z Zoned) Offset() int {
+	return z.offset
+}
+
+// ZonedTime returns the instant as time.Time in a fixed timezone with the retained offset.
+func (z Zoned) ZonedTime() time.Time {
+	return z.UTC.Time.In(time.FixedZone("", z.offset))
+}
+
+// String returns the time formatted in ISO 8601 format with the retained offset: 2006-01-02T15:04:05.000-07:00. If
+// the offset is 0, the format is the same as UTC.String().
+func (z Zoned) String() string {
+	if z.offset == 0 {
+		return z.UTC.String()
+	}
+	return z.ZonedTime().Format(ISO8601)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (z Zoned) MarshalText() ([]byte, error) {
+	if z.IsZero() {
+		return nil, nil
+	}
+	return z.AppendText(make([]byte, 0, len(ISO8601)))
+}
+
+// AppendText implements the encoding.TextAppender interface.
+func (z Zoned) AppendText(b []byte) ([]byte, error) {
+	if z.IsZero() {
+		return b, nil
+	}
+	if err := z.ValidateISO8601(); err != nil {
+		return nil, err
+	}
+	if z.offset == 0 {
+		return z.UTC.appendISO8601(b), nil
+	}
+	return z.ZonedTime().AppendFormat(b, ISO8601), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (z *Zoned) UnmarshalText(data []byte) error {
+	res, err := ParseZoned(string(data))
+	if err != nil {
+		return err
+	}
+	*z = res
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (z Zoned) MarshalJSON() ([]byte, error) {
+	if z.IsZero() {
+		return []byte(`""`), nil
+	}
+	b, err := z.AppendText(append(make([]byte, 0, len(ISO8601)+2), '"'))
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '"'), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. Like UTC.UnmarshalJSON, JSON null is a no-op and
+// surrounding whitespace within the quotes is ignored.
+func (z *Zoned) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	err := json.Unmarshal(data, &s)
+	if err != nil {
+		return errors.E("Zoned.UnmarshalJSON", errors.K.Invalid, WrapSentinel(ErrParse, err))
+	}
+	return z.UnmarshalText([]byte(strings.TrimSpace(s)))
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface. The binary form is the binary form of the UTC
+// instant followed by four bytes with the offset in seconds.
+func (z Zoned) MarshalBinary() ([]byte, error) {
+	enc, err := z.UTC.MarshalBinary()
+	if err != nil || enc == nil {
+		return enc, err
+	}
+	if z.offset < math.MinInt32 || z.offset > math.MaxInt32 {
+		return nil, errors.E("Zoned.MarshalBinary", errors.K.Invalid, ErrOutOfRange,
+			"reason", "offset out of range",
+			"offset", z.offset)
+	}
+	offset := uint32(int32(z.offset))
+	return append(enc, byte(offset>>24), byte(offset>>16), byte(offset>>8), byte(offset)), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface. Besides the binary form of MarshalBinary, it
+// accepts the previous binary form with two bytes for the offset in minutes.
+func (z *Zoned) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		*z = Zoned{}
+		return nil
+	}
+	if len(data) != 13 && len(data) != 11 {
+		return errors.E("Zoned.UnmarshalBinary", errors.K.Invalid, ErrInvalidLength,
+			"reason", "invalid length (expected 13)",
+			"length", len(data))
+	}
+	var u UTC
+	if err := u.UnmarshalBinary(data[:9]); err != nil {
+		return err
+	}
+	var offset int
+	if len(data) == 11 {
+		offset = int(int16(data[10])|int16(data[9])<<8) * 60
+	} else {
+		offset = int(int32(uint32(data[9])<<24 | uint32(data[10])<<16 | uint32(data[11])<<8 | uint32(data[12])))
+	}
+	*z = Zoned{UTC: u, offset: offset}
+	return nil
+}
@@ -0,0 +1,142 @@
+package utc_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eluv-io/utc-go"
+)
+
+func TestParseZoned(t *testing.T) {
+	tests := []struct {
+		s      string
+		want   string
+		offset int
+		utc    string
+	}{
+		{"2001-09-09T03:46:40.000+02:00", "2001-09-09T03:46:40.000+02:00", 7200, oneBillionString},
+		{"2001-09-09T03:46:40+02:00", "2001-09-09T03:46:40.000+02:00", 7200, oneBillionString},
+		{"2001-09-08T20:16:40.000-05:30", "2001-09-08T20:16:40.000-05:30", -19800, oneBillionString},
+		{"2001-09-09T01:46:40.000Z", oneBillionString, 0, oneBillionString},
+		{"2001-09-09T01:46:40", oneBillionString, 0, oneBillionString},
+	}
+	for _, test := range tests {
+		t.Run(test.s, func(t *testing.T) {
+			z, err := utc.ParseZoned(test.s)
+			require.NoError(t, err)
+			assert.Equal(t, test.want, z.String())
+			assert.Equal(t, test.offset, z.Offset())
+			assert.Equal(t, test.utc, z.UTC.String())
+			assertTimezone(t, z.UTC)
+		})
+	}
+
+	_, err := utc.ParseZoned("2001-09-09 01:46")
+	require.Error(t, err)
+}
+
+func TestParseZoned_leapSecond(t *testing.T) {
+	defer utc.SetLeapSecondPolicy(utc.GetLeapSecondPolicy())
+
+	tests := []struct {
+		s      string
+		policy utc.LeapSecondPolicy
+		want   string
+	}{
+		{"2017-01-01T00:59:60.500+01:00", utc.LeapSecondClamp, "2016-12-31T23:59:59.999999999Z"},
+		{"2016-12-31T23:59:60.500Z", utc.LeapSecondSmear, "2016-12-31T23:59:59.75Z"},
+		{"2017-01-01T00:59:59.800+01:00", utc.LeapSecondSmear, "2016-12-31T23:59:59.4Z"},
+		{"2017-01-01T00:59:60.250+01:00", utc.LeapSecondRollover, "2017-01-01T00:00:00.25Z"},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprint(test.s, "_", test.policy), func(t *testing.T) {
+			utc.SetLeapSecondPolicy(test.policy)
+			u, err := utc.FromString(test.s)
+			require.NoError(t, err)
+			z, err := utc.ParseZoned(test.s)
+			require.NoError(t, err)
+			require.Equal(t, test.want, z.UTC.Format(time.RFC3339Nano))
+			require.True(t, u.Equal(z.UTC))
+			if test.s[len(test.s)-1] != 'Z' {
+				require.Equal(t, 3600, z.Offset())
+			}
+		})
+	}
+
+	utc.SetLeapSecondPolicy(utc.LeapSecondReject)
+	_, err := utc.ParseZoned("2016-12-31T23:59:60Z")
+	require.Error(t, err)
+	require.ErrorIs(t, err, utc.ErrParse)
+}
+
+func TestZoned_Compare(t *testing.T) {
+	a := utc.MustParse(oneBillionString).WithOffset(3600)
+	b := utc.MustParse(oneBillionString).WithOffset(-3600)
+	c := utc.NewZoned(oneBillion.Add(time.Second).In(time.FixedZone("X", 7200)))
+
+	require.True(t, a.Equal(b.UTC))
+	require.NotEqual(t, a.String(), b.String())
+	require.True(t, c.After(a.UTC))
+
+	zs := []utc.Zoned{c, b, a}
+	sort.Slice(zs, func(i, j int) bool { return zs[i].Before(zs[j].UTC) })
+	require.Equal(t, c, zs[2])
+}
+
+func TestZoned_Marshal(t *testing.T) {
+	vals := []utc.Zoned{
+		{},
+		utc.MustParse(oneBillionString).WithOffset(3600),
+		utc.MustParse(oneBillionString).WithOffset(-9000),
+		utc.MustParse(oneBillionString).WithOffset(0),
+	}
+	for _, val := range vals {
+		t.Run(val.String(), func(t *testing.T) {
+			bts, err := json.Marshal(val)
+			require.NoError(t, err)
+			var z utc.Zoned
+			require.NoError(t, json.Unmarshal(bts, &z))
+			require.True(t, val.Equal(z.UTC))
+			require.Equal(t, val.Offset(), z.Offset())
+			require.Equal(t, val.String(), z.String())
+
+			bts, err = val.MarshalBinary()
+			require.NoError(t, err)
+			z = utc.Zoned{}
+			require.NoError(t, z.UnmarshalBinary(bts))
+			require.True(t, val.Equal(z.UTC))
+			require.Equal(t, val.Offset(), z.Offset())
+		})
+	}
+
+	bts, err := json.Marshal(utc.MustParse(oneBillionString).WithOffset(7200))
+	require.NoError(t, err)
+	require.Equal(t, `"2001-09-09T03:46:40.000+02:00"`, string(bts))
+
+	// sub-minute offsets (e.g. of historical zones) survive the binary round trip
+	for _, offset := range []int{-(4*3600 + 56*60 + 2), -1, 59} {
+		val := utc.MustParse(oneBillionString).WithOffset(offset)
+		bts, err = val.MarshalBinary()
+		require.NoError(t, err)
+		var z utc.Zoned
+		require.NoError(t, z.UnmarshalBinary(bts))
+		require.True(t, val.Equal(z.UTC))
+		require.Equal(t, offset, z.Offset())
+	}
+
+	var z utc.Zoned
+	require.Error(t, z.UnmarshalBinary([]byte{1, 2, 3}))
+
+	// previous binary form with the offset in minutes
+	bts, err = utc.MustParse(oneBillionString).MarshalBinary()
+	require.NoError(t, err)
+	require.NoError(t, z.UnmarshalBinary(append(bts, 0xff, 0x6a))) // -150 minutes
+	require.Equal(t, -9000, z.Offset())
+	require.Equal(t, oneBillionString, z.UTC.String())
+}